@@ -25,6 +25,10 @@ BINDING_DIR="${CONTRACTS_DIR}/bindings"
 L1_CONTRACTS_DIR="${CONTRACTS_DIR}/src/l1-contracts"
 L2_CONTRACTS_DIR="${CONTRACTS_DIR}/src/l2-contracts"
 
+# ROLAID protocol contracts are built by `forge build` at the repository root
+PROTOCOL_OUT_DIR="$(dirname "${PROJECT_ROOT}")/out"
+PROTOCOL_L1_CONTRACTS=(AuctionService SettlementVault AttestationRegistry LVRAuctionHook)
+
 # Clean and recreate bindings directory
 rm -rf "${BINDING_DIR}"
 mkdir -p "${BINDING_DIR}"
@@ -33,11 +37,12 @@ mkdir -p "${BINDING_DIR}"
 generate_binding() {
     local contract_name=$1
     local contract_type=$2  # l1 or l2
-    local json_path="${DEVKIT_CONTRACTS_DIR}/out/${contract_name}.sol/${contract_name}.json"
+    local out_dir=${3:-"${DEVKIT_CONTRACTS_DIR}/out"}
+    local json_path="${out_dir}/${contract_name}.sol/${contract_name}.json"
 
     if [ ! -f "$json_path" ]; then
         error "Contract JSON not found: $json_path"
-        error "Please run 'devkit avs build' first"
+        error "Please run 'devkit avs build' (or 'forge build' at the repository root) first"
         return 1
     fi
 
@@ -92,5 +97,10 @@ if [ -d "$L2_CONTRACTS_DIR" ]; then
     done
 fi
 
+# Generate bindings for ROLAID protocol contracts
+for contract_name in "${PROTOCOL_L1_CONTRACTS[@]}"; do
+    generate_binding "$contract_name" "l1" "$PROTOCOL_OUT_DIR"
+done
+
 log "Binding generation complete!"
 log "Generated bindings are in: ${BINDING_DIR}/"
\ No newline at end of file
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/auction"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lifecycle"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lvr"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/preflight"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// auctionSettlementHandler produces the settlement commitment for an AuctionService auction.
+type auctionSettlementHandler struct {
+	tw *TaskWorker
+}
+
+func newAuctionSettlementHandler(tw *TaskWorker) *auctionSettlementHandler {
+	return &auctionSettlementHandler{tw: tw}
+}
+
+func (h *auctionSettlementHandler) Kind() string       { return KindAuctionSettlement }
+func (h *auctionSettlementHandler) PayloadKey() string { return "auction" }
+
+func (h *auctionSettlementHandler) SpanAttributes(payload interface{}) []attribute.KeyValue {
+	a, err := h.task(payload)
+	if err != nil {
+		return nil
+	}
+	return []attribute.KeyValue{attrAuctionId.Int64(int64(a.AuctionId))}
+}
+
+func (h *auctionSettlementHandler) Decode(version uint32, raw json.RawMessage) (interface{}, error) {
+	switch version {
+	case EnvelopeVersionV1:
+		var a AuctionTask
+		if err := decodeStrict(raw, &a); err != nil {
+			return nil, fieldErrorf(h.PayloadKey(), "%v", err)
+		}
+		if err := validateAuctionV1(&a); err != nil {
+			return nil, err
+		}
+		return &a, nil
+	default:
+		return nil, unsupportedVersion(h.Kind(), version)
+	}
+}
+
+func (h *auctionSettlementHandler) Validate(ctx context.Context, payload interface{}) (err error) {
+	a, err := h.task(payload)
+	if err != nil {
+		return err
+	}
+	defer func() { h.record(ctx, a, opValidate, nil, err) }()
+	auctionService := common.HexToAddress(a.AuctionService)
+	if err := h.tw.checkTaskContract(ContractAuctionService, "auction.auction_service", auctionService); err != nil {
+		return err
+	}
+	if a.LVRAuctionHook != "" {
+		hook := common.HexToAddress(a.LVRAuctionHook)
+		if err := h.tw.checkTaskContract(ContractLVRAuctionHook, "auction.lvr_auction_hook", hook); err != nil {
+			return err
+		}
+	}
+	if err := h.tw.replay.Check(auctionService, a.AuctionId, a.SubmissionNonce); err != nil {
+		return classFieldErrorf(err, "auction.submission_nonce", "%v", err)
+	}
+	if a.SealedBids != nil {
+		if _, err := h.outcome(a); err != nil {
+			return err
+		}
+	}
+	return h.preflight(ctx, auctionService, a)
+}
+
+// preflight checks the auction against AuctionService state so operators do not sign
+// results that submitSettlement would reject. Skipped when no L1 client is configured.
+//
+// The checks read the head, never the task's reference_block: they decide whether the
+// auction can still be settled, and a stale or replayed envelope must not pass them by
+// naming a block from before the settlement or the end of the window.
+func (h *auctionSettlementHandler) preflight(ctx context.Context, auctionService common.Address, a *AuctionTask) error {
+	if h.tw.l1Client == nil {
+		h.tw.logger.Sugar().Warnw("Skipping auction preflight, no L1 client configured", "auction_id", a.AuctionId)
+		return nil
+	}
+	state, err := preflight.CheckAuction(ctx, h.tw.criticalL1(), preflight.AuctionCheck{
+		AuctionService: auctionService,
+		AuctionId:      new(big.Int).SetUint64(a.AuctionId),
+		OracleUpdateId: common.HexToHash(a.OracleUpdateId),
+		AppId:          common.HexToHash(a.AppId),
+		ImageDigest:    common.HexToHash(a.ImageDigest),
+	})
+	switch {
+	case errors.Is(err, preflight.ErrOracleMismatch):
+		return classFieldErrorf(err, "auction.oracle_update_id", "%v", err)
+	case errors.Is(err, preflight.ErrAttestationFails):
+		return classFieldErrorf(err, "auction.app_id", "%v", err)
+	case errors.Is(err, preflight.ErrUnknownAuction),
+		errors.Is(err, preflight.ErrAuctionSettled),
+		errors.Is(err, preflight.ErrOutsideWindow):
+		return classFieldErrorf(err, "auction.auction_id", "%v", err)
+	case err != nil:
+		return fmt.Errorf("auction preflight: %w", err)
+	}
+	if b := a.SealedBids; b != nil && (b.StartTime != state.StartTime || b.EndTime != state.EndTime) {
+		return fieldErrorf("auction.sealed_bids", "window [%d, %d] does not match onchain [%d, %d]",
+			b.StartTime, b.EndTime, state.StartTime, state.EndTime)
+	}
+	if b := a.SealedBids; b != nil {
+		chainId, err := h.tw.l1Client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("auction preflight: chain id: %w", err)
+		}
+		if chainId.Cmp(new(big.Int).SetUint64(b.ChainId)) != 0 {
+			return fieldErrorf("auction.sealed_bids.chain_id", "%d does not match L1 chain %s", b.ChainId, chainId)
+		}
+	}
+	h.tw.logger.Sugar().Debugw("Auction preflight passed",
+		"auction_id", a.AuctionId,
+		"block", state.BlockNumber,
+	)
+	return nil
+}
+
+func (h *auctionSettlementHandler) Handle(ctx context.Context, payload interface{}) (_ []byte, err error) {
+	a, err := h.task(payload)
+	if err != nil {
+		return nil, err
+	}
+	var chosen *lifecycle.Result
+	defer func() { h.record(ctx, a, opHandle, chosen, err) }()
+	h.tw.logger.Sugar().Infow("Auction settlement task",
+		"auction_id", a.AuctionId,
+		"pool_id", a.PoolId,
+		"oracle_update_id", a.OracleUpdateId,
+	)
+
+	// Everything below depends only on the task and chain state at its reference block,
+	// so every operator signs the same bytes.
+	auctionService := common.HexToAddress(a.AuctionService)
+	settlementData, bid, winner, err := h.settlement(a)
+	if err != nil {
+		return nil, err
+	}
+	lvrEstimate, err := h.lvrEstimate(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	auctionId := new(big.Int).SetUint64(a.AuctionId)
+	poolId := common.HexToHash(a.PoolId)
+	oracleUpdateId := common.HexToHash(a.OracleUpdateId)
+
+	// Recomputable onchain from AuctionService.auctions(id).settlementHash.
+	settlementCommitment := commitment.AuctionSettlement(auctionId, poolId, oracleUpdateId, commitment.SettlementHash(settlementData))
+	result, err := results.EncodeAuctionSettlement(&results.AuctionSettlementResult{
+		AuctionId:      auctionId,
+		PoolId:         poolId,
+		OracleUpdateId: oracleUpdateId,
+		Commitment:     settlementCommitment,
+		AuctionService: auctionService,
+		BidAmount:      bid.Big(),
+		Winner:         winner,
+		// Zero when the task carries no LP split.
+		DistributionRoot: common.HexToHash(a.DistributionRoot),
+		LvrEstimate:      lvrEstimate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the nonce only once a result exists, so a failed attempt can be retried.
+	// Accept re-checks atomically, rejecting a duplicate that raced past Validate.
+	if err := h.tw.replay.Accept(auctionService, a.AuctionId, a.SubmissionNonce); err != nil {
+		return nil, classFieldErrorf(err, "auction.submission_nonce", "%v", err)
+	}
+	chosen = &lifecycle.Result{
+		TaskId:      taskIdFrom(ctx),
+		Winner:      winner,
+		BidAmount:   bid.Big(),
+		Commitment:  settlementCommitment,
+		LvrEstimate: lvrEstimate,
+	}
+	return result, nil
+}
+
+// lvrEstimate estimates the pool arbitrage the auction sells, from the task's oracle
+// price and the pool state at its reference block, read through the task's hook; zero
+// when the task carries no oracle price. The estimate is signed, so a worker without an
+// L1 client fails the task rather than sign a different result.
+func (h *auctionSettlementHandler) lvrEstimate(ctx context.Context, a *AuctionTask) (*big.Int, error) {
+	if a.OracleSqrtPriceX96 == "" {
+		return new(big.Int), nil
+	}
+	hookAddr := common.HexToAddress(a.LVRAuctionHook)
+	if err := h.tw.checkTaskContract(ContractLVRAuctionHook, "auction.lvr_auction_hook", hookAddr); err != nil {
+		return nil, err
+	}
+	if err := h.tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	backend := h.tw.criticalL1()
+	hook, err := lvrauctionhook.NewLVRAuctionHookCaller(hookAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	block := new(big.Int).SetUint64(a.ReferenceBlock)
+	poolManager, err := hook.PoolManager(&bind.CallOpts{Context: ctx, BlockNumber: block})
+	if err != nil {
+		return nil, fmt.Errorf("read poolManager: %w", err)
+	}
+	oracle, _ := new(big.Int).SetString(a.OracleSqrtPriceX96, 10)
+	e, err := lvr.EstimateAt(ctx, backend, poolManager, common.HexToHash(a.PoolId), a.TickSpacing, oracle, block)
+	switch {
+	case errors.Is(err, lvr.ErrPoolNotInitialized):
+		return nil, classFieldErrorf(err, "auction.pool_id", "%v", err)
+	case errors.Is(err, lvr.ErrTickSpacing):
+		return nil, classFieldErrorf(err, "auction.tick_spacing", "%v", err)
+	case err != nil:
+		return nil, fmt.Errorf("lvr estimate: %w", err)
+	}
+	h.tw.logger.Sugar().Infow("LVR estimate",
+		"auction_id", a.AuctionId,
+		"block", a.ReferenceBlock,
+		"pool_sqrt_price_x96", e.SqrtPriceX96.String(),
+		"oracle_sqrt_price_x96", e.OracleSqrtPriceX96.String(),
+		"zero_for_one", e.ZeroForOne,
+		"fee_pips", e.Fee,
+		"ticks_crossed", e.TicksCrossed,
+		"lvr_token1", e.Profit.String(),
+		"lvr_token0", e.Profit0.String(),
+	)
+	return e.Profit, nil
+}
+
+// record adds what the task saw of its auction to the lifecycle store. Store failures are
+// only logged; they never fail a task.
+func (h *auctionSettlementHandler) record(ctx context.Context, a *AuctionTask, op string, chosen *lifecycle.Result, taskErr error) {
+	report := lifecycle.TaskReport{
+		AuctionId:      a.AuctionId,
+		OracleUpdateId: common.HexToHash(a.OracleUpdateId),
+		PoolId:         common.HexToHash(a.PoolId),
+		Task:           lifecycle.TaskRef{Id: taskIdFrom(ctx), Op: op, Time: uint64(time.Now().Unix())},
+		Result:         chosen,
+	}
+	if taskErr != nil {
+		report.Task.Error = taskErr.Error()
+	}
+	if a.SealedBids != nil {
+		report.Bids = seenBids(a.SealedBids)
+	}
+	if err := h.tw.auctions.Task(report); err != nil {
+		h.tw.logger.Warn("Failed to record auction task", zap.Uint64("auction_id", a.AuctionId), zap.Error(err))
+	}
+}
+
+// seenBids lists a bid book's bidders with their commits and reveals as given, whether
+// or not the auction accepted them.
+func seenBids(b *SealedBidBook) []lifecycle.Bid {
+	var bids []lifecycle.Bid
+	index := make(map[common.Address]int)
+	bid := func(bidder string) *lifecycle.Bid {
+		addr := common.HexToAddress(bidder)
+		i, ok := index[addr]
+		if !ok {
+			i = len(bids)
+			index[addr] = i
+			bids = append(bids, lifecycle.Bid{Bidder: addr})
+		}
+		return &bids[i]
+	}
+	for _, c := range b.Commits {
+		seen := bid(c.Bidder)
+		seen.Commitment, seen.CommitTime = common.HexToHash(c.Commitment), c.Time
+	}
+	for _, r := range b.Reveals {
+		seen := bid(r.Bidder)
+		seen.RevealTime = r.Time
+		if amount, err := wei.Parse(r.AmountWei); err == nil {
+			seen.Amount = amount.Big()
+		}
+	}
+	return bids
+}
+
+// settlement returns the payload the settlement commits to, the bid it pays and the
+// winner: the winning sealed bid's when the task carries a sealed-bid book, otherwise the
+// task's settlement_data, expected_bid_wei and winner (zero when it names none).
+func (h *auctionSettlementHandler) settlement(a *AuctionTask) ([]byte, wei.Amount, common.Address, error) {
+	if a.SealedBids == nil {
+		data, err := decodeHexBytes(a.SettlementData)
+		if err != nil {
+			return nil, wei.Amount{}, common.Address{}, fmt.Errorf("settlement_data invalid hex: %w", err)
+		}
+		bid, err := parseWeiField("auction.expected_bid_wei", a.ExpectedBidWei)
+		if err != nil {
+			return nil, wei.Amount{}, common.Address{}, err
+		}
+		return data, bid, common.HexToAddress(a.Winner), nil
+	}
+	out, err := h.outcome(a)
+	if err != nil {
+		return nil, wei.Amount{}, common.Address{}, err
+	}
+	h.tw.logger.Sugar().Infow("Sealed-bid auction closed",
+		"auction_id", a.AuctionId,
+		"winner", out.Winner.Hex(),
+		"bid_wei", out.Amount.String(),
+		"revealed", out.Revealed,
+		"unrevealed", out.Unrevealed,
+	)
+	bid, err := wei.FromBig(out.Amount)
+	if err != nil {
+		return nil, wei.Amount{}, common.Address{}, classFieldErrorf(err, "auction.sealed_bids", "winning bid: %v", err)
+	}
+	return out.SettlementData, bid, out.Winner, nil
+}
+
+// outcome replays the task's sealed-bid book. Well-formed entries the auction rejects
+// (outside their phase, duplicated, not matching a commitment) are skipped, so one bad
+// bidder cannot stall the auction; the result depends only on the book's contents.
+func (h *auctionSettlementHandler) outcome(a *AuctionTask) (*auction.Outcome, error) {
+	b := a.SealedBids
+	auctionId := new(big.Int).SetUint64(a.AuctionId)
+	book, err := auction.NewBook(auctionId, auction.Window{Start: b.StartTime, RevealStart: b.RevealStart, End: b.EndTime})
+	if err != nil {
+		return nil, fieldErrorf("auction.sealed_bids.reveal_start", "%v", err)
+	}
+	for i, c := range b.Commits {
+		if err := book.Commit(common.HexToAddress(c.Bidder), common.HexToHash(c.Commitment), c.Time); err != nil {
+			h.tw.logger.Sugar().Debugw("Skipping sealed-bid commit", "auction_id", a.AuctionId, "index", i, "error", err)
+		}
+	}
+	for i, r := range b.Reveals {
+		amount, err := parseWeiField(fmt.Sprintf("auction.sealed_bids.reveals[%d].amount_wei", i), r.AmountWei)
+		if err != nil {
+			return nil, err
+		}
+		data, err := decodeHexBytes(r.SettlementData)
+		if err != nil {
+			return nil, classFieldErrorf(ErrBadHex, fmt.Sprintf("auction.sealed_bids.reveals[%d].settlement_data", i), "invalid hex: %v", err)
+		}
+		bid := auction.Bid{Bidder: common.HexToAddress(r.Bidder), Amount: amount.Big(), SettlementData: data}
+		if err := book.Reveal(bid, common.HexToHash(r.Salt), r.Time); err != nil {
+			h.tw.logger.Sugar().Debugw("Skipping sealed-bid reveal", "auction_id", a.AuctionId, "index", i, "error", err)
+		}
+	}
+	out, err := book.Outcome()
+	if err != nil {
+		return nil, classFieldErrorf(err, "auction.sealed_bids", "%v", err)
+	}
+	if a.ExpectedBidWei != "" {
+		expected, err := parseWeiField("auction.expected_bid_wei", a.ExpectedBidWei)
+		if err != nil {
+			return nil, err
+		}
+		if expected.Big().Cmp(out.Amount) != 0 {
+			return nil, fieldErrorf("auction.expected_bid_wei", "%s does not match winning bid %s", expected, out.Amount)
+		}
+	}
+	if a.Winner != "" && common.HexToAddress(a.Winner) != out.Winner {
+		return nil, fieldErrorf("auction.winner", "%s does not match sealed-bid winner %s", a.Winner, out.Winner.Hex())
+	}
+	return out, nil
+}
+
+func (h *auctionSettlementHandler) task(payload interface{}) (*AuctionTask, error) {
+	a, ok := payload.(*AuctionTask)
+	if !ok || a == nil {
+		return nil, fmt.Errorf("auction task missing")
+	}
+	return a, nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/auction"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+func Test_AuctionReplayRejected(t *testing.T) {
+	t.Setenv("PERFORMER_DATA_DIR", t.TempDir())
+	tw := NewTaskWorker(zap.NewNop())
+
+	req := &performerV1.TaskRequest{
+		TaskId:  []byte("task-1"),
+		Payload: []byte(testAuctionEnvelope),
+	}
+	if _, err := tw.HandleTask(req); err != nil {
+		t.Fatalf("first HandleTask failed: %v", err)
+	}
+	if err := tw.ValidateTask(req); err == nil || !strings.Contains(err.Error(), "auction.submission_nonce") {
+		t.Fatalf("ValidateTask on replay = %v, want submission_nonce error", err)
+	}
+	if _, err := tw.HandleTask(req); err == nil {
+		t.Fatalf("HandleTask accepted a replayed envelope")
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// The highest nonce survives a restart.
+	tw = NewTaskWorker(zap.NewNop())
+	defer tw.Close()
+	if err := tw.ValidateTask(req); err == nil {
+		t.Fatalf("ValidateTask accepted a replayed envelope after restart")
+	}
+
+	bumped := strings.Replace(testAuctionEnvelope, `"submission_nonce": 1`, `"submission_nonce": 2`, 1)
+	req.Payload = []byte(bumped)
+	if err := tw.ValidateTask(req); err != nil {
+		t.Fatalf("ValidateTask with higher nonce failed: %v", err)
+	}
+}
+
+// testChainId is the chain sealed bids are signed for in tests.
+const testChainId = 31337
+
+// testBidder signs sealed bids with a key derived from seed.
+type testBidder struct{ key *ecdsa.PrivateKey }
+
+func newTestBidder(seed byte) testBidder {
+	key, err := crypto.ToECDSA(common.LeftPadBytes([]byte{seed}, 32))
+	if err != nil {
+		panic(err)
+	}
+	return testBidder{key: key}
+}
+
+func (b testBidder) address() common.Address { return crypto.PubkeyToAddress(b.key.PublicKey) }
+
+func (b testBidder) sign(digest common.Hash) string {
+	sig, err := crypto.Sign(digest[:], b.key)
+	if err != nil {
+		panic(err)
+	}
+	return hexutil.Encode(sig)
+}
+
+func (b testBidder) commit(auctionService common.Address, auctionId uint64, bid auction.Bid, salt common.Hash, at uint64) SealedBidCommit {
+	id := new(big.Int).SetUint64(auctionId)
+	sealed := auction.SealBid(id, bid, salt)
+	domain := auction.SigningDomain{ChainId: big.NewInt(testChainId), AuctionService: auctionService}
+	return SealedBidCommit{
+		Bidder:     bid.Bidder.Hex(),
+		Commitment: sealed.Hex(),
+		Time:       at,
+		Signature:  b.sign(auction.CommitDigest(domain, id, bid.Bidder, sealed, at)),
+	}
+}
+
+func (b testBidder) reveal(auctionService common.Address, auctionId uint64, bid auction.Bid, salt common.Hash, at uint64) SealedBidReveal {
+	domain := auction.SigningDomain{ChainId: big.NewInt(testChainId), AuctionService: auctionService}
+	return SealedBidReveal{
+		Bidder:         bid.Bidder.Hex(),
+		AmountWei:      bid.Amount.String(),
+		SettlementData: hexutil.Encode(bid.SettlementData),
+		Salt:           salt.Hex(),
+		Time:           at,
+		Signature:      b.sign(auction.RevealDigest(domain, new(big.Int).SetUint64(auctionId), bid, salt, at)),
+	}
+}
+
+func Test_AuctionSealedBids(t *testing.T) {
+	bidders := []testBidder{newTestBidder(1), newTestBidder(2), newTestBidder(3)}
+	alice, bob, carol := bidders[0].address(), bidders[1].address(), bidders[2].address()
+	bids := []auction.Bid{
+		{Bidder: alice, Amount: big.NewInt(900), SettlementData: []byte("alice")},
+		{Bidder: bob, Amount: big.NewInt(1000), SettlementData: []byte("bob")},
+	}
+	salt := common.HexToHash(testBytes32A)
+	auctionService := common.HexToAddress(testAddress)
+
+	var commits []SealedBidCommit
+	var reveals []SealedBidReveal
+	for i, b := range bids {
+		commits = append(commits, bidders[i].commit(auctionService, 7, b, salt, 110))
+		reveals = append(reveals, bidders[i].reveal(auctionService, 7, b, salt, 160))
+	}
+	// A reveal without a commitment is skipped, however high.
+	reveals = append(reveals, bidders[2].reveal(auctionService, 7, auction.Bid{Bidder: carol, Amount: big.NewInt(5000)}, salt, 160))
+	book, err := json.Marshal(SealedBidBook{ChainId: testChainId, StartTime: 100, RevealStart: 150, EndTime: 200, Commits: commits, Reveals: reveals})
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := strings.Replace(testAuctionEnvelope, `"settlement_data": "0xdeadbeef",`, `"sealed_bids": `+string(book)+`,`, 1)
+
+	t.Setenv("PERFORMER_DATA_DIR", t.TempDir())
+	tw := NewTaskWorker(zap.NewNop())
+	defer tw.Close()
+
+	// The expected bid must match the winner, and settlement_data is decided by the book.
+	for name, payload := range map[string]string{
+		"auction.expected_bid_wei": strings.Replace(envelope, `"expected_bid_wei": "1000"`, `"expected_bid_wei": "900"`, 1),
+		"auction.settlement_data":  strings.Replace(envelope, `"sealed_bids"`, `"settlement_data": "0xdeadbeef", "sealed_bids"`, 1),
+		"auction.winner":           strings.Replace(envelope, `"sealed_bids"`, `"winner": "`+alice.Hex()+`", "sealed_bids"`, 1),
+	} {
+		req := &performerV1.TaskRequest{TaskId: []byte("task-2"), Payload: []byte(payload)}
+		if err := tw.ValidateTask(req); err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("ValidateTask = %v, want %s error", err, name)
+		}
+	}
+
+	// The relay can neither move a bidder's time nor sign for another bidder.
+	backdated := append([]SealedBidCommit(nil), commits...)
+	backdated[1].Time = 105
+	forged := append([]SealedBidReveal(nil), reveals...)
+	forged[0] = bidders[2].reveal(auctionService, 7, auction.Bid{Bidder: alice, Amount: big.NewInt(2000)}, salt, 160)
+	for name, b := range map[string]SealedBidBook{
+		"auction.sealed_bids.commits[1].signature": {ChainId: testChainId, StartTime: 100, RevealStart: 150, EndTime: 200, Commits: backdated, Reveals: reveals},
+		"auction.sealed_bids.reveals[0].signature": {ChainId: testChainId, StartTime: 100, RevealStart: 150, EndTime: 200, Commits: commits, Reveals: forged},
+		"auction.sealed_bids.chain_id":             {StartTime: 100, RevealStart: 150, EndTime: 200, Commits: commits, Reveals: reveals},
+	} {
+		raw, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload := strings.Replace(testAuctionEnvelope, `"settlement_data": "0xdeadbeef",`, `"sealed_bids": `+string(raw)+`,`, 1)
+		req := &performerV1.TaskRequest{TaskId: []byte("task-2"), Payload: []byte(payload)}
+		if err := tw.ValidateTask(req); err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("ValidateTask = %v, want %s error", err, name)
+		}
+	}
+
+	req := &performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(envelope)}
+	if err := tw.ValidateTask(req); err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+	resp, err := tw.HandleTask(req)
+	if err != nil {
+		t.Fatalf("HandleTask: %v", err)
+	}
+	res, err := results.DecodeAuctionSettlement(resp.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := commitment.AuctionSettlement(big.NewInt(7), common.HexToHash(testBytes32A), common.HexToHash(testBytes32B), commitment.SettlementHash([]byte("bob")))
+	if res.Commitment != want {
+		t.Fatalf("commitment = %s, want the winning bid's %s", common.Hash(res.Commitment).Hex(), want.Hex())
+	}
+	if res.BidAmount.Int64() != 1000 || res.Winner != bob {
+		t.Fatalf("bid amount = %s from %s, want the winning 1000 from bob", res.BidAmount, res.Winner.Hex())
+	}
+
+	// The lifecycle store keeps every bid seen, the winner and the tasks.
+	a, ok := tw.auctions.Get(7)
+	if !ok {
+		t.Fatal("auction not recorded")
+	}
+	if len(a.Bids) != 3 || a.Bids[2].Bidder != carol || a.Bids[2].Commitment != (common.Hash{}) || a.Bids[1].Amount.Int64() != 1000 {
+		t.Fatalf("bids = %+v", a.Bids)
+	}
+	if a.Result == nil || a.Result.Winner != bob || a.Result.Commitment != want || a.Result.TaskId != hexutil.Encode([]byte("task-1")) {
+		t.Fatalf("result = %+v", a.Result)
+	}
+	if len(a.Tasks) != 2 || a.Tasks[0].Error == "" || a.Tasks[1].Op != opHandle || a.Tasks[1].Error != "" {
+		t.Fatalf("tasks = %+v", a.Tasks)
+	}
+}
+
+func Test_AuctionLVREstimate(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Contracts[ContractLVRAuctionHook] = testHookAddress.Hex()
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+
+	// Without an oracle price the signed estimate is zero.
+	out, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testAuctionEnvelope)})
+	if err != nil {
+		t.Fatalf("HandleTask: %v", err)
+	}
+	r, err := results.DecodeAuctionSettlement(out.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.LvrEstimate.Sign() != 0 {
+		t.Fatalf("lvr estimate = %s, want 0", r.LvrEstimate)
+	}
+
+	// With one, a worker that cannot read the pool fails rather than sign a zero.
+	priced := strings.Replace(testAuctionEnvelope, `"submission_nonce": 1`,
+		`"oracle_sqrt_price_x96": "79228162514264337593543950336", "reference_block": 100, "lvr_auction_hook": "`+testHookAddress.Hex()+`", "tick_spacing": 10, "submission_nonce": 2`, 1)
+	if _, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-2"), Payload: []byte(priced)}); err == nil || !strings.Contains(err.Error(), "L1 client") {
+		t.Fatalf("HandleTask without an L1 client = %v", err)
+	}
+
+	// The pool is read through the task's hook, which must be the one the operator runs.
+	other := &performerV1.TaskRequest{
+		TaskId:  []byte("task-3"),
+		Payload: []byte(strings.Replace(priced, testHookAddress.Hex(), "0x00000000000000000000000000000000000000e1", 1)),
+	}
+	if err := tw.ValidateTask(other); err == nil || !strings.Contains(err.Error(), "auction.lvr_auction_hook") {
+		t.Fatalf("ValidateTask with another hook = %v", err)
+	}
+	if _, err := tw.HandleTask(other); err == nil || !strings.Contains(err.Error(), "auction.lvr_auction_hook") {
+		t.Fatalf("HandleTask with another hook = %v", err)
+	}
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/eigenai"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the performer settings. Values are layered: defaults, then the YAML
+// config file, then env variables, then command-line flags.
+type Config struct {
+	Port            int           `yaml:"port"`
+	StatusAddr      string        `yaml:"status_addr"`
+	Timeout         time.Duration `yaml:"timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	MaxHeadAge      time.Duration `yaml:"max_head_age"` // readiness fails when an RPC head is older; 0 disables
+	LogLevel        string        `yaml:"log_level"`
+	DataDir         string        `yaml:"data_dir"`
+	L1RpcUrl        string        `yaml:"l1_rpc_url"`
+	L2RpcUrl        string        `yaml:"l2_rpc_url"`
+	RPC             RPCConfig     `yaml:"rpc"`
+	OTLPEndpoint    string        `yaml:"otlp_endpoint"` // OTLP/gRPC trace collector URL; tracing is off when empty
+	ServiceName     string        `yaml:"service_name"`
+
+	// Contracts maps protocol contract names (e.g. "AuctionService") to addresses.
+	// Entries in the contract store take precedence.
+	Contracts map[string]string `yaml:"contracts"`
+
+	EigenAI     EigenAIConfig     `yaml:"eigenai"`
+	Events      EventsConfig      `yaml:"events"`
+	Auctions    AuctionsConfig    `yaml:"auctions"`
+	TaskCreator TaskCreatorConfig `yaml:"task_creator"`
+	Submitter   SubmitterConfig   `yaml:"submitter"`
+
+	// stateFile is the state database file under DataDir, set per command; stateDBFile
+	// when empty.
+	stateFile string
+}
+
+// RPCConfig configures the RPC pools, see pkg/rpcpool. Each chain's pool holds its
+// *_rpc_url followed by the extra URLs listed here.
+type RPCConfig struct {
+	L1Urls      []string      `yaml:"l1_urls"`
+	L2Urls      []string      `yaml:"l2_urls"`
+	HedgeAfter  time.Duration `yaml:"hedge_after"`  // a read not answered by then also goes to the next endpoint; 0 disables
+	MaxFailures int           `yaml:"max_failures"` // consecutive failures before an endpoint cools down
+	Cooldown    time.Duration `yaml:"cooldown"`     // how long a failing endpoint is tried last
+	// Quorum is how many L1 endpoints must agree on auction and attestation checks; 0 or 1
+	// reads from the best endpoint alone.
+	Quorum int `yaml:"quorum"`
+}
+
+// l1Urls returns the L1 pool's endpoints.
+func (c *Config) l1Urls() []string {
+	return rpcUrls(c.L1RpcUrl, c.RPC.L1Urls)
+}
+
+// l2Urls returns the L2 pool's endpoints.
+func (c *Config) l2Urls() []string {
+	return rpcUrls(c.L2RpcUrl, c.RPC.L2Urls)
+}
+
+func rpcUrls(primary string, extra []string) []string {
+	var out []string
+	for _, u := range append([]string{primary}, extra...) {
+		if u = strings.TrimSpace(u); u != "" {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// EigenAIConfig configures the EigenAI client used by insurance tasks that name a model.
+// The client is enabled by setting an API key or a grant private key.
+type EigenAIConfig struct {
+	Env               string        `yaml:"env"`      // sepolia or mainnet, picks the base URL
+	BaseURL           string        `yaml:"base_url"` // overrides env
+	Model             string        `yaml:"model"`
+	GrantServerURL    string        `yaml:"grant_server_url"`
+	APIKey            string        `yaml:"api_key"`
+	PrivateKey        string        `yaml:"private_key"` // hex, signs deTERMinal grants when no API key is set
+	Timeout           time.Duration `yaml:"timeout"`     // per HTTP attempt
+	Retries           int           `yaml:"retries"`
+	VerifyDeterminism bool          `yaml:"verify_determinism"` // ask twice and fail on differing answers
+}
+
+// EventsConfig configures the protocol event listener, see pkg/events. Zero values take
+// the package defaults.
+type EventsConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	StartBlock   uint64        `yaml:"start_block"` // first block to backfill; 0 starts at the head
+	ChunkSize    uint64        `yaml:"chunk_size"`  // most blocks per eth_getLogs call
+	PollInterval time.Duration `yaml:"poll_interval"`
+	ReorgDepth   int           `yaml:"reorg_depth"` // recent block hashes kept to find reorgs
+}
+
+// AuctionsConfig configures the auction tracker, which follows each auction from the
+// protocol events to a final settlement or expiry, see pkg/lifecycle. It runs with the
+// event listener.
+type AuctionsConfig struct {
+	Confirmations uint64        `yaml:"confirmations"` // blocks that make a settlement final; 0 waits for the finalized tag
+	PollInterval  time.Duration `yaml:"poll_interval"` // how often finality and expiry are checked
+}
+
+// TaskCreatorConfig configures `performer task-creator`, which posts auction_settlement
+// tasks to the TaskMailbox for closed AuctionService auctions.
+type TaskCreatorConfig struct {
+	PrivateKey      string        `yaml:"private_key"`      // hex, sends createTask transactions
+	AVS             string        `yaml:"avs"`              // AVS of the executor operator set
+	OperatorSetId   uint32        `yaml:"operator_set_id"`  // executor operator set
+	RefundCollector string        `yaml:"refund_collector"` // receives task fee refunds; the sender when empty
+	Pools           []string      `yaml:"pools"`            // the one pool id auctioned; AuctionCreated does not name it
+	AppId           string        `yaml:"app_id"`           // EigenCompute auctioneer appId (hex)
+	ImageDigest     string        `yaml:"image_digest"`     // auctioneer Docker digest (hex)
+	BidsURL         string        `yaml:"bids_url"`         // serves an auction's sealed-bid book, {auction_id} is substituted
+	PollInterval    time.Duration `yaml:"poll_interval"`    // how often closed auctions are checked
+}
+
+// SubmitterConfig configures `performer settlement-submitter`, which sends verified
+// auction results to AuctionService.submitSettlement. Zero values take the pkg/submitter
+// defaults.
+type SubmitterConfig struct {
+	PrivateKey    string        `yaml:"private_key"`     // hex, sends and pays the bid of every settlement
+	AVS           string        `yaml:"avs"`             // AVS whose verified tasks are settled
+	OperatorSetId uint32        `yaml:"operator_set_id"` // executor operator set of those tasks
+	ListenAddr    string        `yaml:"listen_addr"`     // HTTP API accepting settlements; keep it off public interfaces
+	Confirmations uint64        `yaml:"confirmations"`   // blocks that make a settlement final; 0 waits for the finalized tag
+	BumpAfter     time.Duration `yaml:"bump_after"`      // how long a transaction may sit unmined before its fees are bumped
+	BumpPercent   uint64        `yaml:"bump_percent"`    // fee increase per bump, at least 10
+	MaxFeePerGas  string        `yaml:"max_fee_per_gas"` // fee cap, e.g. "50 gwei"; uncapped when empty
+	PollInterval  time.Duration `yaml:"poll_interval"`   // how often receipts are checked
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Port:            8080,
+		StatusAddr:      ":8081",
+		Timeout:         5 * time.Second,
+		ShutdownTimeout: 30 * time.Second,
+		MaxHeadAge:      2 * time.Minute,
+		LogLevel:        "info",
+		ServiceName:     "rolaid-performer",
+		Contracts:       map[string]string{},
+		EigenAI: EigenAIConfig{
+			Model:             eigenai.DefaultModel,
+			Timeout:           30 * time.Second,
+			Retries:           2,
+			VerifyDeterminism: true,
+		},
+		Auctions: AuctionsConfig{
+			PollInterval: 12 * time.Second,
+		},
+		TaskCreator: TaskCreatorConfig{
+			PollInterval: 5 * time.Second,
+		},
+		Submitter: SubmitterConfig{
+			ListenAddr: "127.0.0.1:8082",
+		},
+	}
+}
+
+// configFromEnv returns the defaults overlaid with env variables.
+func configFromEnv() (*Config, error) {
+	cfg := defaultConfig()
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.validate()
+}
+
+// loadConfig builds the config from defaults, the config file, env and flags. The config
+// file is taken from -config or PERFORMER_CONFIG.
+func loadConfig(args []string) (*Config, error) {
+	cfg, _, err := loadConfigArgs("performer", args)
+	return cfg, err
+}
+
+// loadConfigArgs is loadConfig for subcommands, also returning the positional arguments
+// left after the flags.
+func loadConfigArgs(name string, args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("PERFORMER_CONFIG"), "path to a YAML config file (env PERFORMER_CONFIG)")
+	port := fs.Int("port", 0, "gRPC port for the performer (env PERFORMER_PORT)")
+	statusAddr := fs.String("status-addr", "", "listen address for the status server (env PERFORMER_STATUS_ADDR)")
+	timeout := fs.Duration("timeout", 0, "per-task timeout (env PERFORMER_TIMEOUT)")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 0, "how long to drain in-flight tasks on shutdown (env PERFORMER_SHUTDOWN_TIMEOUT)")
+	maxHeadAge := fs.Duration("max-head-age", 0, "readiness fails when an RPC head block is older, 0 disables (env PERFORMER_MAX_HEAD_AGE)")
+	logLevel := fs.String("log-level", "", "debug, info, warn or error (env PERFORMER_LOG_LEVEL)")
+	dataDir := fs.String("data-dir", "", "directory for local state (env PERFORMER_DATA_DIR)")
+	l1RpcUrl := fs.String("l1-rpc-url", "", "L1 RPC endpoint (env L1_RPC_URL)")
+	l2RpcUrl := fs.String("l2-rpc-url", "", "L2 RPC endpoint (env L2_RPC_URL)")
+	otlpEndpoint := fs.String("otlp-endpoint", "", "OTLP/gRPC trace collector URL, e.g. http://localhost:4317 (env OTEL_EXPORTER_OTLP_ENDPOINT)")
+	contractFlags := make(map[string]*string, len(protocolContractNames))
+	for _, name := range protocolContractNames {
+		envName := contractAddressEnv[name]
+		flagName := strings.ReplaceAll(strings.ToLower(envName), "_", "-")
+		contractFlags[name] = fs.String(flagName, "", fmt.Sprintf("%s address (env %s)", name, envName))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := defaultConfig()
+	if *configPath != "" {
+		if err := cfg.applyFile(*configPath); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, nil, err
+	}
+
+	// Only flags set explicitly override earlier layers.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.Port = *port
+		case "status-addr":
+			cfg.StatusAddr = *statusAddr
+		case "timeout":
+			cfg.Timeout = *timeout
+		case "shutdown-timeout":
+			cfg.ShutdownTimeout = *shutdownTimeout
+		case "max-head-age":
+			cfg.MaxHeadAge = *maxHeadAge
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		case "data-dir":
+			cfg.DataDir = *dataDir
+		case "l1-rpc-url":
+			cfg.L1RpcUrl = *l1RpcUrl
+		case "l2-rpc-url":
+			cfg.L2RpcUrl = *l2RpcUrl
+		case "otlp-endpoint":
+			cfg.OTLPEndpoint = *otlpEndpoint
+		}
+	})
+	for name, val := range contractFlags {
+		if *val != "" {
+			cfg.Contracts[name] = *val
+		}
+	}
+	return cfg, fs.Args(), cfg.validate()
+}
+
+func (c *Config) applyFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config file: %w", err)
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	if c.Contracts == nil {
+		c.Contracts = map[string]string{}
+	}
+	return nil
+}
+
+func (c *Config) applyEnv() error {
+	if v := os.Getenv("PERFORMER_PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("PERFORMER_PORT: %w", err)
+		}
+		c.Port = port
+	}
+	if v := os.Getenv("EIGENAI_RETRIES"); v != "" {
+		retries, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("EIGENAI_RETRIES: %w", err)
+		}
+		c.EigenAI.Retries = retries
+	}
+	if v := os.Getenv("EVENTS_REORG_DEPTH"); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("EVENTS_REORG_DEPTH: %w", err)
+		}
+		c.Events.ReorgDepth = depth
+	}
+	if v := os.Getenv("TASK_CREATOR_OPERATOR_SET_ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("TASK_CREATOR_OPERATOR_SET_ID: %w", err)
+		}
+		c.TaskCreator.OperatorSetId = uint32(id)
+	}
+	if v := os.Getenv("SUBMITTER_OPERATOR_SET_ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("SUBMITTER_OPERATOR_SET_ID: %w", err)
+		}
+		c.Submitter.OperatorSetId = uint32(id)
+	}
+	if v := os.Getenv("TASK_CREATOR_POOLS"); v != "" {
+		c.TaskCreator.Pools = strings.Split(v, ",")
+	}
+	if v := os.Getenv("L1_RPC_URLS"); v != "" {
+		c.RPC.L1Urls = strings.Split(v, ",")
+	}
+	if v := os.Getenv("L2_RPC_URLS"); v != "" {
+		c.RPC.L2Urls = strings.Split(v, ",")
+	}
+	for env, dst := range map[string]*int{
+		"RPC_QUORUM":       &c.RPC.Quorum,
+		"RPC_MAX_FAILURES": &c.RPC.MaxFailures,
+	} {
+		if v := os.Getenv(env); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = n
+		}
+	}
+	for env, dst := range map[string]*uint64{
+		"EVENTS_START_BLOCK":      &c.Events.StartBlock,
+		"EVENTS_CHUNK_SIZE":       &c.Events.ChunkSize,
+		"AUCTIONS_CONFIRMATIONS":  &c.Auctions.Confirmations,
+		"SUBMITTER_CONFIRMATIONS": &c.Submitter.Confirmations,
+		"SUBMITTER_BUMP_PERCENT":  &c.Submitter.BumpPercent,
+	} {
+		if v := os.Getenv(env); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = n
+		}
+	}
+	for env, dst := range map[string]*bool{
+		"VERIFY_DETERMINISM": &c.EigenAI.VerifyDeterminism,
+		"EVENTS_ENABLED":     &c.Events.Enabled,
+	} {
+		if v := os.Getenv(env); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = b
+		}
+	}
+	for env, dst := range map[string]*time.Duration{
+		"PERFORMER_TIMEOUT":          &c.Timeout,
+		"PERFORMER_SHUTDOWN_TIMEOUT": &c.ShutdownTimeout,
+		"PERFORMER_MAX_HEAD_AGE":     &c.MaxHeadAge,
+		"EIGENAI_TIMEOUT":            &c.EigenAI.Timeout,
+		"EVENTS_POLL_INTERVAL":       &c.Events.PollInterval,
+		"AUCTIONS_POLL_INTERVAL":     &c.Auctions.PollInterval,
+		"TASK_CREATOR_POLL_INTERVAL": &c.TaskCreator.PollInterval,
+		"SUBMITTER_BUMP_AFTER":       &c.Submitter.BumpAfter,
+		"SUBMITTER_POLL_INTERVAL":    &c.Submitter.PollInterval,
+	} {
+		if v := os.Getenv(env); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = d
+		}
+	}
+	for env, dst := range map[string]*string{
+		"PERFORMER_STATUS_ADDR":       &c.StatusAddr,
+		"PERFORMER_LOG_LEVEL":         &c.LogLevel,
+		"PERFORMER_DATA_DIR":          &c.DataDir,
+		"L1_RPC_URL":                  &c.L1RpcUrl,
+		"L2_RPC_URL":                  &c.L2RpcUrl,
+		"OTEL_EXPORTER_OTLP_ENDPOINT": &c.OTLPEndpoint,
+		"OTEL_SERVICE_NAME":           &c.ServiceName,
+		"EIGENAI_ENV":                 &c.EigenAI.Env,
+		"EIGENAI_BASE_URL":            &c.EigenAI.BaseURL,
+		"EIGENAI_MODEL":               &c.EigenAI.Model,
+		"DETERMINAL_SERVER_URL":       &c.EigenAI.GrantServerURL,
+		"EIGENAI_API_KEY":             &c.EigenAI.APIKey,
+		"TASK_CREATOR_PRIVATE_KEY":    &c.TaskCreator.PrivateKey,
+		"TASK_CREATOR_AVS":            &c.TaskCreator.AVS,
+		"TASK_CREATOR_REFUND_ADDRESS": &c.TaskCreator.RefundCollector,
+		"TASK_CREATOR_BIDS_URL":       &c.TaskCreator.BidsURL,
+		"SUBMITTER_PRIVATE_KEY":       &c.Submitter.PrivateKey,
+		"SUBMITTER_AVS":               &c.Submitter.AVS,
+		"SUBMITTER_LISTEN_ADDR":       &c.Submitter.ListenAddr,
+		"SUBMITTER_MAX_FEE_PER_GAS":   &c.Submitter.MaxFeePerGas,
+		// Shared with apps/auctioneer.
+		"APP_ID":       &c.TaskCreator.AppId,
+		"IMAGE_DIGEST": &c.TaskCreator.ImageDigest,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*dst = v
+		}
+	}
+	// PRIVATE_KEY is the fallback apps/insurance uses too.
+	for _, env := range []string{"PRIVATE_KEY", "EIGENAI_PRIVATE_KEY"} {
+		if v := os.Getenv(env); v != "" {
+			c.EigenAI.PrivateKey = v
+		}
+	}
+	for name, env := range contractAddressEnv {
+		if v := os.Getenv(env); v != "" {
+			c.Contracts[name] = v
+		}
+	}
+	return nil
+}
+
+func (c *Config) validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("port out of range: %d", c.Port)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if c.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown_timeout must not be negative")
+	}
+	if c.MaxHeadAge < 0 {
+		return fmt.Errorf("max_head_age must not be negative")
+	}
+	if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+		return fmt.Errorf("log_level: %w", err)
+	}
+	if err := c.EigenAI.validate(); err != nil {
+		return fmt.Errorf("eigenai: %w", err)
+	}
+	// A task that outlives its deadline fails however EigenAI answers.
+	if e := c.EigenAI; (e.APIKey != "" || e.PrivateKey != "") && e.budget() > c.Timeout {
+		return fmt.Errorf("eigenai: timeout %s with %d retries can take %s, longer than the %s task timeout", e.Timeout, e.Retries, e.budget(), c.Timeout)
+	}
+	if c.RPC.HedgeAfter < 0 || c.RPC.Cooldown < 0 || c.RPC.MaxFailures < 0 {
+		return fmt.Errorf("rpc: hedge_after, cooldown and max_failures must not be negative")
+	}
+	if n := len(c.l1Urls()); c.RPC.Quorum > 1 && c.RPC.Quorum > n {
+		return fmt.Errorf("rpc: quorum %d exceeds the %d L1 endpoints", c.RPC.Quorum, n)
+	}
+	if c.Events.PollInterval < 0 || c.Events.ReorgDepth < 0 {
+		return fmt.Errorf("events: poll_interval and reorg_depth must not be negative")
+	}
+	if c.Auctions.PollInterval <= 0 {
+		return fmt.Errorf("auctions: poll_interval must be positive")
+	}
+	for name, addr := range c.Contracts {
+		if _, ok := contractAddressEnv[name]; !ok {
+			return fmt.Errorf("contracts: unknown contract %q", name)
+		}
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("contracts.%s: invalid address %q", name, addr)
+		}
+	}
+	return nil
+}
+
+// newLogger builds the production logger at the configured level.
+func (c *Config) newLogger() (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(c.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	zc := zap.NewProductionConfig()
+	zc.Level = zap.NewAtomicLevelAt(level)
+	return zc.Build()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_LoadConfigLayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "performer.yaml")
+	file := `port: 9000
+timeout: 10s
+log_level: debug
+l1_rpc_url: http://file-l1
+contracts:
+  AuctionService: "0x00000000000000000000000000000000000000b1"
+  SettlementVault: "0x00000000000000000000000000000000000000b2"
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PERFORMER_TIMEOUT", "20s")
+	t.Setenv("L1_RPC_URL", "http://env-l1")
+	t.Setenv("SETTLEMENT_VAULT_ADDRESS", testAddress)
+
+	cfg, err := loadConfig([]string{"-config", path, "-port", "9100", "-auction-service-address", testAddress})
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if cfg.Port != 9100 {
+		t.Errorf("port = %d, want flag value 9100", cfg.Port)
+	}
+	if cfg.Timeout != 20*time.Second {
+		t.Errorf("timeout = %s, want env value 20s", cfg.Timeout)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("log level = %q, want file value debug", cfg.LogLevel)
+	}
+	if cfg.L1RpcUrl != "http://env-l1" {
+		t.Errorf("l1 rpc = %q, want env value", cfg.L1RpcUrl)
+	}
+	if cfg.ShutdownTimeout != defaultConfig().ShutdownTimeout {
+		t.Errorf("shutdown timeout = %s, want default", cfg.ShutdownTimeout)
+	}
+	if got := cfg.Contracts[ContractAuctionService]; got != testAddress {
+		t.Errorf("AuctionService = %s, want flag value", got)
+	}
+	if got := cfg.Contracts[ContractSettlementVault]; got != testAddress {
+		t.Errorf("SettlementVault = %s, want env value", got)
+	}
+}
+
+func Test_LoadConfigRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		file    string
+		wantErr string
+	}{
+		{name: "bad level", args: []string{"-log-level", "loud"}, wantErr: "log_level"},
+		{name: "bad port", args: []string{"-port", "70000"}, wantErr: "port"},
+		{name: "bad address", args: []string{"-settlement-vault-address", "0x1234"}, wantErr: "SettlementVault"},
+		{name: "unknown file field", file: "prot: 1\n", wantErr: "prot"},
+		{name: "unknown contract", file: "contracts:\n  HelloWorldL1: \"0x00000000000000000000000000000000000000a1\"\n", wantErr: "HelloWorldL1"},
+		{name: "eigenai slower than tasks", file: "timeout: 10s\neigenai:\n  api_key: key-1\n  timeout: 4s\n  retries: 1\n", wantErr: "longer than the 10s task timeout"},
+		{name: "quorum too large", file: "l1_rpc_url: http://a\nrpc:\n  l1_urls: [http://b]\n  quorum: 3\n", wantErr: "quorum 3 exceeds the 2 L1 endpoints"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				path := filepath.Join(t.TempDir(), "performer.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				args = append([]string{"-config", path}, args...)
+			}
+			_, err := loadConfig(args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	taskmailbox "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/TaskMailbox"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/attestationregistry"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/settlementvault"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Names the ROLAID protocol contracts are registered under in the contract store.
+// They match the Solidity contract names so devkit can locate their ABIs.
+const (
+	ContractAuctionService      = "AuctionService"
+	ContractSettlementVault     = "SettlementVault"
+	ContractAttestationRegistry = "AttestationRegistry"
+	ContractLVRAuctionHook      = "LVRAuctionHook"
+	ContractTaskMailbox         = "TaskMailbox"
+)
+
+// contractAddressEnv is the env variable configuring each protocol contract, for running
+// the performer outside devkit where the contract store is not populated.
+var contractAddressEnv = map[string]string{
+	ContractAuctionService:      "AUCTION_SERVICE_ADDRESS",
+	ContractSettlementVault:     "SETTLEMENT_VAULT_ADDRESS",
+	ContractAttestationRegistry: "ATTESTATION_REGISTRY_ADDRESS",
+	ContractLVRAuctionHook:      "LVR_AUCTION_HOOK_ADDRESS",
+	ContractTaskMailbox:         "TASK_MAILBOX_ADDRESS",
+}
+
+// protocolContractNames lists the protocol contracts in a stable order.
+var protocolContractNames = []string{
+	ContractAuctionService,
+	ContractSettlementVault,
+	ContractAttestationRegistry,
+	ContractLVRAuctionHook,
+	ContractTaskMailbox,
+}
+
+// missingAddressError reports a protocol contract with no address in the contract store or config.
+type missingAddressError struct {
+	contract string
+	env      string
+}
+
+func (e *missingAddressError) Error() string {
+	return fmt.Sprintf("%s address missing (contract store, config or env %s)", e.contract, e.env)
+}
+
+// resolveContract returns the address of a protocol contract by name. The contract
+// store is authoritative; the performer config (file, env or flag) is used when the
+// store has no entry.
+func (tw *TaskWorker) resolveContract(name string) (common.Address, error) {
+	envName, ok := contractAddressEnv[name]
+	if !ok {
+		return common.Address{}, fmt.Errorf("unknown contract %q", name)
+	}
+	if tw.contractStore != nil {
+		if addr, err := tw.contractStore.GetContract(name); err == nil && addr != (common.Address{}) {
+			return addr, nil
+		}
+	}
+	val := tw.config.Contracts[name]
+	if val == "" {
+		return common.Address{}, &missingAddressError{contract: name, env: envName}
+	}
+	if !common.IsHexAddress(val) {
+		return common.Address{}, fmt.Errorf("%s address invalid: %q", name, val)
+	}
+	return common.HexToAddress(val), nil
+}
+
+// checkTaskContract rejects a task bound to a different protocol contract than the one
+// this operator resolves for name. The task's address is what goes into the result, so
+// local configuration can refuse a task but never changes the signed bytes. Operators
+// without a local address accept the task's.
+func (tw *TaskWorker) checkTaskContract(name, field string, addr common.Address) error {
+	local, err := tw.resolveContract(name)
+	if err != nil {
+		var missing *missingAddressError
+		if errors.As(err, &missing) {
+			return nil
+		}
+		return err
+	}
+	if local != addr {
+		return fieldErrorf(field, "task targets %s %s, operator is configured for %s", name, addr.Hex(), local.Hex())
+	}
+	return nil
+}
+
+// logProtocolContracts reports which protocol contracts resolved at startup.
+func (tw *TaskWorker) logProtocolContracts() {
+	for _, name := range protocolContractNames {
+		addr, err := tw.resolveContract(name)
+		if err != nil {
+			tw.logger.Warn("Protocol contract not configured", zap.String("contract", name), zap.Error(err))
+			continue
+		}
+		tw.logger.Info("Protocol contract", zap.String("contract", name), zap.String("address", addr.Hex()))
+	}
+}
+
+func (tw *TaskWorker) requireL1Client() error {
+	if tw.l1Client == nil {
+		return fmt.Errorf("L1 client not configured (env L1_RPC_URL)")
+	}
+	return nil
+}
+
+// AuctionService binds the AuctionService contract on L1.
+func (tw *TaskWorker) AuctionService() (*auctionservice.AuctionService, error) {
+	addr, err := tw.resolveContract(ContractAuctionService)
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	return auctionservice.NewAuctionService(addr, tw.l1Client)
+}
+
+// SettlementVault binds the SettlementVault contract on L1.
+func (tw *TaskWorker) SettlementVault() (*settlementvault.SettlementVault, error) {
+	addr, err := tw.resolveContract(ContractSettlementVault)
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	return settlementvault.NewSettlementVault(addr, tw.l1Client)
+}
+
+// AttestationRegistry binds the AttestationRegistry contract on L1.
+func (tw *TaskWorker) AttestationRegistry() (*attestationregistry.AttestationRegistry, error) {
+	addr, err := tw.resolveContract(ContractAttestationRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	return attestationregistry.NewAttestationRegistry(addr, tw.l1Client)
+}
+
+// LVRAuctionHook binds the Uniswap v4 LVRAuctionHook contract on L1.
+func (tw *TaskWorker) LVRAuctionHook() (*lvrauctionhook.LVRAuctionHook, error) {
+	addr, err := tw.resolveContract(ContractLVRAuctionHook)
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	return lvrauctionhook.NewLVRAuctionHook(addr, tw.l1Client)
+}
+
+// TaskMailbox binds the Hourglass TaskMailbox on L1.
+func (tw *TaskWorker) TaskMailbox() (*taskmailbox.TaskMailbox, error) {
+	addr, err := tw.resolveContract(ContractTaskMailbox)
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	return taskmailbox.NewTaskMailbox(addr, tw.l1Client)
+}
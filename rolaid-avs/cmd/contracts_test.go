@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+func Test_ResolveContract(t *testing.T) {
+	t.Setenv("SETTLEMENT_VAULT_ADDRESS", testAddress)
+	t.Setenv("LVR_AUCTION_HOOK_ADDRESS", "")
+	w := NewTaskWorker(zap.NewNop())
+
+	addr, err := w.resolveContract(ContractSettlementVault)
+	if err != nil {
+		t.Fatalf("resolve SettlementVault: %v", err)
+	}
+	if addr != common.HexToAddress(testAddress) {
+		t.Fatalf("SettlementVault = %s, want %s", addr.Hex(), testAddress)
+	}
+
+	if _, err := w.resolveContract(ContractLVRAuctionHook); err == nil || !strings.Contains(err.Error(), "LVR_AUCTION_HOOK_ADDRESS") {
+		t.Fatalf("expected missing address error, got %v", err)
+	}
+
+	if _, err := w.resolveContract("HelloWorldL1"); err == nil {
+		t.Fatal("expected unknown contract error")
+	}
+
+	// Bindings need an L1 client even when the address resolves.
+	if _, err := w.SettlementVault(); err == nil || !strings.Contains(err.Error(), "L1_RPC_URL") {
+		t.Fatalf("expected missing L1 client error, got %v", err)
+	}
+}
+
+func Test_TaskContractMustMatchOperator(t *testing.T) {
+	t.Setenv("PERFORMER_DATA_DIR", "")
+	t.Setenv("AUCTION_SERVICE_ADDRESS", "0x00000000000000000000000000000000000000ff")
+	w := NewTaskWorker(zap.NewNop())
+	defer w.Close()
+
+	// The task's address is signed; an operator configured for another contract refuses it.
+	req := &performerV1.TaskRequest{TaskId: []byte("task"), Payload: []byte(testAuctionEnvelope)}
+	if err := w.ValidateTask(req); err == nil || !strings.Contains(err.Error(), "auction.auction_service") {
+		t.Fatalf("ValidateTask = %v, want auction_service mismatch", err)
+	}
+
+	t.Setenv("AUCTION_SERVICE_ADDRESS", "")
+	w = NewTaskWorker(zap.NewNop())
+	defer w.Close()
+	if err := w.ValidateTask(req); err != nil {
+		t.Fatalf("ValidateTask without a local address: %v", err)
+	}
+}
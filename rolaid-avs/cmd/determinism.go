@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// Probes run by the determinism check.
+const (
+	probeRepeat    = "repeat"    // identical re-runs: map iteration order, randomness
+	probeEnv       = "env"       // operator env, config and contract store
+	probeClock     = "clock"     // wall-clock time
+	probeCanonical = "canonical" // result encoding
+)
+
+// determinismCheck re-runs a task handler under varied conditions and flags results that
+// change. The Aggregator only reaches quorum on identical signed bytes, so a result may
+// depend on the task and on chain state at the task's pinned block, and nothing else.
+//
+// The env probe rewrites process env variables, so the check belongs in the
+// check-determinism subcommand and in tests, never in a serving performer.
+type determinismCheck struct {
+	cfg *Config
+	// newWorker builds a fresh worker for every run, so runs share no replay or cache state.
+	newWorker func(cfg *Config) *TaskWorker
+	// repeats is the number of identical re-runs compared with the first.
+	repeats int
+	// clockDelay separates the runs compared by the clock probe. It exceeds a second so
+	// results derived from unix seconds change too.
+	clockDelay time.Duration
+}
+
+type determinismFinding struct {
+	Probe  string `json:"probe"`
+	Detail string `json:"detail"`
+}
+
+type determinismReport struct {
+	Kind     string               `json:"kind"`
+	Result   string               `json:"result"` // hex of the first run's result
+	Findings []determinismFinding `json:"findings,omitempty"`
+}
+
+func newDeterminismCheck(cfg *Config) *determinismCheck {
+	return &determinismCheck{
+		cfg: cfg,
+		newWorker: func(cfg *Config) *TaskWorker {
+			return NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+		},
+		repeats:    8,
+		clockDelay: 1100 * time.Millisecond,
+	}
+}
+
+// run checks the handler for one task payload. An error means the task could not be
+// handled at all; non-determinism is reported as findings.
+func (c *determinismCheck) run(ctx context.Context, payload []byte) (*determinismReport, error) {
+	// Runs keep replay nonces in memory so every run can accept the same task.
+	cfg := *c.cfg
+	cfg.DataDir = ""
+
+	first, kind, err := c.handle(ctx, &cfg, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	report := &determinismReport{Kind: kind, Result: hexutil.Encode(first)}
+	flag := func(probe, format string, args ...interface{}) {
+		report.Findings = append(report.Findings, determinismFinding{Probe: probe, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	// Go randomizes map iteration, so identical runs disagree when a result depends on it.
+	for i := 0; i < c.repeats; i++ {
+		res, _, err := c.handle(ctx, &cfg, payload, nil)
+		if diff := compareRun(first, res, err); diff != "" {
+			flag(probeRepeat, "re-run %d %s; check map iteration order and randomness", i+1, diff)
+			// The remaining probes cannot tell their variable from this noise.
+			return report, nil
+		}
+	}
+
+	res, err := c.withOtherOperatorEnv(&cfg, func(other *Config) ([]byte, error) {
+		res, _, err := c.handle(ctx, other, payload, func(tw *TaskWorker) { tw.contractStore = nil })
+		return res, err
+	})
+	if diff := compareRun(first, res, err); diff != "" {
+		flag(probeEnv, "run with other contract addresses in env and config %s; take addresses from the task", diff)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.clockDelay):
+	}
+	res, _, err = c.handle(ctx, &cfg, payload, nil)
+	if diff := compareRun(first, res, err); diff != "" {
+		flag(probeClock, "run %s later %s; use block timestamps instead of the wall clock", c.clockDelay, diff)
+	}
+
+	if kind == KindAuctionSettlement || kind == KindInsurancePayout {
+		if err := results.Canonical(first); err != nil {
+			flag(probeCanonical, "%v", err)
+		}
+	}
+	return report, nil
+}
+
+// handle decodes and handles payload on a fresh worker, after applying mutate to it.
+func (c *determinismCheck) handle(ctx context.Context, cfg *Config, payload []byte, mutate func(*TaskWorker)) ([]byte, string, error) {
+	tw := c.newWorker(cfg)
+	defer tw.Close()
+	if mutate != nil {
+		mutate(tw)
+	}
+	env, h, err := tw.registry.Decode(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	res, err := h.Handle(ctx, env.Payload)
+	return res, env.Kind, err
+}
+
+// withOtherOperatorEnv runs fn as an operator whose protocol contract addresses, in both
+// env and config, differ from cfg's. The env is restored afterwards.
+func (c *determinismCheck) withOtherOperatorEnv(cfg *Config, fn func(*Config) ([]byte, error)) ([]byte, error) {
+	other := *cfg
+	other.Contracts = make(map[string]string, len(protocolContractNames))
+	for _, name := range protocolContractNames {
+		addr := common.BytesToAddress(crypto.Keccak256([]byte("determinism-check:" + name))).Hex()
+		other.Contracts[name] = addr
+
+		env := contractAddressEnv[name]
+		prev, had := os.LookupEnv(env)
+		os.Setenv(env, addr)
+		defer func() {
+			if had {
+				os.Setenv(env, prev)
+			} else {
+				os.Unsetenv(env)
+			}
+		}()
+	}
+	return fn(&other)
+}
+
+// compareRun describes how a run differs from the first one, or returns "" if it matches.
+func compareRun(first, res []byte, err error) string {
+	if err != nil {
+		return fmt.Sprintf("failed (%v)", err)
+	}
+	if !bytes.Equal(first, res) {
+		return fmt.Sprintf("returned %s, first run %s", hexutil.Encode(res), hexutil.Encode(first))
+	}
+	return ""
+}
+
+// runCheckDeterminism implements `performer check-determinism [flags] payload.json...`.
+// It takes the performer's flags, checks each task envelope file ("-" for stdin), prints
+// one JSON report per task and fails if any task is non-deterministic.
+func runCheckDeterminism(args []string, stdin io.Reader, stdout io.Writer) error {
+	cfg, files, err := loadConfigArgs("check-determinism", args)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("usage: performer check-determinism [flags] payload.json... (- reads stdin)")
+	}
+
+	check := newDeterminismCheck(cfg)
+	enc := json.NewEncoder(stdout)
+	failed := 0
+	for _, file := range files {
+		var payload []byte
+		if file == "-" {
+			payload, err = io.ReadAll(stdin)
+		} else {
+			payload, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return err
+		}
+		report, err := check.run(context.Background(), payload)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if len(report.Findings) > 0 {
+			failed++
+		}
+		if err := enc.Encode(struct {
+			File string `json:"file"`
+			*determinismReport
+		}{file, report}); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tasks are not deterministic", failed, len(files))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// probeHandler is a task kind whose result comes from fn, for exercising the check.
+type probeHandler struct {
+	kind string
+	fn   func() []byte
+}
+
+func (h *probeHandler) Kind() string       { return h.kind }
+func (h *probeHandler) PayloadKey() string { return h.kind }
+func (h *probeHandler) Decode(version uint32, raw json.RawMessage) (interface{}, error) {
+	return raw, nil
+}
+func (h *probeHandler) Validate(ctx context.Context, payload interface{}) error { return nil }
+func (h *probeHandler) Handle(ctx context.Context, payload interface{}) ([]byte, error) {
+	return h.fn(), nil
+}
+
+func Test_DeterminismCheck(t *testing.T) {
+	t.Setenv("AUCTION_SERVICE_ADDRESS", testAddress)
+	check := newDeterminismCheck(defaultConfig())
+	check.clockDelay = 10 * time.Millisecond
+	check.newWorker = func(cfg *Config) *TaskWorker {
+		tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+		for _, h := range []TaskHandler{
+			&probeHandler{kind: "env_probe", fn: func() []byte {
+				return []byte(os.Getenv("AUCTION_SERVICE_ADDRESS"))
+			}},
+			&probeHandler{kind: "map_probe", fn: func() []byte {
+				var out []byte
+				for k := range map[byte]bool{'a': true, 'b': true, 'c': true, 'd': true, 'e': true, 'f': true, 'g': true, 'h': true} {
+					out = append(out, k)
+				}
+				return out
+			}},
+		} {
+			if err := tw.RegisterHandler(h); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return tw
+	}
+
+	tests := []struct {
+		name      string
+		payload   string
+		wantProbe string
+	}{
+		{name: "auction settlement", payload: testAuctionEnvelope},
+		{name: "insurance payout", payload: testInsuranceEnvelope},
+		{name: "env read", payload: `{"kind": "env_probe", "env_probe": {}}`, wantProbe: probeEnv},
+		{name: "map ordering", payload: `{"kind": "map_probe", "map_probe": {}}`, wantProbe: probeRepeat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := check.run(context.Background(), []byte(tt.payload))
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			if tt.wantProbe == "" {
+				if len(report.Findings) > 0 {
+					t.Fatalf("unexpected findings: %+v", report.Findings)
+				}
+				return
+			}
+			if len(report.Findings) != 1 || report.Findings[0].Probe != tt.wantProbe {
+				t.Fatalf("findings = %+v, want one %s finding", report.Findings, tt.wantProbe)
+			}
+		})
+	}
+
+	// The env probe restores the operator's env.
+	if got := os.Getenv("AUCTION_SERVICE_ADDRESS"); got != testAddress {
+		t.Fatalf("AUCTION_SERVICE_ADDRESS = %q after check, want %q", got, testAddress)
+	}
+}
+
+func Test_RunCheckDeterminism(t *testing.T) {
+	t.Setenv("PERFORMER_CONFIG", "")
+	path := filepath.Join(t.TempDir(), "task.json")
+	if err := os.WriteFile(path, []byte(testInsuranceEnvelope), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runCheckDeterminism([]string{path, "-"}, strings.NewReader(testAuctionEnvelope), &out); err != nil {
+		t.Fatalf("runCheckDeterminism: %v", err)
+	}
+	dec := json.NewDecoder(&out)
+	for _, want := range []string{KindInsurancePayout, KindAuctionSettlement} {
+		var report struct {
+			File     string               `json:"file"`
+			Kind     string               `json:"kind"`
+			Findings []determinismFinding `json:"findings"`
+		}
+		if err := dec.Decode(&report); err != nil {
+			t.Fatalf("decode report: %v", err)
+		}
+		if report.Kind != want || len(report.Findings) > 0 {
+			t.Fatalf("report = %+v, want deterministic %s", report, want)
+		}
+	}
+
+	if err := runCheckDeterminism(nil, nil, &out); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Fatalf("no payloads = %v, want usage error", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errShuttingDown is returned for tasks that arrive after shutdown has begun.
+var errShuttingDown = errors.New("performer is shutting down")
+
+// taskGate tracks in-flight task calls so shutdown can stop admitting new tasks and
+// wait for the running ones to finish.
+type taskGate struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+}
+
+// enter admits a task call. Every successful enter must be paired with leave.
+func (g *taskGate) enter() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return errShuttingDown
+	}
+	g.inflight.Add(1)
+	return nil
+}
+
+// draining reports whether shutdown has begun.
+func (g *taskGate) draining() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+func (g *taskGate) leave() {
+	g.inflight.Done()
+}
+
+// drain stops admitting tasks and waits for in-flight ones until ctx is done.
+func (g *taskGate) drain(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
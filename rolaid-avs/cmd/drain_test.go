@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_DrainWaitsForInflightTasks(t *testing.T) {
+	w := NewTaskWorker(zap.NewNop())
+	defer w.Close()
+
+	if err := w.tasks.enter(); err != nil {
+		t.Fatalf("enter: %v", err)
+	}
+
+	drained := make(chan error, 1)
+	go func() { drained <- w.Drain(context.Background()) }()
+
+	// New tasks are rejected as soon as draining starts.
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, err := w.HandleTask(&performerV1.TaskRequest{TaskId: []byte("t"), Payload: []byte(testAuctionEnvelope)})
+		if errors.Is(err, errShuttingDown) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("task admitted while draining: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned with a task in flight: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	w.tasks.leave()
+	if err := <-drained; err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+}
+
+func Test_DrainDeadlineCancelsTasks(t *testing.T) {
+	w := NewTaskWorker(zap.NewNop())
+	defer w.Close()
+
+	if err := w.tasks.enter(); err != nil {
+		t.Fatalf("enter: %v", err)
+	}
+	defer w.tasks.leave()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := w.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("drain error = %v, want deadline exceeded", err)
+	}
+	if w.taskCtx.Err() == nil {
+		t.Fatal("task context not cancelled after drain deadline")
+	}
+}
+
+// blockingHandler is an echo kind whose calls wait for their context to end.
+type blockingHandler struct{ echoHandler }
+
+func (h *blockingHandler) Validate(ctx context.Context, payload interface{}) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, payload interface{}) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func Test_TaskTimeout(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Timeout = 20 * time.Millisecond
+	w := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer w.Close()
+	if err := w.RegisterHandler(&blockingHandler{}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &performerV1.TaskRequest{TaskId: []byte("t"), Payload: []byte(`{"version": 1, "kind": "echo", "echo": {"message": "hi"}}`)}
+	if err := w.ValidateTask(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ValidateTask = %v, want deadline exceeded", err)
+	}
+	if _, err := w.HandleTask(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("HandleTask = %v, want deadline exceeded", err)
+	}
+}
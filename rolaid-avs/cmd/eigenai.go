@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/eigenai"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func (c *EigenAIConfig) validate() error {
+	if _, err := eigenai.BaseURL(c.Env); err != nil {
+		return err
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+	if c.PrivateKey != "" {
+		if _, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x")); err != nil {
+			return fmt.Errorf("private_key: %w", err)
+		}
+	}
+	return nil
+}
+
+// budget is the longest an insurance task can wait on EigenAI: one Complete, or two
+// when determinism is verified.
+func (c *EigenAIConfig) budget() time.Duration {
+	d := eigenai.Config{Timeout: c.Timeout, Retries: c.Retries}.MaxComplete()
+	if c.VerifyDeterminism {
+		d *= 2
+	}
+	return d
+}
+
+// newClient builds the EigenAI client, or returns nil when neither an API key nor a
+// grant private key is configured.
+func (c *EigenAIConfig) newClient() (*eigenai.Client, error) {
+	if c.APIKey == "" && c.PrivateKey == "" {
+		return nil, nil
+	}
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		var err error
+		if baseURL, err = eigenai.BaseURL(c.Env); err != nil {
+			return nil, err
+		}
+	}
+	cfg := eigenai.Config{
+		BaseURL:        baseURL,
+		GrantServerURL: c.GrantServerURL,
+		Model:          c.Model,
+		APIKey:         c.APIKey,
+		Timeout:        c.Timeout,
+		Retries:        c.Retries,
+	}
+	if c.APIKey == "" {
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("eigenai private key: %w", err)
+		}
+		cfg.PrivateKey = key
+	}
+	return eigenai.New(cfg)
+}
+
+// modelClaims asks the task's EigenAI model what each policy pays for the task's events
+// and returns the claims per beneficiary. Every operator sends the same prompt, model and
+// seed, so they all get the same answer and sign the same vector.
+func (h *insurancePayoutHandler) modelClaims(ctx context.Context, ins *InsuranceTask, policies []payout.Policy, pot wei.Amount) (map[common.Address]*big.Int, error) {
+	if h.tw.eigenAI == nil {
+		return nil, fmt.Errorf("insurance.model %q: EigenAI client not configured", ins.Model)
+	}
+	if err := payout.ValidatePolicies(policies); err != nil {
+		return nil, fieldErrorf("insurance.policies", "%v", err)
+	}
+	req := eigenai.Request{Model: ins.Model, Prompt: insurancePrompt(ins, policies, pot), Seed: ins.Seed}
+	var answer string
+	var err error
+	if h.tw.config.EigenAI.VerifyDeterminism {
+		answer, err = h.tw.eigenAI.VerifyDeterminism(ctx, req)
+	} else {
+		answer, err = h.tw.eigenAI.Complete(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseModelClaims(answer, policies)
+}
+
+// insurancePrompt renders the task as the question put to the model. It depends only on
+// the task, with policies in task order.
+func insurancePrompt(ins *InsuranceTask, policies []payout.Policy, pot wei.Amount) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are an insurance actuary. Decide the payout for each policy in batch %q.\n", ins.PolicyBatchId)
+	fmt.Fprintf(&b, "Events: %s.\n", strings.Join(ins.Events, ", "))
+	if len(ins.PriceMoves) > 0 {
+		b.WriteString("Price moves (event, asset: change in basis points):\n")
+		for _, m := range ins.PriceMoves {
+			fmt.Fprintf(&b, "- %s, %s: %+d\n", m.Event, m.Asset, m.MoveBps)
+		}
+	}
+	fmt.Fprintf(&b, "The pot is %s wei.\n", pot)
+	b.WriteString("Policies (id: coverage in wei, covered events):\n")
+	for _, p := range policies {
+		covered := "all events"
+		if len(p.Events) > 0 {
+			covered = strings.Join(p.Events, ", ")
+		}
+		fmt.Fprintf(&b, "- %s: %s wei, %s\n", p.Id, p.Coverage, covered)
+	}
+	b.WriteString(`Reply with only a JSON object mapping each policy id to its payout in wei as a decimal string, e.g. {"policy-id": "0"}. A payout must not exceed the policy's coverage.`)
+	return b.String()
+}
+
+// parseModelClaims reads the model's JSON answer. Payouts above a policy's coverage are
+// capped at it, policies the answer leaves out pay nothing and unknown ids are an error.
+func parseModelClaims(answer string, policies []payout.Policy) (map[common.Address]*big.Int, error) {
+	start, end := strings.Index(answer, "{"), strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("model answer has no JSON object: %q", answer)
+	}
+	var amounts map[string]string
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &amounts); err != nil {
+		return nil, fmt.Errorf("model answer: %w", err)
+	}
+
+	byId := make(map[string]payout.Policy, len(policies))
+	for _, p := range policies {
+		byId[p.Id] = p
+	}
+	ids := make([]string, 0, len(amounts))
+	for id := range amounts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	claims := make(map[common.Address]*big.Int)
+	for _, id := range ids {
+		p, ok := byId[id]
+		if !ok {
+			return nil, fmt.Errorf("model answer names unknown policy %q", id)
+		}
+		amount, err := wei.Parse(amounts[id])
+		if err != nil {
+			return nil, fmt.Errorf("model answer for policy %q: %w", id, err)
+		}
+		claim := amount.Big()
+		if claim.Cmp(p.Coverage) > 0 {
+			claim.Set(p.Coverage)
+		}
+		if c, ok := claims[p.Beneficiary]; ok {
+			c.Add(c, claim)
+		} else {
+			claims[p.Beneficiary] = claim
+		}
+	}
+	return claims, nil
+}
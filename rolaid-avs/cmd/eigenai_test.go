@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var testModelEnvelope = strings.Replace(testInsuranceEnvelope, `"seed": 42,`, `"seed": 42, "model": "test-model",`, 1)
+
+func Test_InsurancePayoutModel(t *testing.T) {
+	var prompts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model    string `json:"model"`
+			Seed     uint64 `json:"seed"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || r.Header.Get("x-api-key") != "key-1" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if req.Model != "test-model" || req.Seed != 42 {
+			t.Errorf("model %q seed %d, want test-model 42", req.Model, req.Seed)
+		}
+		prompts = append(prompts, req.Messages[0].Content)
+		// p-1 asks above its 600 wei coverage and is capped.
+		fmt.Fprintf(w, `{"choices": [{"message": {"content": %q}}]}`, `Payouts: {"p-1": "900", "p-2": "200"}`)
+	}))
+	defer srv.Close()
+
+	cfg := defaultConfig()
+	cfg.EigenAI.BaseURL = srv.URL
+	cfg.EigenAI.APIKey = "key-1"
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+
+	resp, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testModelEnvelope)})
+	if err != nil {
+		t.Fatalf("HandleTask: %v", err)
+	}
+	if len(prompts) != 2 || prompts[0] != prompts[1] {
+		t.Fatalf("determinism check sent %d prompts, want the same prompt twice", len(prompts))
+	}
+	res, err := results.DecodeInsurancePayout(resp.GetResult())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if res.ModelSource != results.ModelEigenAI || res.ModelVersion != "test-model" {
+		t.Errorf("model = %d %q, want EigenAI test-model", res.ModelSource, res.ModelVersion)
+	}
+	// 600 + 200 claimed against the 1000 wei pot pays in full.
+	want := map[common.Address]int64{
+		common.HexToAddress(testAddress):     600,
+		common.HexToAddress(testBeneficiary): 200,
+	}
+	if len(res.Beneficiaries) != len(want) {
+		t.Fatalf("vector = %v %v, want %v", res.Beneficiaries, res.Amounts, want)
+	}
+	for i, b := range res.Beneficiaries {
+		if res.Amounts[i].Int64() != want[b] {
+			t.Errorf("payout to %s = %s, want %d", b.Hex(), res.Amounts[i], want[b])
+		}
+	}
+}
+
+func Test_InsuranceModelUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := defaultConfig()
+	cfg.EigenAI.BaseURL = srv.URL
+	cfg.EigenAI.APIKey = "key-1"
+	cfg.EigenAI.Retries = 0
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+
+	// The task names the model, so no operator may decide it with another one.
+	if _, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testModelEnvelope)}); err == nil {
+		t.Fatal("HandleTask succeeded with EigenAI down")
+	}
+}
+
+func Test_InsuranceModelWithoutClient(t *testing.T) {
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), defaultConfig())
+	defer tw.Close()
+	err := tw.ValidateTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testModelEnvelope)})
+	if err == nil || !strings.Contains(err.Error(), "insurance.model") {
+		t.Fatalf("ValidateTask = %v, want an insurance.model error", err)
+	}
+}
+
+func Test_ParseModelClaims(t *testing.T) {
+	policies := []payout.Policy{
+		{Id: "p-1", Beneficiary: common.HexToAddress(testAddress), Coverage: big.NewInt(600)},
+		{Id: "p-2", Beneficiary: common.HexToAddress(testAddress), Coverage: big.NewInt(600)},
+	}
+	tests := []struct {
+		name    string
+		answer  string
+		want    int64
+		wantErr string
+	}{
+		{name: "beneficiary claims summed", answer: `{"p-1": "100", "p-2": "50"}`, want: 150},
+		{name: "capped at coverage", answer: `{"p-1": "900"}`, want: 600},
+		{name: "no object", answer: "nothing to pay", wantErr: "no JSON object"},
+		{name: "unknown policy", answer: `{"p-9": "1"}`, wantErr: `unknown policy "p-9"`},
+		{name: "bad amount", answer: `{"p-1": "-1"}`, wantErr: `policy "p-1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := parseModelClaims(tt.answer, policies)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseModelClaims = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseModelClaims: %v", err)
+			}
+			if got := claims[common.HexToAddress(testAddress)]; got == nil || got.Int64() != tt.want {
+				t.Fatalf("claim = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
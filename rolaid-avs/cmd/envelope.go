@@ -0,0 +1,404 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/actuarial"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/auction"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lvr"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Task kinds handled by the built-in handlers.
+const (
+	KindAuctionSettlement = "auction_settlement"
+	KindInsurancePayout   = "insurance_payout"
+)
+
+// EnvelopeVersionV1 is the first versioned envelope schema. Envelopes that omit
+// the version field are treated as v1 so task creators predating the field keep working.
+const EnvelopeVersionV1 uint32 = 1
+
+// Task envelope schema (JSON over TaskRequest.Payload) routed to the handler registered
+// for Kind. The kind payload sits under the handler's payload key, e.g.
+//
+//	{"version": 1, "kind": "auction_settlement", "auction": {...}}
+type TaskEnvelope struct {
+	Version  uint32            `json:"version,omitempty"`
+	Kind     string            `json:"kind"`
+	Metadata map[string]string `json:"meta,omitempty"`
+
+	// Payload is the kind payload decoded by the handler (*AuctionTask, *InsuranceTask, ...).
+	Payload interface{} `json:"-"`
+}
+
+type AuctionTask struct {
+	AuctionId       uint64 `json:"auction_id"`
+	PoolId          string `json:"pool_id"`                    // bytes32 hex
+	OracleUpdateId  string `json:"oracle_update_id"`           // bytes32 hex
+	SettlementData  string `json:"settlement_data"`            // hex-encoded payload to submit onchain
+	ExpectedBidWei  string `json:"expected_bid_wei"`           // bid paid with the settlement, e.g. "1000", "0x3e8", "0.001ether"
+	AppId           string `json:"app_id"`                     // EigenCompute appId (hex)
+	ImageDigest     string `json:"image_digest"`               // Docker digest (hex)
+	SubmissionNonce uint64 `json:"submission_nonce"`           // replay guard, must increase per (auction_service, auction_id)
+	AuctionService  string `json:"auction_service"`            // contract the result is bound to
+	SettlementVault string `json:"settlement_vault,omitempty"` // optional override
+	ReferenceBlock  uint64 `json:"reference_block,omitempty"`  // L1 block the chain reads signed into the result are pinned to
+
+	// Winner is the bidder the settlement pays for, signed into the result. Without a
+	// sealed-bid book it names the winner; with one it is an optional cross-check.
+	Winner string `json:"winner,omitempty"`
+
+	// DistributionRoot is the Merkle root (pkg/merkle, `performer merkle-distribution`)
+	// of how the LP share of the proceeds is split; it is signed into the result.
+	DistributionRoot string `json:"distribution_root,omitempty"`
+
+	// OracleSqrtPriceX96 is the oracle price for oracle_update_id as a Uniswap
+	// sqrtPriceX96 (decimal, token1 per token0). When set, the pool's LVR at that price
+	// (pkg/lvr) is read at reference_block, from the PoolManager of lvr_auction_hook, and
+	// signed into the result. PoolManager does not store the pool's tick spacing, so
+	// tick_spacing carries the pool key's to find the initialized ticks the arbitrage
+	// crosses.
+	OracleSqrtPriceX96 string `json:"oracle_sqrt_price_x96,omitempty"`
+	LVRAuctionHook     string `json:"lvr_auction_hook,omitempty"` // required with oracle_sqrt_price_x96
+	TickSpacing        int32  `json:"tick_spacing,omitempty"`     // required with oracle_sqrt_price_x96
+
+	// SealedBids, when present, decides the settlement: the winning reveal supplies the
+	// settlement data, so settlement_data must be omitted.
+	SealedBids *SealedBidBook `json:"sealed_bids,omitempty"`
+}
+
+// SealedBidBook carries the commits and reveals of a sealed-bid auction. Each entry is
+// signed by its bidder as EIP-712 typed data (pkg/auction CommitDigest, RevealDigest)
+// under chain_id and the task's auction_service, time included, so the relay serving the
+// book cannot forge or backdate entries. Every operator replays the same book through
+// pkg/auction and so reaches the same winner.
+type SealedBidBook struct {
+	ChainId     uint64            `json:"chain_id"`     // chain of auction_service, in the signing domain
+	StartTime   uint64            `json:"start_time"`   // AuctionService auctions(id).startTime
+	RevealStart uint64            `json:"reveal_start"` // end of the commit phase, start of the reveal phase
+	EndTime     uint64            `json:"end_time"`     // AuctionService auctions(id).endTime
+	Commits     []SealedBidCommit `json:"commits"`
+	Reveals     []SealedBidReveal `json:"reveals"`
+}
+
+type SealedBidCommit struct {
+	Bidder     string `json:"bidder"`
+	Commitment string `json:"commitment"` // bytes32 hex, see auction.SealBid
+	Time       uint64 `json:"time"`
+	Signature  string `json:"signature"` // bidder's 65-byte signature of auction.CommitDigest
+}
+
+type SealedBidReveal struct {
+	Bidder         string `json:"bidder"`
+	AmountWei      string `json:"amount_wei"`      // wei amount, see expected_bid_wei
+	SettlementData string `json:"settlement_data"` // hex-encoded payload submitted if this bid wins
+	Salt           string `json:"salt"`            // bytes32 hex
+	Time           uint64 `json:"time"`
+	Signature      string `json:"signature"` // bidder's 65-byte signature of auction.RevealDigest
+}
+
+type InsuranceTask struct {
+	PolicyBatchId   string   `json:"policy_batch_id"`
+	Events          []string `json:"events"`           // descriptions / ids
+	Seed            uint64   `json:"seed"`             // for deterministic EigenAI call
+	AmountWei       string   `json:"amount_wei"`       // total pot to allocate, wei amount
+	AppId           string   `json:"app_id"`           // EigenCompute appId (hex)
+	ImageDigest     string   `json:"image_digest"`     // Docker digest (hex)
+	SettlementVault string   `json:"settlement_vault"` // contract the result is bound to
+
+	// Policies is the batch the pot is allocated across, see pkg/payout.
+	Policies []InsurancePolicy `json:"policies"`
+	// PriceMoves are the price changes observed during Events, read by the actuarial model.
+	PriceMoves []InsurancePriceMove `json:"price_moves,omitempty"`
+	// Model is the EigenAI model that decides each policy's claim, queried with Seed.
+	// When set and EigenAI fails, the task fails, so every operator decides with the same
+	// model. When empty the rule-based model in pkg/actuarial decides instead, which is
+	// how a batch is paid out during an EigenAI outage.
+	Model string `json:"model,omitempty"`
+}
+
+type InsurancePriceMove struct {
+	Event   string `json:"event"`           // one of Events
+	Asset   string `json:"asset,omitempty"` // asset whose price moved, for the record
+	MoveBps int64  `json:"move_bps"`        // signed change in basis points
+}
+
+type InsurancePolicy struct {
+	PolicyId    string   `json:"policy_id"`
+	Beneficiary string   `json:"beneficiary"`      // address paid if the policy is hit
+	CoverageWei string   `json:"coverage_wei"`     // wei amount claimed when hit
+	Events      []string `json:"events,omitempty"` // events covered; empty covers every event
+}
+
+// FieldError reports which envelope field failed validation, using the JSON path
+// of the field (e.g. "auction.pool_id").
+type FieldError struct {
+	Field string
+	Msg   string
+	// Err is an optional cause or error class (ErrMissingField, ErrBadHex) for errors.Is.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Msg)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Error classes carried by FieldError.
+var (
+	ErrMissingField = errors.New("missing field")
+	ErrBadHex       = errors.New("bad hex")
+)
+
+func fieldErrorf(field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Msg: fmt.Sprintf(format, args...)}
+}
+
+// classFieldErrorf is fieldErrorf wrapping a cause or error class.
+func classFieldErrorf(class error, field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Msg: fmt.Sprintf(format, args...), Err: class}
+}
+
+// validateAuctionV1 is the schema check for auction_settlement v1 payloads.
+func validateAuctionV1(a *AuctionTask) error {
+	// Shape checks only; attestation is verified onchain via registry/AuctionService.
+	for _, f := range []struct{ name, val string }{
+		{"auction.pool_id", a.PoolId},
+		{"auction.oracle_update_id", a.OracleUpdateId},
+		{"auction.app_id", a.AppId},
+		{"auction.image_digest", a.ImageDigest},
+	} {
+		if err := requireBytes32(f.name, f.val); err != nil {
+			return err
+		}
+	}
+	if _, err := decodeHexBytes(a.SettlementData); err != nil {
+		return classFieldErrorf(ErrBadHex, "auction.settlement_data", "invalid hex: %v", err)
+	}
+	// Without a sealed-bid book the task names the bid; with one, the winner does and
+	// expected_bid_wei is an optional cross-check.
+	if a.SealedBids == nil || a.ExpectedBidWei != "" {
+		if _, err := parseWeiField("auction.expected_bid_wei", a.ExpectedBidWei); err != nil {
+			return err
+		}
+	}
+	if a.SealedBids != nil {
+		if a.SettlementData != "" {
+			return fieldErrorf("auction.settlement_data", "must be omitted when sealed_bids is set")
+		}
+		if err := validateSealedBids(a); err != nil {
+			return err
+		}
+	}
+	if a.Winner != "" {
+		if err := requireAddress("auction.winner", a.Winner); err != nil {
+			return err
+		}
+	}
+	if a.DistributionRoot != "" {
+		if err := requireBytes32("auction.distribution_root", a.DistributionRoot); err != nil {
+			return err
+		}
+	}
+	if a.OracleSqrtPriceX96 != "" {
+		price, ok := new(big.Int).SetString(a.OracleSqrtPriceX96, 10)
+		if !ok {
+			return fieldErrorf("auction.oracle_sqrt_price_x96", "must be a decimal integer")
+		}
+		if _, err := lvr.TickAtSqrtPrice(price); err != nil {
+			return fieldErrorf("auction.oracle_sqrt_price_x96", "%v", err)
+		}
+		// The estimate is signed, so every operator must read the pool at the same block.
+		if a.ReferenceBlock == 0 {
+			return fieldErrorf("auction.reference_block", "required with oracle_sqrt_price_x96")
+		}
+		// Like auction_service, the pool is read through the hook the task names, so
+		// operators configured differently still read the same pool.
+		if err := requireAddress("auction.lvr_auction_hook", a.LVRAuctionHook); err != nil {
+			return err
+		}
+		if a.TickSpacing < lvr.MinTickSpacing || a.TickSpacing > lvr.MaxTickSpacing {
+			return fieldErrorf("auction.tick_spacing", "must be in [%d, %d] with oracle_sqrt_price_x96", lvr.MinTickSpacing, lvr.MaxTickSpacing)
+		}
+	}
+	if a.SubmissionNonce == 0 {
+		return fieldErrorf("auction.submission_nonce", "must be greater than zero")
+	}
+	// The result embeds auction_service, so it comes from the task rather than operator
+	// config: operators configured differently must still sign identical bytes.
+	if err := requireAddress("auction.auction_service", a.AuctionService); err != nil {
+		return err
+	}
+	if a.SettlementVault != "" {
+		if err := requireAddress("auction.settlement_vault", a.SettlementVault); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSealedBids checks the shape of a task's sealed-bid book and that each entry is
+// signed by its bidder. Entries that are well formed but invalid for the auction (late,
+// unmatched) are skipped when the book is replayed.
+func validateSealedBids(a *AuctionTask) error {
+	b := a.SealedBids
+	w := auction.Window{Start: b.StartTime, RevealStart: b.RevealStart, End: b.EndTime}
+	if err := w.Validate(); err != nil {
+		return fieldErrorf("auction.sealed_bids.reveal_start", "%v", err)
+	}
+	if b.ChainId == 0 {
+		return classFieldErrorf(ErrMissingField, "auction.sealed_bids.chain_id", "missing")
+	}
+	if err := requireAddress("auction.auction_service", a.AuctionService); err != nil {
+		return err
+	}
+	auctionId := new(big.Int).SetUint64(a.AuctionId)
+	domain := auction.SigningDomain{ChainId: new(big.Int).SetUint64(b.ChainId), AuctionService: common.HexToAddress(a.AuctionService)}
+	for i, c := range b.Commits {
+		field := fmt.Sprintf("auction.sealed_bids.commits[%d]", i)
+		if err := requireAddress(field+".bidder", c.Bidder); err != nil {
+			return err
+		}
+		if err := requireBytes32(field+".commitment", c.Commitment); err != nil {
+			return err
+		}
+		digest := auction.CommitDigest(domain, auctionId, common.HexToAddress(c.Bidder), common.HexToHash(c.Commitment), c.Time)
+		if err := verifyBidSignature(field+".signature", c.Signature, digest, c.Bidder); err != nil {
+			return err
+		}
+	}
+	for i, r := range b.Reveals {
+		field := fmt.Sprintf("auction.sealed_bids.reveals[%d]", i)
+		if err := requireAddress(field+".bidder", r.Bidder); err != nil {
+			return err
+		}
+		amount, err := parseWeiField(field+".amount_wei", r.AmountWei)
+		if err != nil {
+			return err
+		}
+		data, err := decodeHexBytes(r.SettlementData)
+		if err != nil {
+			return classFieldErrorf(ErrBadHex, field+".settlement_data", "invalid hex: %v", err)
+		}
+		if err := requireBytes32(field+".salt", r.Salt); err != nil {
+			return err
+		}
+		bid := auction.Bid{Bidder: common.HexToAddress(r.Bidder), Amount: amount.Big(), SettlementData: data}
+		digest := auction.RevealDigest(domain, auctionId, bid, common.HexToHash(r.Salt), r.Time)
+		if err := verifyBidSignature(field+".signature", r.Signature, digest, r.Bidder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBidSignature checks that sig, hex-encoded, is bidder's signature of digest. A
+// forged entry means the relay is not passing on what bidders sent, so it fails the task
+// rather than being skipped.
+func verifyBidSignature(field, sig string, digest common.Hash, bidder string) error {
+	if sig == "" {
+		return classFieldErrorf(ErrMissingField, field, "missing")
+	}
+	raw, err := decodeHexBytes(sig)
+	if err != nil {
+		return classFieldErrorf(ErrBadHex, field, "invalid hex: %v", err)
+	}
+	if err := auction.VerifySignature(digest, raw, common.HexToAddress(bidder)); err != nil {
+		return classFieldErrorf(err, field, "%v", err)
+	}
+	return nil
+}
+
+// validateInsuranceV1 is the schema check for insurance_payout v1 payloads.
+func validateInsuranceV1(ins *InsuranceTask) error {
+	if ins.PolicyBatchId == "" {
+		return classFieldErrorf(ErrMissingField, "insurance.policy_batch_id", "missing")
+	}
+	if _, err := parseWeiField("insurance.amount_wei", ins.AmountWei); err != nil {
+		return err
+	}
+	if err := requireBytes32("insurance.app_id", ins.AppId); err != nil {
+		return err
+	}
+	if err := requireBytes32("insurance.image_digest", ins.ImageDigest); err != nil {
+		return err
+	}
+	if err := requireAddress("insurance.settlement_vault", ins.SettlementVault); err != nil {
+		return err
+	}
+	if len(ins.Policies) == 0 {
+		return classFieldErrorf(ErrMissingField, "insurance.policies", "missing")
+	}
+	seen := make(map[string]bool, len(ins.Policies))
+	for i, p := range ins.Policies {
+		field := fmt.Sprintf("insurance.policies[%d]", i)
+		if p.PolicyId == "" {
+			return classFieldErrorf(ErrMissingField, field+".policy_id", "missing")
+		}
+		if seen[p.PolicyId] {
+			return fieldErrorf(field+".policy_id", "duplicate policy id %q", p.PolicyId)
+		}
+		seen[p.PolicyId] = true
+		if err := requireAddress(field+".beneficiary", p.Beneficiary); err != nil {
+			return err
+		}
+		if _, err := parseWeiField(field+".coverage_wei", p.CoverageWei); err != nil {
+			return err
+		}
+	}
+	events := make(map[string]bool, len(ins.Events))
+	for _, e := range ins.Events {
+		events[e] = true
+	}
+	for i, m := range ins.PriceMoves {
+		field := fmt.Sprintf("insurance.price_moves[%d]", i)
+		if !events[m.Event] {
+			return fieldErrorf(field+".event", "%q is not one of the task's events", m.Event)
+		}
+		if m.MoveBps < -actuarial.FullBps {
+			return fieldErrorf(field+".move_bps", "price cannot fall %d bps", -m.MoveBps)
+		}
+	}
+	return nil
+}
+
+// parseWeiField parses a wei amount field (see pkg/wei), reporting errors against its
+// JSON path.
+func parseWeiField(field, val string) (wei.Amount, error) {
+	if val == "" {
+		return wei.Amount{}, classFieldErrorf(ErrMissingField, field, "missing")
+	}
+	amount, err := wei.Parse(val)
+	if err != nil {
+		return wei.Amount{}, classFieldErrorf(err, field, "%v", err)
+	}
+	return amount, nil
+}
+
+// requireBytes32 checks val is a 0x-prefixed 32-byte hex string.
+func requireBytes32(field, val string) error {
+	return requireHexBytes(field, val, 32)
+}
+
+// requireAddress checks val is a 0x-prefixed 20-byte hex string.
+func requireAddress(field, val string) error {
+	return requireHexBytes(field, val, 20)
+}
+
+func requireHexBytes(field, val string, size int) error {
+	if err := requireHex(field, val, 2+2*size); err != nil {
+		return err
+	}
+	if _, err := hex.DecodeString(val[2:]); err != nil {
+		return classFieldErrorf(ErrBadHex, field, "invalid hex: %v", err)
+	}
+	return nil
+}
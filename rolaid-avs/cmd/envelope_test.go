@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testBytes32A = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	testBytes32B = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	testAddress  = "0x00000000000000000000000000000000000000a1"
+	// testBeneficiary is the second policy holder in testInsuranceEnvelope.
+	testBeneficiary = "0x00000000000000000000000000000000000000b2"
+
+	testAuctionEnvelope = `{
+		"version": 1,
+		"kind": "auction_settlement",
+		"auction": {
+			"auction_id": 7,
+			"pool_id": "` + testBytes32A + `",
+			"oracle_update_id": "` + testBytes32B + `",
+			"settlement_data": "0xdeadbeef",
+			"expected_bid_wei": "1000",
+			"app_id": "` + testBytes32A + `",
+			"image_digest": "` + testBytes32B + `",
+			"submission_nonce": 1,
+			"auction_service": "` + testAddress + `"
+		}
+	}`
+
+	testInsuranceEnvelope = `{
+		"version": 1,
+		"kind": "insurance_payout",
+		"insurance": {
+			"policy_batch_id": "batch-1",
+			"events": ["depeg"],
+			"seed": 42,
+			"amount_wei": "1000",
+			"app_id": "` + testBytes32A + `",
+			"image_digest": "` + testBytes32B + `",
+			"settlement_vault": "` + testAddress + `",
+			"policies": ` + testInsurancePolicies + `
+		}
+	}`
+
+	// testInsurancePolicies claim 1200 wei against the 1000 wei pot, which splits 500/500.
+	testInsurancePolicies = `[
+		{"policy_id": "p-1", "beneficiary": "` + testAddress + `", "coverage_wei": "600", "events": ["depeg"]},
+		{"policy_id": "p-2", "beneficiary": "` + testBeneficiary + `", "coverage_wei": "600"}
+	]`
+)
+
+func Test_DecodeEnvelope(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantField string
+		wantErr   string
+	}{
+		{name: "auction v1", data: testAuctionEnvelope},
+		{name: "insurance v1", data: testInsuranceEnvelope},
+		{
+			name: "missing version defaults to v1",
+			data: strings.Replace(testAuctionEnvelope, `"version": 1,`, "", 1),
+		},
+		{
+			name:    "unknown top-level field",
+			data:    strings.Replace(testAuctionEnvelope, `"kind"`, `"knd": "x", "kind"`, 1),
+			wantErr: `unknown field "knd"`,
+		},
+		{
+			name:    "unknown payload field",
+			data:    strings.Replace(testAuctionEnvelope, `"auction_id"`, `"auction_idd": 1, "auction_id"`, 1),
+			wantErr: `unknown field "auction_idd"`,
+		},
+		{
+			name:    "trailing data",
+			data:    testAuctionEnvelope + `{}`,
+			wantErr: "trailing data",
+		},
+		{
+			name:      "misspelled kind",
+			data:      strings.Replace(testAuctionEnvelope, `"auction_settlement"`, `"auction_setlement"`, 1),
+			wantField: "kind",
+		},
+		{
+			name:      "unsupported version",
+			data:      strings.Replace(testAuctionEnvelope, `"version": 1`, `"version": 99`, 1),
+			wantField: "version",
+		},
+		{
+			name:      "payload missing for kind",
+			data:      `{"version": 1, "kind": "insurance_payout"}`,
+			wantField: "insurance",
+		},
+		{
+			name:      "payload of another kind",
+			data:      strings.Replace(testAuctionEnvelope, `"kind"`, `"insurance": {}, "kind"`, 1),
+			wantField: "insurance",
+		},
+		{
+			name:      "bad pool id hex",
+			data:      strings.Replace(testAuctionEnvelope, `"pool_id": "0x11`, `"pool_id": "0xzz`, 1),
+			wantField: "auction.pool_id",
+		},
+		{
+			name:      "short oracle update id",
+			data:      strings.Replace(testAuctionEnvelope, `"oracle_update_id": "`+testBytes32B+`"`, `"oracle_update_id": "0x22"`, 1),
+			wantField: "auction.oracle_update_id",
+		},
+		{
+			name:      "missing image digest",
+			data:      strings.Replace(testInsuranceEnvelope, `"image_digest": "`+testBytes32B+`"`, `"image_digest": ""`, 1),
+			wantField: "insurance.image_digest",
+		},
+		{
+			name: "unit-suffixed bid",
+			data: strings.Replace(testAuctionEnvelope, `"expected_bid_wei": "1000"`, `"expected_bid_wei": "0.001ether"`, 1),
+		},
+		{
+			name:      "bid over uint96",
+			data:      strings.Replace(testAuctionEnvelope, `"expected_bid_wei": "1000"`, `"expected_bid_wei": "0x1000000000000000000000000"`, 1),
+			wantField: "auction.expected_bid_wei",
+			wantErr:   "exceeds uint96",
+		},
+		{
+			name:      "negative pot",
+			data:      strings.Replace(testInsuranceEnvelope, `"amount_wei": "1000"`, `"amount_wei": "-1"`, 1),
+			wantField: "insurance.amount_wei",
+		},
+		{
+			name:      "bad distribution root",
+			data:      strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"distribution_root": "0x12", "submission_nonce"`, 1),
+			wantField: "auction.distribution_root",
+		},
+		{
+			name: "oracle price",
+			data: strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"oracle_sqrt_price_x96": "79228162514264337593543950336", "reference_block": 100, "lvr_auction_hook": "0x00000000000000000000000000000000000000c1", "tick_spacing": 10, "submission_nonce"`, 1),
+		},
+		{
+			name:      "oracle price without tick spacing",
+			data:      strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"oracle_sqrt_price_x96": "79228162514264337593543950336", "reference_block": 100, "lvr_auction_hook": "0x00000000000000000000000000000000000000c1", "submission_nonce"`, 1),
+			wantField: "auction.tick_spacing",
+		},
+		{
+			name:      "oracle price without hook",
+			data:      strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"oracle_sqrt_price_x96": "79228162514264337593543950336", "reference_block": 100, "submission_nonce"`, 1),
+			wantField: "auction.lvr_auction_hook",
+		},
+		{
+			name:      "oracle price without reference block",
+			data:      strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"oracle_sqrt_price_x96": "79228162514264337593543950336", "submission_nonce"`, 1),
+			wantField: "auction.reference_block",
+		},
+		{
+			name:      "oracle price out of range",
+			data:      strings.Replace(testAuctionEnvelope, `"submission_nonce"`, `"oracle_sqrt_price_x96": "1", "reference_block": 100, "lvr_auction_hook": "0x00000000000000000000000000000000000000c1", "tick_spacing": 10, "submission_nonce"`, 1),
+			wantField: "auction.oracle_sqrt_price_x96",
+		},
+		{
+			name:      "missing policies",
+			data:      strings.Replace(testInsuranceEnvelope, testInsurancePolicies, "[]", 1),
+			wantField: "insurance.policies",
+		},
+		{
+			name:      "duplicate policy id",
+			data:      strings.Replace(testInsuranceEnvelope, `"policy_id": "p-2"`, `"policy_id": "p-1"`, 1),
+			wantField: "insurance.policies[1].policy_id",
+		},
+		{
+			name:      "bad coverage",
+			data:      strings.Replace(testInsuranceEnvelope, `"coverage_wei": "600"`, `"coverage_wei": "1.5wei"`, 1),
+			wantField: "insurance.policies[0].coverage_wei",
+		},
+		{
+			name:      "price move for another event",
+			data:      strings.Replace(testInsuranceEnvelope, `"seed": 42,`, `"seed": 42, "price_moves": [{"event": "exploit", "move_bps": -500}],`, 1),
+			wantField: "insurance.price_moves[0].event",
+		},
+		{
+			name:      "price falls below zero",
+			data:      strings.Replace(testInsuranceEnvelope, `"seed": 42,`, `"seed": 42, "price_moves": [{"event": "depeg", "move_bps": -500}, {"event": "depeg", "move_bps": -10001}],`, 1),
+			wantField: "insurance.price_moves[1].move_bps",
+		},
+		{
+			name:      "missing bid",
+			data:      strings.Replace(testAuctionEnvelope, `"expected_bid_wei": "1000",`, "", 1),
+			wantField: "auction.expected_bid_wei",
+		},
+		{
+			name:      "missing auction service",
+			data:      strings.Replace(testAuctionEnvelope, `"auction_service": "`+testAddress+`"`, `"auction_service": ""`, 1),
+			wantField: "auction.auction_service",
+		},
+		{
+			name:      "missing settlement vault",
+			data:      strings.Replace(testInsuranceEnvelope, `"settlement_vault": "`+testAddress+`"`, `"settlement_vault": ""`, 1),
+			wantField: "insurance.settlement_vault",
+		},
+		{
+			name:      "bad settlement data",
+			data:      strings.Replace(testAuctionEnvelope, `"0xdeadbeef"`, `"0xdeadbee"`, 1),
+			wantField: "auction.settlement_data",
+		},
+	}
+
+	tw := NewTaskWorker(zap.NewNop())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, _, err := tw.registry.Decode([]byte(tt.data))
+			if tt.wantField == "" && tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if env.Version != EnvelopeVersionV1 {
+					t.Fatalf("version = %d, want %d", env.Version, EnvelopeVersionV1)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error, got envelope %+v", env)
+			}
+			if tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %q does not contain %q", err, tt.wantErr)
+			}
+			if tt.wantField != "" {
+				var fe *FieldError
+				if !errors.As(err, &fe) {
+					t.Fatalf("expected FieldError, got %T: %v", err, err)
+				}
+				if fe.Field != tt.wantField {
+					t.Fatalf("field = %q, want %q (%v)", fe.Field, tt.wantField, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/settlementvault"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/events"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// Protocol events followed by the event listener.
+const (
+	EventSwapObserved        = "SwapObserved"
+	EventAuctionAuthorized   = "AuctionAuthorized"
+	EventAuctionRevoked      = "AuctionRevoked"
+	EventAuctionCreated      = "AuctionCreated"
+	EventSettlementSubmitted = "SettlementSubmitted"
+	EventProceedsRecorded    = "ProceedsRecorded"
+)
+
+// eventListenerName keys the listener's checkpoint in the state database.
+const eventListenerName = "l1-protocol"
+
+// ProtocolEvent is a decoded protocol log. Data is the binding's event struct, e.g.
+// *auctionservice.AuctionServiceAuctionCreated.
+type ProtocolEvent struct {
+	Contract string // ContractAuctionService, ...
+	Name     string // EventAuctionCreated, ...
+	Log      types.Log
+	Data     interface{}
+}
+
+// EventSubscriber consumes the protocol events followed by the event listener. Events
+// arrive in chain order and may be redelivered after a restart. RollbackEvents undoes
+// the events above block after a reorg; they are delivered again from the new chain.
+type EventSubscriber interface {
+	HandleEvent(ctx context.Context, ev *ProtocolEvent) error
+	RollbackEvents(ctx context.Context, block uint64) error
+}
+
+// SubscribeEvents adds a consumer of protocol events. Subscribe before the listener runs.
+func (tw *TaskWorker) SubscribeEvents(s EventSubscriber) {
+	tw.eventSubscribers = append(tw.eventSubscribers, s)
+}
+
+// eventDecoder maps (contract address, event id) to the binding that parses the log.
+type eventDecoder map[common.Address]map[common.Hash]eventSpec
+
+type eventSpec struct {
+	contract string
+	name     string
+	parse    func(types.Log) (interface{}, error)
+}
+
+func (d eventDecoder) add(contract string, addr common.Address, meta *bind.MetaData, name string, parse func(types.Log) (interface{}, error)) error {
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		return err
+	}
+	ev, ok := parsed.Events[name]
+	if !ok {
+		return fmt.Errorf("%s ABI has no event %s", contract, name)
+	}
+	if d[addr] == nil {
+		d[addr] = make(map[common.Hash]eventSpec)
+	}
+	d[addr][ev.ID] = eventSpec{contract: contract, name: name, parse: parse}
+	return nil
+}
+
+func (d eventDecoder) decode(l types.Log) (*ProtocolEvent, error) {
+	if len(l.Topics) == 0 {
+		return nil, fmt.Errorf("log without topics at block %d", l.BlockNumber)
+	}
+	spec, ok := d[l.Address][l.Topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %s from %s", l.Topics[0].Hex(), l.Address.Hex())
+	}
+	data, err := spec.parse(l)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s.%s: %w", spec.contract, spec.name, err)
+	}
+	return &ProtocolEvent{Contract: spec.contract, Name: spec.name, Log: l, Data: data}, nil
+}
+
+func (d eventDecoder) filter() ([]common.Address, []common.Hash) {
+	var addrs []common.Address
+	seen := make(map[common.Hash]bool)
+	var topics []common.Hash
+	for addr, specs := range d {
+		addrs = append(addrs, addr)
+		for id := range specs {
+			if !seen[id] {
+				seen[id] = true
+				topics = append(topics, id)
+			}
+		}
+	}
+	return addrs, topics
+}
+
+// newEventDecoder binds the protocol contracts that resolve, skipping the others.
+func (tw *TaskWorker) newEventDecoder() (eventDecoder, error) {
+	d := make(eventDecoder)
+	if addr, err := tw.resolveContract(ContractLVRAuctionHook); err == nil {
+		hook, err := lvrauctionhook.NewLVRAuctionHookFilterer(addr, nil)
+		if err != nil {
+			return nil, err
+		}
+		for name, parse := range map[string]func(types.Log) (interface{}, error){
+			EventSwapObserved:      func(l types.Log) (interface{}, error) { return hook.ParseSwapObserved(l) },
+			EventAuctionAuthorized: func(l types.Log) (interface{}, error) { return hook.ParseAuctionAuthorized(l) },
+			EventAuctionRevoked:    func(l types.Log) (interface{}, error) { return hook.ParseAuctionRevoked(l) },
+		} {
+			if err := d.add(ContractLVRAuctionHook, addr, lvrauctionhook.LVRAuctionHookMetaData, name, parse); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		tw.logger.Warn("Not following LVRAuctionHook events", zap.Error(err))
+	}
+	if addr, err := tw.resolveContract(ContractAuctionService); err == nil {
+		svc, err := auctionservice.NewAuctionServiceFilterer(addr, nil)
+		if err != nil {
+			return nil, err
+		}
+		for name, parse := range map[string]func(types.Log) (interface{}, error){
+			EventAuctionCreated:      func(l types.Log) (interface{}, error) { return svc.ParseAuctionCreated(l) },
+			EventSettlementSubmitted: func(l types.Log) (interface{}, error) { return svc.ParseSettlementSubmitted(l) },
+		} {
+			if err := d.add(ContractAuctionService, addr, auctionservice.AuctionServiceMetaData, name, parse); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		tw.logger.Warn("Not following AuctionService events", zap.Error(err))
+	}
+	if addr, err := tw.resolveContract(ContractSettlementVault); err == nil {
+		vault, err := settlementvault.NewSettlementVaultFilterer(addr, nil)
+		if err != nil {
+			return nil, err
+		}
+		parse := func(l types.Log) (interface{}, error) { return vault.ParseProceedsRecorded(l) }
+		if err := d.add(ContractSettlementVault, addr, settlementvault.SettlementVaultMetaData, EventProceedsRecorded, parse); err != nil {
+			return nil, err
+		}
+	} else {
+		tw.logger.Warn("Not following SettlementVault events", zap.Error(err))
+	}
+	if len(d) == 0 {
+		return nil, fmt.Errorf("no protocol contracts configured")
+	}
+	return d, nil
+}
+
+// eventDispatcher is the listener's events.Handler: it decodes protocol logs and passes
+// them to the worker's event subscribers.
+type eventDispatcher struct {
+	tw      *TaskWorker
+	decoder eventDecoder
+}
+
+func (d *eventDispatcher) HandleLogs(ctx context.Context, logs []types.Log) error {
+	for _, l := range logs {
+		ev, err := d.decoder.decode(l)
+		if err != nil {
+			return err
+		}
+		d.tw.logger.Debug("Protocol event",
+			zap.String("contract", ev.Contract),
+			zap.String("event", ev.Name),
+			zap.Uint64("block", l.BlockNumber),
+			zap.String("tx", l.TxHash.Hex()),
+		)
+		d.tw.metrics.protocolEvents.WithLabelValues(ev.Contract, ev.Name).Inc()
+		for _, s := range d.tw.eventSubscribers {
+			if err := s.HandleEvent(ctx, ev); err != nil {
+				return fmt.Errorf("%s.%s at block %d: %w", ev.Contract, ev.Name, l.BlockNumber, err)
+			}
+		}
+	}
+	if len(logs) > 0 {
+		d.tw.metrics.eventBlock.Set(float64(logs[len(logs)-1].BlockNumber))
+	}
+	return nil
+}
+
+func (d *eventDispatcher) Rollback(ctx context.Context, block uint64) error {
+	d.tw.logger.Warn("Chain reorg, rolling back protocol events", zap.Uint64("to_block", block))
+	d.tw.metrics.eventReorgs.Inc()
+	for _, s := range d.tw.eventSubscribers {
+		if err := s.RollbackEvents(ctx, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newEventListener builds the L1 protocol event listener, with its checkpoint in the
+// state database.
+func (tw *TaskWorker) newEventListener() (*events.Listener, error) {
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	decoder, err := tw.newEventDecoder()
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.requireState(); err != nil {
+		return nil, err
+	}
+	store, err := events.NewBoltCheckpoints(tw.stateDB)
+	if err != nil {
+		return nil, err
+	}
+	addrs, topics := decoder.filter()
+	cfg := tw.config.Events
+	return events.New(tw.l1Client, store, &eventDispatcher{tw: tw, decoder: decoder}, events.Config{
+		Name:         eventListenerName,
+		Addresses:    addrs,
+		Topics:       topics,
+		StartBlock:   cfg.StartBlock,
+		ChunkSize:    cfg.ChunkSize,
+		PollInterval: cfg.PollInterval,
+		ReorgDepth:   cfg.ReorgDepth,
+		OnError: func(err error) {
+			tw.logger.Warn("Event listener", zap.Error(err))
+		},
+	})
+}
+
+// runEventListener follows protocol events until ctx is done, when enabled.
+func (tw *TaskWorker) runEventListener(ctx context.Context) {
+	if !tw.config.Events.Enabled {
+		return
+	}
+	l, err := tw.newEventListener()
+	if err != nil {
+		tw.logger.Error("Event listener not started", zap.Error(err))
+		return
+	}
+	tw.logger.Info("Following protocol events", zap.Strings("events", protocolEventNames))
+	if err := l.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		tw.logger.Error("Event listener stopped", zap.Error(err))
+	}
+}
+
+var protocolEventNames = []string{
+	EventSwapObserved, EventAuctionAuthorized, EventAuctionRevoked,
+	EventAuctionCreated, EventSettlementSubmitted, EventProceedsRecorded,
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/settlementvault"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+var (
+	testHookAddress    = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	testServiceAddress = common.HexToAddress("0x00000000000000000000000000000000000000c2")
+	testVault          = common.HexToAddress(testVaultAddress)
+)
+
+// eventLog builds the log a contract emits for event, from its indexed topics and
+// non-indexed values.
+func eventLog(t *testing.T, meta *bind.MetaData, addr common.Address, event string, block uint64, topics []common.Hash, values ...interface{}) types.Log {
+	t.Helper()
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := parsed.Events[event]
+	data, err := ev.Inputs.NonIndexed().Pack(values...)
+	if err != nil {
+		t.Fatalf("pack %s: %v", event, err)
+	}
+	return types.Log{Address: addr, Topics: append([]common.Hash{ev.ID}, topics...), Data: data, BlockNumber: block}
+}
+
+// recordingSubscriber keeps the events it was given and rolls them back.
+type recordingSubscriber struct {
+	events    []*ProtocolEvent
+	rollbacks []uint64
+}
+
+func (s *recordingSubscriber) HandleEvent(ctx context.Context, ev *ProtocolEvent) error {
+	s.events = append(s.events, ev)
+	return nil
+}
+
+func (s *recordingSubscriber) RollbackEvents(ctx context.Context, block uint64) error {
+	s.rollbacks = append(s.rollbacks, block)
+	return nil
+}
+
+func newEventTestWorker(t *testing.T) *TaskWorker {
+	cfg := defaultConfig()
+	cfg.Contracts[ContractLVRAuctionHook] = testHookAddress.Hex()
+	cfg.Contracts[ContractAuctionService] = testServiceAddress.Hex()
+	cfg.Contracts[ContractSettlementVault] = testVaultAddress
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	t.Cleanup(func() { tw.Close() })
+	return tw
+}
+
+func Test_EventDispatch(t *testing.T) {
+	tw := newEventTestWorker(t)
+	sub := &recordingSubscriber{}
+	tw.SubscribeEvents(sub)
+	decoder, err := tw.newEventDecoder()
+	if err != nil {
+		t.Fatalf("newEventDecoder: %v", err)
+	}
+	addrs, topics := decoder.filter()
+	if len(addrs) != 3 || len(topics) != len(protocolEventNames) {
+		t.Fatalf("filter = %d addresses, %d topics", len(addrs), len(topics))
+	}
+
+	pool := common.HexToHash(testBytes32A)
+	oracleUpdate := common.HexToHash(testBytes32B)
+	logs := []types.Log{
+		eventLog(t, lvrauctionhook.LVRAuctionHookMetaData, testHookAddress, EventSwapObserved, 10,
+			[]common.Hash{pool}, big.NewInt(-5), [32]byte(oracleUpdate)),
+		eventLog(t, lvrauctionhook.LVRAuctionHookMetaData, testHookAddress, EventAuctionAuthorized, 10,
+			[]common.Hash{pool, common.BytesToHash(testVault.Bytes())}, uint64(99), [32]byte(oracleUpdate)),
+		eventLog(t, auctionservice.AuctionServiceMetaData, testServiceAddress, EventAuctionCreated, 11,
+			[]common.Hash{common.BigToHash(big.NewInt(7)), oracleUpdate}, uint64(100), uint64(200)),
+		eventLog(t, settlementvault.SettlementVaultMetaData, testVault, EventProceedsRecorded, 12,
+			nil, big.NewInt(1000), big.NewInt(700), big.NewInt(300)),
+	}
+	d := &eventDispatcher{tw: tw, decoder: decoder}
+	if err := d.HandleLogs(context.Background(), logs); err != nil {
+		t.Fatalf("HandleLogs: %v", err)
+	}
+
+	if len(sub.events) != len(logs) {
+		t.Fatalf("got %d events, want %d", len(sub.events), len(logs))
+	}
+	if ev, ok := sub.events[0].Data.(*lvrauctionhook.LVRAuctionHookSwapObserved); !ok || ev.Delta.Int64() != -5 || ev.PoolId != pool {
+		t.Errorf("SwapObserved = %+v", sub.events[0].Data)
+	}
+	if ev, ok := sub.events[1].Data.(*lvrauctionhook.LVRAuctionHookAuctionAuthorized); !ok || ev.Winner != testVault || ev.Expiry != 99 {
+		t.Errorf("AuctionAuthorized = %+v", sub.events[1].Data)
+	}
+	if ev, ok := sub.events[2].Data.(*auctionservice.AuctionServiceAuctionCreated); !ok || ev.Id.Int64() != 7 || ev.EndTime != 200 {
+		t.Errorf("AuctionCreated = %+v", sub.events[2].Data)
+	}
+	if ev, ok := sub.events[3].Data.(*settlementvault.SettlementVaultProceedsRecorded); !ok || ev.InsuranceAmount.Int64() != 300 {
+		t.Errorf("ProceedsRecorded = %+v", sub.events[3].Data)
+	}
+	if got := testutil.ToFloat64(tw.metrics.protocolEvents.WithLabelValues(ContractLVRAuctionHook, EventSwapObserved)); got != 1 {
+		t.Errorf("SwapObserved count = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(tw.metrics.eventBlock); got != 12 {
+		t.Errorf("event block = %v, want 12", got)
+	}
+
+	if err := d.Rollback(context.Background(), 10); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(sub.rollbacks) != 1 || sub.rollbacks[0] != 10 || testutil.ToFloat64(tw.metrics.eventReorgs) != 1 {
+		t.Errorf("rollbacks = %v, reorgs = %v", sub.rollbacks, testutil.ToFloat64(tw.metrics.eventReorgs))
+	}
+
+	unknown := logs[3]
+	unknown.Address = testHookAddress
+	if err := d.HandleLogs(context.Background(), []types.Log{unknown}); err == nil {
+		t.Error("HandleLogs accepted an event the contract does not emit")
+	}
+}
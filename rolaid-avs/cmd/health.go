@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/rpcpool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// healthCheckTimeout bounds each dependency probe made by the readiness endpoint.
+const healthCheckTimeout = 3 * time.Second
+
+// rpcProbeInterval is how often RPC connectivity metrics are refreshed between readiness probes.
+const rpcProbeInterval = 15 * time.Second
+
+// requiredContracts must resolve to deployed code on L1 for the performer to be ready.
+var requiredContracts = []string{ContractAuctionService, ContractSettlementVault}
+
+// chainReader is the subset of an RPC client probed by the readiness checks.
+type chainReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+type componentStatus struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type chainStatus struct {
+	componentStatus
+	Configured     bool    `json:"configured"`
+	ChainID        uint64  `json:"chain_id,omitempty"`
+	HeadBlock      uint64  `json:"head_block,omitempty"`
+	HeadAgeSeconds float64 `json:"head_age_seconds,omitempty"`
+	// Endpoints reports each endpoint of the chain's RPC pool.
+	Endpoints []rpcpool.EndpointStatus `json:"endpoints,omitempty"`
+}
+
+type contractStatus struct {
+	componentStatus
+	Address string `json:"address,omitempty"`
+	HasCode bool   `json:"has_code"`
+}
+
+// readinessResponse is served on /readyz. The performer is ready when it is not draining,
+// L1 is reachable with a fresh head, a configured L2 is reachable, and the required
+// contracts have code on L1. The contract store is reported but does not gate readiness:
+// outside devkit the addresses come from config instead.
+type readinessResponse struct {
+	Ready         bool                      `json:"ready"`
+	Draining      bool                      `json:"draining"`
+	ContractStore componentStatus           `json:"contract_store"`
+	L1            chainStatus               `json:"l1"`
+	L2            chainStatus               `json:"l2"`
+	Contracts     map[string]contractStatus `json:"contracts"`
+}
+
+// readiness probes the worker's dependencies.
+func (tw *TaskWorker) readiness(ctx context.Context) readinessResponse {
+	resp := readinessResponse{
+		Draining:  tw.tasks.draining(),
+		Contracts: make(map[string]contractStatus, len(requiredContracts)),
+	}
+
+	resp.ContractStore.OK = tw.contractStore != nil && tw.contractStoreErr == nil
+	if tw.contractStoreErr != nil {
+		resp.ContractStore.Error = tw.contractStoreErr.Error()
+	} else if tw.contractStore == nil {
+		resp.ContractStore.Error = "not loaded"
+	}
+
+	var l1, l2 chainReader
+	if tw.l1Client != nil {
+		l1 = tw.l1Client
+	}
+	if tw.l2Client != nil {
+		l2 = tw.l2Client
+	}
+	resp.L1 = tw.chainStatus(ctx, len(tw.config.l1Urls()) > 0, l1, tw.l1DialErr)
+	resp.L2 = tw.chainStatus(ctx, len(tw.config.l2Urls()) > 0, l2, tw.l2DialErr)
+	if tw.l1Client != nil {
+		resp.L1.Endpoints = tw.l1Client.Status()
+	}
+	if tw.l2Client != nil {
+		resp.L2.Endpoints = tw.l2Client.Status()
+	}
+	tw.metrics.observeChain("l1", resp.L1)
+	tw.metrics.observeChain("l2", resp.L2)
+	if !resp.L1.Configured {
+		resp.L1.Error = "not configured (env L1_RPC_URL)"
+	}
+
+	ready := !resp.Draining && resp.L1.OK && (resp.L2.OK || !resp.L2.Configured)
+	for _, name := range requiredContracts {
+		cs := tw.contractStatus(ctx, name, l1, resp.L1.OK)
+		resp.Contracts[name] = cs
+		ready = ready && cs.OK
+	}
+	resp.Ready = ready
+	return resp
+}
+
+// monitorRPC refreshes the RPC connectivity metrics until ctx is done.
+func (tw *TaskWorker) monitorRPC(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		tw.readiness(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (tw *TaskWorker) chainStatus(ctx context.Context, configured bool, client chainReader, dialErr error) chainStatus {
+	st := chainStatus{Configured: configured}
+	if !configured {
+		return st
+	}
+	if dialErr != nil || client == nil {
+		st.Error = fmt.Sprintf("dial failed: %v", dialErr)
+		return st
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		st.Error = fmt.Sprintf("chain id: %v", err)
+		return st
+	}
+	st.ChainID = chainID.Uint64()
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		st.Error = fmt.Sprintf("head block: %v", err)
+		return st
+	}
+	st.HeadBlock = head.Number.Uint64()
+	age := time.Since(time.Unix(int64(head.Time), 0))
+	st.HeadAgeSeconds = age.Seconds()
+	if max := tw.config.MaxHeadAge; max > 0 && age > max {
+		st.Error = fmt.Sprintf("head block is %s old (max %s)", age.Truncate(time.Second), max)
+		return st
+	}
+	st.OK = true
+	return st
+}
+
+func (tw *TaskWorker) contractStatus(ctx context.Context, name string, l1 chainReader, l1OK bool) contractStatus {
+	var st contractStatus
+	addr, err := tw.resolveContract(name)
+	if err != nil {
+		st.Error = err.Error()
+		return st
+	}
+	st.Address = addr.Hex()
+	if !l1OK {
+		st.Error = "L1 unavailable"
+		return st
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	code, err := l1.CodeAt(ctx, addr, nil)
+	if err != nil {
+		st.Error = fmt.Sprintf("get code: %v", err)
+		return st
+	}
+	st.HasCode = len(code) > 0
+	if !st.HasCode {
+		st.Error = "no contract code at address"
+		return st
+	}
+	st.OK = true
+	return st
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+const testVaultAddress = "0x00000000000000000000000000000000000000b2"
+
+// fakeRPC serves the JSON-RPC methods probed by the readiness checks.
+func fakeRPC(t *testing.T, headTime time.Time, code map[common.Address][]byte) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode rpc request: %v", err)
+			return
+		}
+		var result interface{}
+		switch req.Method {
+		case "eth_chainId":
+			result = hexutil.Uint64(11155111)
+		case "eth_getBlockByNumber":
+			result = &types.Header{
+				Number:     big.NewInt(1234),
+				Time:       uint64(headTime.Unix()),
+				Difficulty: big.NewInt(0),
+			}
+		case "eth_getCode":
+			var addr common.Address
+			if err := json.Unmarshal(req.Params[0], &addr); err != nil {
+				t.Errorf("decode address: %v", err)
+			}
+			result = hexutil.Bytes(code[addr])
+		default:
+			t.Errorf("unexpected rpc method %s", req.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func readyz(t *testing.T, tw *TaskWorker) (int, readinessResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	newStatusServer("", tw).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var got readinessResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode readiness: %v", err)
+	}
+	return rec.Code, got
+}
+
+func Test_Readiness(t *testing.T) {
+	deployed := map[common.Address][]byte{
+		common.HexToAddress(testAddress):      {0x60, 0x80},
+		common.HexToAddress(testVaultAddress): {0x60, 0x80},
+	}
+	newWorker := func(rpcURL string) *TaskWorker {
+		cfg := defaultConfig()
+		cfg.L1RpcUrl = rpcURL
+		cfg.Contracts[ContractAuctionService] = testAddress
+		cfg.Contracts[ContractSettlementVault] = testVaultAddress
+		return NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		rpc := fakeRPC(t, time.Now(), deployed)
+		defer rpc.Close()
+		tw := newWorker(rpc.URL)
+		defer tw.Close()
+
+		code, got := readyz(t, tw)
+		if code != http.StatusOK || !got.Ready {
+			t.Fatalf("code = %d, readiness = %+v", code, got)
+		}
+		if got.L1.ChainID != 11155111 || got.L1.HeadBlock != 1234 {
+			t.Fatalf("unexpected L1 status %+v", got.L1)
+		}
+		if got.L2.Configured {
+			t.Fatalf("L2 reported as configured: %+v", got.L2)
+		}
+		if !got.Contracts[ContractSettlementVault].HasCode {
+			t.Fatalf("vault status %+v", got.Contracts[ContractSettlementVault])
+		}
+	})
+
+	t.Run("stale head", func(t *testing.T) {
+		rpc := fakeRPC(t, time.Now().Add(-time.Hour), deployed)
+		defer rpc.Close()
+		tw := newWorker(rpc.URL)
+		defer tw.Close()
+
+		code, got := readyz(t, tw)
+		if code != http.StatusServiceUnavailable || got.L1.OK || !strings.Contains(got.L1.Error, "old") {
+			t.Fatalf("code = %d, L1 = %+v", code, got.L1)
+		}
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		rpc := fakeRPC(t, time.Now(), map[common.Address][]byte{common.HexToAddress(testAddress): {0x60}})
+		defer rpc.Close()
+		tw := newWorker(rpc.URL)
+		defer tw.Close()
+
+		code, got := readyz(t, tw)
+		vault := got.Contracts[ContractSettlementVault]
+		if code != http.StatusServiceUnavailable || vault.HasCode || vault.OK {
+			t.Fatalf("code = %d, vault = %+v", code, vault)
+		}
+		if !got.Contracts[ContractAuctionService].OK {
+			t.Fatalf("auction service = %+v", got.Contracts[ContractAuctionService])
+		}
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		rpc := fakeRPC(t, time.Now(), deployed)
+		defer rpc.Close()
+		tw := newWorker("http://127.0.0.1:1/key")
+		tw.config.RPC.L1Urls = []string{rpc.URL}
+		tw.l1Client, _ = dialPool(zap.NewNop(), tw.config.l1Urls(), "l1", 1, tw.config.RPC)
+		defer tw.Close()
+
+		code, got := readyz(t, tw)
+		if code != http.StatusOK || len(got.L1.Endpoints) != 2 {
+			t.Fatalf("code = %d, L1 = %+v", code, got.L1)
+		}
+		if dead := got.L1.Endpoints[0]; dead.Name != "http://127.0.0.1:1" || dead.Errors == 0 {
+			t.Fatalf("dead endpoint = %+v", dead)
+		}
+	})
+
+	t.Run("no L1", func(t *testing.T) {
+		tw := newWorker("")
+		defer tw.Close()
+
+		code, got := readyz(t, tw)
+		if code != http.StatusServiceUnavailable || got.L1.Configured {
+			t.Fatalf("code = %d, L1 = %+v", code, got.L1)
+		}
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		rpc := fakeRPC(t, time.Now(), deployed)
+		defer rpc.Close()
+		tw := newWorker(rpc.URL)
+		defer tw.Close()
+		if err := tw.Drain(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		code, got := readyz(t, tw)
+		if code != http.StatusServiceUnavailable || !got.Draining {
+			t.Fatalf("code = %d, readiness = %+v", code, got)
+		}
+
+		rec := httptest.NewRecorder()
+		newStatusServer("", tw).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("liveness code = %d while draining", rec.Code)
+		}
+	})
+}
+
+func Test_DialPoolQuorum(t *testing.T) {
+	// An endpoint that fails to dial must not lower the quorum to the ones that did.
+	urls := []string{"http://127.0.0.1:1", "unix:///nonexistent/rpc.sock"}
+	if _, err := dialPool(zap.NewNop(), urls, "l1", 2, RPCConfig{}); err == nil {
+		t.Fatal("dialPool built a pool with fewer endpoints than the quorum")
+	}
+	p, err := dialPool(zap.NewNop(), urls, "l1", 1, RPCConfig{})
+	if err != nil {
+		t.Fatalf("dialPool: %v", err)
+	}
+	defer p.Close()
+	if p.Len() != 1 {
+		t.Fatalf("pool has %d endpoints, want 1", p.Len())
+	}
+}
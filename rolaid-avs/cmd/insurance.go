@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/actuarial"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/merkle"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// insurancePayoutHandler allocates an insurance pot across a policy batch and commits to
+// the payout vector.
+type insurancePayoutHandler struct {
+	tw *TaskWorker
+}
+
+func newInsurancePayoutHandler(tw *TaskWorker) *insurancePayoutHandler {
+	return &insurancePayoutHandler{tw: tw}
+}
+
+func (h *insurancePayoutHandler) Kind() string       { return KindInsurancePayout }
+func (h *insurancePayoutHandler) PayloadKey() string { return "insurance" }
+
+func (h *insurancePayoutHandler) SpanAttributes(payload interface{}) []attribute.KeyValue {
+	ins, err := h.task(payload)
+	if err != nil {
+		return nil
+	}
+	return []attribute.KeyValue{attrPolicyBatchId.String(ins.PolicyBatchId)}
+}
+
+func (h *insurancePayoutHandler) Decode(version uint32, raw json.RawMessage) (interface{}, error) {
+	switch version {
+	case EnvelopeVersionV1:
+		var ins InsuranceTask
+		if err := decodeStrict(raw, &ins); err != nil {
+			return nil, fieldErrorf(h.PayloadKey(), "%v", err)
+		}
+		if err := validateInsuranceV1(&ins); err != nil {
+			return nil, err
+		}
+		return &ins, nil
+	default:
+		return nil, unsupportedVersion(h.Kind(), version)
+	}
+}
+
+func (h *insurancePayoutHandler) Validate(ctx context.Context, payload interface{}) error {
+	ins, err := h.task(payload)
+	if err != nil {
+		return err
+	}
+	if ins.Model != "" && h.tw.eigenAI == nil {
+		return fieldErrorf("insurance.model", "task needs EigenAI model %q but no EigenAI client is configured", ins.Model)
+	}
+	return h.tw.checkTaskContract(ContractSettlementVault, "insurance.settlement_vault", common.HexToAddress(ins.SettlementVault))
+}
+
+func (h *insurancePayoutHandler) Handle(ctx context.Context, payload interface{}) ([]byte, error) {
+	ins, err := h.task(payload)
+	if err != nil {
+		return nil, err
+	}
+	h.tw.logger.Sugar().Infow("Insurance payout task",
+		"batch", ins.PolicyBatchId,
+		"events", ins.Events,
+		"seed", ins.Seed,
+		"model", ins.Model,
+	)
+
+	amountWei, err := parseWeiField("insurance.amount_wei", ins.AmountWei)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts, model, err := h.allocate(ctx, ins, amountWei)
+	if err != nil {
+		return nil, err
+	}
+	beneficiaries, amounts := payout.Split(payouts)
+	vectorCommitment, err := commitment.PayoutVector(beneficiaries, amounts)
+	if err != nil {
+		return nil, err
+	}
+	distributionRoot, err := merkle.Root(payoutLeaves(payouts))
+	if err != nil {
+		return nil, err
+	}
+	h.tw.logger.Sugar().Infow("Insurance payout allocated",
+		"batch", ins.PolicyBatchId,
+		"beneficiaries", len(payouts),
+		"total_wei", payout.Total(payouts).String(),
+		"pot_wei", amountWei.String(),
+		"model", model.version,
+	)
+
+	payoutCommitment := commitment.InsurancePayout(ins.PolicyBatchId, ins.Events, ins.Seed, amountWei.Big())
+	return results.EncodeInsurancePayout(&results.InsurancePayoutResult{
+		PolicyBatchId:    ins.PolicyBatchId,
+		PayoutCommitment: payoutCommitment,
+		Seed:             ins.Seed,
+		SettlementVault:  common.HexToAddress(ins.SettlementVault),
+		VectorCommitment: vectorCommitment,
+		Beneficiaries:    beneficiaries,
+		Amounts:          amounts,
+		DistributionRoot: distributionRoot,
+		ModelSource:      model.source,
+		ModelVersion:     model.version,
+	})
+}
+
+// claimModel is the model that decided an insurance task's claims, recorded in its result.
+type claimModel struct {
+	source  uint8 // results.ModelRules or results.ModelEigenAI
+	version string
+}
+
+var rulesModel = claimModel{source: results.ModelRules, version: actuarial.Version}
+
+// allocate splits the pot across the batch's policies. The task's EigenAI model decides
+// each claim when it names one, and the actuarial model does otherwise.
+func (h *insurancePayoutHandler) allocate(ctx context.Context, ins *InsuranceTask, pot wei.Amount) ([]payout.Payout, claimModel, error) {
+	policies := make([]payout.Policy, len(ins.Policies))
+	for i, p := range ins.Policies {
+		coverage, err := parseWeiField(fmt.Sprintf("insurance.policies[%d].coverage_wei", i), p.CoverageWei)
+		if err != nil {
+			return nil, claimModel{}, err
+		}
+		policies[i] = payout.Policy{
+			Id:          p.PolicyId,
+			Beneficiary: common.HexToAddress(p.Beneficiary),
+			Coverage:    coverage.Big(),
+			Events:      p.Events,
+		}
+	}
+	if ins.Model != "" {
+		// Every operator must decide with the same model, so an EigenAI failure fails
+		// the task rather than signing a result the quorum cannot match.
+		claims, err := h.modelClaims(ctx, ins, policies, pot)
+		if err != nil {
+			return nil, claimModel{}, err
+		}
+		payouts, err := payout.Allocate(pot.Big(), claims)
+		return payouts, claimModel{source: results.ModelEigenAI, version: ins.Model}, err
+	}
+
+	moves := make([]actuarial.PriceMove, len(ins.PriceMoves))
+	for i, m := range ins.PriceMoves {
+		moves[i] = actuarial.PriceMove{Event: m.Event, Asset: m.Asset, MoveBps: m.MoveBps}
+	}
+	payouts, err := actuarial.Schedule(pot.Big(), policies, ins.Events, moves)
+	if err != nil {
+		return nil, claimModel{}, fieldErrorf("insurance.policies", "%v", err)
+	}
+	return payouts, rulesModel, nil
+}
+
+func (h *insurancePayoutHandler) task(payload interface{}) (*InsuranceTask, error) {
+	ins, ok := payload.(*InsuranceTask)
+	if !ok || ins == nil {
+		return nil, fmt.Errorf("insurance task missing")
+	}
+	return ins, nil
+}
+
+// payoutLeaves turns a payout vector into Merkle distribution leaves.
+func payoutLeaves(payouts []payout.Payout) []merkle.Leaf {
+	leaves := make([]merkle.Leaf, len(payouts))
+	for i, p := range payouts {
+		leaves[i] = merkle.Leaf{Account: p.Beneficiary, Amount: p.Amount}
+	}
+	return leaves
+}
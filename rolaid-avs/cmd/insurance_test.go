@@ -0,0 +1,105 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/actuarial"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/merkle"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+func Test_InsurancePayoutVector(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    map[common.Address]int64
+	}{
+		{
+			name:    "oversubscribed pot split pro rata",
+			payload: testInsuranceEnvelope,
+			want: map[common.Address]int64{
+				common.HexToAddress(testAddress):     500,
+				common.HexToAddress(testBeneficiary): 500,
+			},
+		},
+		{
+			name:    "uncovered event",
+			payload: strings.Replace(testInsuranceEnvelope, `"events": ["depeg"],`, `"events": ["exploit"],`, 1),
+			want:    map[common.Address]int64{common.HexToAddress(testBeneficiary): 600},
+		},
+		{
+			// An 1050 bps depeg pays half of p-1's and p-2's coverage.
+			name:    "partial loss from price moves",
+			payload: strings.Replace(testInsuranceEnvelope, `"seed": 42,`, `"seed": 42, "price_moves": [{"event": "depeg", "asset": "USDC", "move_bps": -1050}],`, 1),
+			want: map[common.Address]int64{
+				common.HexToAddress(testAddress):     300,
+				common.HexToAddress(testBeneficiary): 300,
+			},
+		},
+		{
+			name:    "no events",
+			payload: strings.Replace(testInsuranceEnvelope, `"events": ["depeg"],`, `"events": [],`, 1),
+			want:    map[common.Address]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tw := NewTaskWorker(zap.NewNop())
+			defer tw.Close()
+			resp, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(tt.payload)})
+			if err != nil {
+				t.Fatalf("HandleTask: %v", err)
+			}
+			res, err := results.DecodeInsurancePayout(resp.GetResult())
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			if res.ModelSource != results.ModelRules || res.ModelVersion != actuarial.Version {
+				t.Errorf("model = %d %q, want the actuarial model", res.ModelSource, res.ModelVersion)
+			}
+			if len(res.Beneficiaries) != len(tt.want) || len(res.Amounts) != len(tt.want) {
+				t.Fatalf("vector = %v %v, want %v", res.Beneficiaries, res.Amounts, tt.want)
+			}
+			total := new(big.Int)
+			for i, b := range res.Beneficiaries {
+				if res.Amounts[i].Int64() != tt.want[b] {
+					t.Errorf("payout to %s = %s, want %d", b.Hex(), res.Amounts[i], tt.want[b])
+				}
+				total.Add(total, res.Amounts[i])
+			}
+			if total.Cmp(big.NewInt(1000)) > 0 {
+				t.Errorf("payouts total %s, over the 1000 wei pot", total)
+			}
+			want, err := commitment.PayoutVector(res.Beneficiaries, res.Amounts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if common.Hash(res.VectorCommitment) != want {
+				t.Errorf("vector commitment = %x, want %s", res.VectorCommitment, want.Hex())
+			}
+			root, err := merkle.Root(payoutLeaves(payoutsOf(res)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if common.Hash(res.DistributionRoot) != root {
+				t.Errorf("distribution root = %x, want %s", res.DistributionRoot, root.Hex())
+			}
+		})
+	}
+}
+
+func payoutsOf(res *results.InsurancePayoutResult) []payout.Payout {
+	payouts := make([]payout.Payout, len(res.Beneficiaries))
+	for i, b := range res.Beneficiaries {
+		payouts[i] = payout.Payout{Beneficiary: b, Amount: res.Amounts[i]}
+	}
+	return payouts
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lifecycle"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// auctionTracker feeds protocol events to the worker's auction lifecycle and checks
+// settlements for finality. Settlements reorged out put their auction back to await
+// settlement; the task creator re-queues it from the same rollback.
+type auctionTracker struct {
+	tw      *TaskWorker
+	tracker *lifecycle.Tracker
+	// gracePeriod reads AuctionService.submissionGracePeriod.
+	gracePeriod func(ctx context.Context) (uint64, error)
+}
+
+func (tw *TaskWorker) newAuctionTracker() (*auctionTracker, error) {
+	if err := tw.requireL1Client(); err != nil {
+		return nil, err
+	}
+	addr, err := tw.resolveContract(ContractAuctionService)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := auctionservice.NewAuctionServiceCaller(addr, tw.l1Client)
+	if err != nil {
+		return nil, err
+	}
+	return &auctionTracker{
+		tw:      tw,
+		tracker: tw.auctions,
+		gracePeriod: func(ctx context.Context) (uint64, error) {
+			return svc.SubmissionGracePeriod(&bind.CallOpts{Context: ctx})
+		},
+	}, nil
+}
+
+// newAuctionLifecycle loads the auction lifecycle from the state database. Without a data
+// dir, which only offline runs have (commands refuse to start, see requireState), it is
+// kept in memory.
+func (tw *TaskWorker) newAuctionLifecycle() *lifecycle.Tracker {
+	cfg := lifecycle.Config{
+		Confirmations: tw.config.Auctions.Confirmations,
+		OnChange:      tw.auctionChanged,
+	}
+	if tw.stateDB != nil {
+		tracker, err := openAuctionLifecycle(tw.stateDB, cfg)
+		if err == nil {
+			for _, a := range tracker.List() {
+				tw.metrics.auctions.WithLabelValues(string(a.Status)).Inc()
+			}
+			return tracker
+		}
+		tw.logger.Error("Failed to load the auction lifecycle store", zap.Error(err))
+		tw.stateErr = errors.Join(tw.stateErr, fmt.Errorf("auction lifecycle: %w", err))
+	}
+	tracker, _ := lifecycle.New(lifecycle.NewMemoryStore(), cfg)
+	return tracker
+}
+
+func openAuctionLifecycle(db *bolt.DB, cfg lifecycle.Config) (*lifecycle.Tracker, error) {
+	store, err := lifecycle.NewBoltStore(db)
+	if err != nil {
+		return nil, err
+	}
+	return lifecycle.New(store, cfg)
+}
+
+// auctionChanged logs an auction's status change and keeps the metrics.
+func (tw *TaskWorker) auctionChanged(prev lifecycle.Status, a lifecycle.Auction) {
+	if prev != "" {
+		tw.metrics.auctions.WithLabelValues(string(prev)).Dec()
+	}
+	if a.Status != "" {
+		tw.metrics.auctions.WithLabelValues(string(a.Status)).Inc()
+	}
+	fields := []zap.Field{
+		zap.Uint64("auction_id", a.Id),
+		zap.String("from", string(prev)),
+		zap.String("to", string(a.Status)),
+	}
+	switch {
+	case a.Status == "":
+		tw.logger.Warn("Auction reorged out", fields...)
+	case (prev == lifecycle.StatusSubmitted || prev == lifecycle.StatusFinalized) && a.Settlement == nil:
+		tw.metrics.settlementRequeues.Inc()
+		tw.logger.Warn("Auction settlement reorged out, auction re-queued", append(fields, zap.Int("requeues", a.Requeues))...)
+	default:
+		tw.logger.Info("Auction status", fields...)
+	}
+}
+
+func (t *auctionTracker) HandleEvent(ctx context.Context, ev *ProtocolEvent) error {
+	switch data := ev.Data.(type) {
+	case *auctionservice.AuctionServiceAuctionCreated:
+		if !data.Id.IsUint64() {
+			return nil
+		}
+		if a, ok := t.tracker.Get(data.Id.Uint64()); ok && a.CreatedBlock != 0 {
+			return nil // redelivered
+		}
+		grace, err := t.gracePeriod(ctx)
+		if err != nil {
+			return fmt.Errorf("read submission grace period: %w", err)
+		}
+		return t.tracker.Created(lifecycle.Auction{
+			Id:             data.Id.Uint64(),
+			OracleUpdateId: data.OracleUpdateId,
+			StartTime:      data.StartTime,
+			EndTime:        data.EndTime,
+			GracePeriod:    grace,
+			CreatedBlock:   ev.Log.BlockNumber,
+		})
+	case *lvrauctionhook.LVRAuctionHookAuctionAuthorized:
+		return t.tracker.Authorized(data.OracleUpdateId, lifecycle.Authorization{
+			PoolId:      data.PoolId,
+			Winner:      data.Winner,
+			Expiry:      data.Expiry,
+			BlockNumber: ev.Log.BlockNumber,
+		})
+	case *auctionservice.AuctionServiceSettlementSubmitted:
+		if !data.Id.IsUint64() {
+			return nil
+		}
+		if _, ok := t.tracker.Get(data.Id.Uint64()); !ok {
+			// Created before the listener's start block.
+			t.tw.logger.Debug("Settlement for an untracked auction", zap.String("auction_id", data.Id.String()))
+			return nil
+		}
+		return t.tracker.Submitted(data.Id.Uint64(), lifecycle.Settlement{
+			Bidder:         data.Winner,
+			BidAmount:      data.BidAmount,
+			SettlementHash: data.SettlementHash,
+			AppId:          data.AppId,
+			ImageDigest:    data.ImageDigest,
+			TxHash:         ev.Log.TxHash,
+			BlockNumber:    ev.Log.BlockNumber,
+			BlockHash:      ev.Log.BlockHash,
+		})
+	}
+	return nil
+}
+
+func (t *auctionTracker) RollbackEvents(ctx context.Context, block uint64) error {
+	return t.tracker.Rollback(block)
+}
+
+// run checks finality and expiry every poll interval until ctx is done.
+func (t *auctionTracker) run(ctx context.Context) {
+	ticker := time.NewTicker(t.tw.config.Auctions.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := t.tracker.Advance(ctx, t.tw.l1Client); err != nil && ctx.Err() == nil {
+			t.tw.logger.Warn("Auction finality check failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// trackAuctions subscribes the auction tracker to protocol events and runs it until ctx
+// is done, when the event listener is enabled. Call it before the listener runs.
+func (tw *TaskWorker) trackAuctions(ctx context.Context) {
+	if !tw.config.Events.Enabled {
+		return
+	}
+	t, err := tw.newAuctionTracker()
+	if err != nil {
+		tw.logger.Error("Auction tracker not started", zap.Error(err))
+		return
+	}
+	tw.SubscribeEvents(t)
+	go t.run(ctx)
+}
+
+// handleAuctions adds the auction lifecycle API to mux:
+//
+//	GET /auctions               auctions by id, filtered by ?pool=, ?status=, and ?from= and
+//	                            ?to= (unix seconds, matching windows that overlap the range)
+//	GET /auctions/{auction_id}  one auction
+func (tw *TaskWorker) handleAuctions(mux *http.ServeMux) {
+	mux.HandleFunc("GET /auctions", func(w http.ResponseWriter, r *http.Request) {
+		f, err := auctionFilter(r.URL.Query())
+		if err != nil {
+			writeJSON(w, tw.logger, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, tw.logger, http.StatusOK, tw.auctions.Query(f))
+	})
+	mux.HandleFunc("GET /auctions/{auction_id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("auction_id"), 10, 64)
+		if err != nil {
+			writeJSON(w, tw.logger, http.StatusBadRequest, errorResponse{Error: "auction_id must be a decimal integer"})
+			return
+		}
+		a, ok := tw.auctions.Get(id)
+		if !ok {
+			writeJSON(w, tw.logger, http.StatusNotFound, errorResponse{Error: "unknown auction"})
+			return
+		}
+		writeJSON(w, tw.logger, http.StatusOK, a)
+	})
+}
+
+func auctionFilter(q url.Values) (lifecycle.Filter, error) {
+	var f lifecycle.Filter
+	if v := q.Get("pool"); v != "" {
+		if err := requireBytes32("pool", v); err != nil {
+			return f, err
+		}
+		f.PoolId = common.HexToHash(v)
+	}
+	if v := q.Get("status"); v != "" {
+		if f.Status = lifecycle.Status(v); !f.Status.Valid() {
+			return f, fmt.Errorf("status: unknown status %q", v)
+		}
+	}
+	for name, dst := range map[string]*uint64{"from": &f.From, "to": &f.To} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return f, fmt.Errorf("%s: must be unix seconds", name)
+			}
+			*dst = n
+		}
+	}
+	if f.To != 0 && f.From > f.To {
+		return f, fmt.Errorf("from is after to")
+	}
+	return f, nil
+}
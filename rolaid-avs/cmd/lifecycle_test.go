@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lifecycle"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func Test_AuctionTracker(t *testing.T) {
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), defaultConfig())
+	defer tw.Close()
+	tracker := tw.auctions
+	at := &auctionTracker{
+		tw:          tw,
+		tracker:     tracker,
+		gracePeriod: func(ctx context.Context) (uint64, error) { return 600, nil },
+	}
+	ctx := context.Background()
+
+	oracleUpdate := common.HexToHash(testBytes32B)
+	winner := common.HexToAddress("0xb2")
+	settled := &ProtocolEvent{
+		Contract: ContractAuctionService,
+		Name:     EventSettlementSubmitted,
+		Log:      types.Log{BlockNumber: 14, TxHash: common.HexToHash("0x01")},
+		Data: &auctionservice.AuctionServiceSettlementSubmitted{
+			Id: big.NewInt(7), Winner: winner, BidAmount: big.NewInt(1000), SettlementHash: common.HexToHash("0x02"),
+		},
+	}
+	for _, ev := range []*ProtocolEvent{
+		auctionCreated(7, oracleUpdate, 10),
+		auctionCreated(7, oracleUpdate, 10), // redelivered
+		hookEvent(EventAuctionAuthorized, &lvrauctionhook.LVRAuctionHookAuctionAuthorized{
+			PoolId: common.HexToHash(testBytes32A), Winner: winner, OracleUpdateId: oracleUpdate,
+		}, 12),
+		settled,
+	} {
+		if err := at.HandleEvent(ctx, ev); err != nil {
+			t.Fatalf("HandleEvent(%s): %v", ev.Name, err)
+		}
+	}
+	a, _ := tracker.Get(7)
+	if a.Status != lifecycle.StatusSubmitted || a.GracePeriod != 600 || a.Deadline() != 800 || a.Settlement.Bidder != winner {
+		t.Fatalf("auction = %+v", a)
+	}
+
+	if err := at.RollbackEvents(ctx, 13); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := tracker.Get(7); a.Status != lifecycle.StatusAuthorized || a.Requeues != 1 {
+		t.Fatalf("auction after reorg = %+v", a)
+	}
+	if got := testutil.ToFloat64(tw.metrics.settlementRequeues); got != 1 {
+		t.Fatalf("requeues = %v", got)
+	}
+	if got := testutil.ToFloat64(tw.metrics.auctions.WithLabelValues(string(lifecycle.StatusAuthorized))); got != 1 {
+		t.Fatalf("authorized gauge = %v", got)
+	}
+	if got := testutil.ToFloat64(tw.metrics.auctions.WithLabelValues(string(lifecycle.StatusSubmitted))); got != 0 {
+		t.Fatalf("submitted gauge = %v", got)
+	}
+
+	if err := at.RollbackEvents(ctx, 9); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tracker.Get(7); ok {
+		t.Fatal("reorged auction still tracked")
+	}
+	if got := testutil.ToFloat64(tw.metrics.auctions.WithLabelValues(string(lifecycle.StatusAuthorized))); got != 0 {
+		t.Fatalf("authorized gauge = %v after the auction was dropped", got)
+	}
+}
+
+func Test_AuctionsAPI(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.DataDir = t.TempDir()
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+	req := &performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testAuctionEnvelope)}
+	if _, err := tw.HandleTask(req); err != nil {
+		t.Fatalf("HandleTask: %v", err)
+	}
+	// The onchain window arrives after the task.
+	if err := tw.auctions.Created(lifecycle.Auction{Id: 7, StartTime: 100, EndTime: 200, GracePeriod: 600, CreatedBlock: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.auctions.Created(lifecycle.Auction{Id: 8, StartTime: 300, EndTime: 400, CreatedBlock: 11}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string) (int, []byte) {
+		rec := httptest.NewRecorder()
+		newStatusServer("", tw).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code, rec.Body.Bytes()
+	}
+	list := func(query string) []uint64 {
+		t.Helper()
+		code, body := get("/auctions" + query)
+		if code != http.StatusOK {
+			t.Fatalf("GET /auctions%s = %d %s", query, code, body)
+		}
+		var got []lifecycle.Auction
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+		ids := []uint64{}
+		for _, a := range got {
+			ids = append(ids, a.Id)
+		}
+		return ids
+	}
+	for query, want := range map[string][]uint64{
+		"":                          {7, 8},
+		"?pool=" + testBytes32A:     {7},
+		"?status=created":           {7, 8},
+		"?status=finalized":         {},
+		"?from=250":                 {8},
+		"?from=150&to=250":          {7},
+		"?to=99":                    {},
+		"?pool=" + testBytes32B:     {},
+		"?status=created&from=1000": {},
+	} {
+		if got := list(query); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("GET /auctions%s = %v, want %v", query, got, want)
+		}
+	}
+
+	code, body := get("/auctions/7")
+	var a lifecycle.Auction
+	if err := json.Unmarshal(body, &a); code != http.StatusOK || err != nil {
+		t.Fatalf("GET /auctions/7 = %d %s", code, body)
+	}
+	if a.Deadline() != 800 || a.Result == nil || a.Result.BidAmount.Int64() != 1000 || len(a.Tasks) != 1 || a.Tasks[0].Op != opHandle {
+		t.Fatalf("auction = %+v", a)
+	}
+
+	for path, want := range map[string]int{
+		"/auctions/9":           http.StatusNotFound,
+		"/auctions/x":           http.StatusBadRequest,
+		"/auctions?status=done": http.StatusBadRequest,
+		"/auctions?pool=0x12":   http.StatusBadRequest,
+		"/auctions?from=2&to=1": http.StatusBadRequest,
+	} {
+		if code, body := get(path); code != want {
+			t.Errorf("GET %s = %d %s, want %d", path, code, body, want)
+		}
+	}
+}
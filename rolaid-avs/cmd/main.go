@@ -2,20 +2,27 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
-	"crypto/sha256"
-	"encoding/hex"
+	"syscall"
 	"time"
 
-	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/helloworldl1"
 	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/taskavsregistrar"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/eigenai"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/lifecycle"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/replay"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/rpcpool"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/contracts"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/server"
 	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
-	"github.com/ethereum/go-ethereum/ethclient"
+	bolt "go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -28,44 +35,176 @@ import (
 
 type TaskWorker struct {
 	logger        *zap.Logger
+	config        *Config
 	contractStore *contracts.ContractStore
-	l1Client      *ethclient.Client
-	l2Client      *ethclient.Client
+	l1Client      *rpcpool.Pool
+	l2Client      *rpcpool.Pool
+	registry      *HandlerRegistry
+	metrics       *performerMetrics
+	stateDB       *bolt.DB
+	replay        replay.Guard
+	// eigenAI answers insurance tasks that name a model; nil when not configured.
+	eigenAI *eigenai.Client
+	// eventSubscribers consume protocol events from the event listener.
+	eventSubscribers []EventSubscriber
+	// auctions records each auction's history, from tasks and, when the event listener
+	// runs, from protocol events through to settlement finality.
+	auctions *lifecycle.Tracker
+
+	// Startup failures, kept for the readiness endpoint.
+	contractStoreErr error
+	l1DialErr        error
+	l2DialErr        error
+	// stateErr is set when the data dir is configured but its state could not be opened;
+	// see requireState.
+	stateErr error
+
+	// tasks admits task calls and tracks in-flight ones for draining on shutdown.
+	tasks taskGate
+	// taskCtx is the parent context of handler calls, each of which gets its own Timeout
+	// deadline. It outlives shutdown signals so in-flight tasks can drain, and is
+	// cancelled once the drain deadline passes.
+	taskCtx     context.Context
+	cancelTasks context.CancelFunc
 }
 
+// NewTaskWorker creates a worker configured from env variables.
 func NewTaskWorker(logger *zap.Logger) *TaskWorker {
-	// Initialize contract store from environment variables
-	contractStore, err := contracts.NewContractStore()
+	cfg, err := configFromEnv()
 	if err != nil {
-		logger.Warn("Failed to load contract store", zap.Error(err))
+		logger.Warn("Invalid performer config in env, using defaults", zap.Error(err))
+		cfg = defaultConfig()
+	}
+	return NewTaskWorkerWithConfig(logger, cfg)
+}
+
+func NewTaskWorkerWithConfig(logger *zap.Logger, cfg *Config) *TaskWorker {
+	// Initialize contract store from environment variables
+	contractStore, contractStoreErr := contracts.NewContractStore()
+	if contractStoreErr != nil {
+		logger.Warn("Failed to load contract store", zap.Error(contractStoreErr))
 	}
 
 	// Initialize Ethereum clients if RPC URLs are provided
-	var l1Client, l2Client *ethclient.Client
+	var l1Client, l2Client *rpcpool.Pool
+	var l1DialErr, l2DialErr error
 
-	if l1RpcUrl := os.Getenv("L1_RPC_URL"); l1RpcUrl != "" {
-		l1Client, err = ethclient.Dial(l1RpcUrl)
-		if err != nil {
-			logger.Error("Failed to connect to L1 RPC", zap.Error(err))
+	if urls := cfg.l1Urls(); len(urls) > 0 {
+		l1Client, l1DialErr = dialPool(logger, urls, "l1", cfg.RPC.Quorum, cfg.RPC)
+		if l1DialErr != nil {
+			logger.Error("Failed to connect to L1 RPC", zap.Error(l1DialErr))
 		}
 	}
 
-	if l2RpcUrl := os.Getenv("L2_RPC_URL"); l2RpcUrl != "" {
-		l2Client, err = ethclient.Dial(l2RpcUrl)
-		if err != nil {
-			logger.Error("Failed to connect to L2 RPC", zap.Error(err))
+	if urls := cfg.l2Urls(); len(urls) > 0 {
+		l2Client, l2DialErr = dialPool(logger, urls, "l2", 1, cfg.RPC)
+		if l2DialErr != nil {
+			logger.Error("Failed to connect to L2 RPC", zap.Error(l2DialErr))
+		}
+	}
+
+	// Local state (replay nonces) lives in the data dir when set
+	var stateDB *bolt.DB
+	var stateErr error
+	if cfg.DataDir != "" {
+		file := cfg.stateFile
+		if file == "" {
+			file = stateDBFile
+		}
+		stateDB, stateErr = openStateDB(cfg.DataDir, file)
+		if stateErr != nil {
+			logger.Error("Failed to open state database", zap.Error(stateErr))
 		}
 	}
+	replayGuard, err := newReplayGuard(stateDB)
+	if err != nil {
+		logger.Error("Failed to initialize persistent replay guard", zap.Error(err))
+		stateErr = errors.Join(stateErr, err)
+	}
 
-	return &TaskWorker{
+	eigenAI, err := cfg.EigenAI.newClient()
+	if err != nil {
+		logger.Error("Failed to configure EigenAI client", zap.Error(err))
+	} else if eigenAI != nil {
+		logger.Info("EigenAI client configured", zap.String("mode", eigenAI.Mode()), zap.String("model", eigenAI.Model()))
+	}
+
+	taskCtx, cancelTasks := context.WithCancel(context.Background())
+	tw := &TaskWorker{
 		logger:        logger,
+		config:        cfg,
 		contractStore: contractStore,
 		l1Client:      l1Client,
 		l2Client:      l2Client,
+		registry:      NewHandlerRegistry(),
+		metrics:       newPerformerMetrics(),
+		stateDB:       stateDB,
+		replay:        replayGuard,
+		eigenAI:       eigenAI,
+		taskCtx:       taskCtx,
+		cancelTasks:   cancelTasks,
+
+		contractStoreErr: contractStoreErr,
+		l1DialErr:        l1DialErr,
+		l2DialErr:        l2DialErr,
+		stateErr:         stateErr,
+	}
+	tw.auctions = tw.newAuctionLifecycle()
+
+	// Built-in task kinds. Additional kinds register via RegisterHandler at startup.
+	for _, h := range []TaskHandler{
+		newAuctionSettlementHandler(tw),
+		newInsurancePayoutHandler(tw),
+	} {
+		if err := tw.RegisterHandler(h); err != nil {
+			logger.Error("Failed to register task handler", zap.String("kind", h.Kind()), zap.Error(err))
+		}
+	}
+	tw.logProtocolContracts()
+	return tw
+}
+
+// Drain stops admitting tasks and waits for in-flight ones until ctx is done. Tasks
+// still running at the deadline have their context cancelled.
+func (tw *TaskWorker) Drain(ctx context.Context) error {
+	err := tw.tasks.drain(ctx)
+	if err != nil {
+		tw.cancelTasks()
+	}
+	return err
+}
+
+// Close releases local state held by the worker.
+func (tw *TaskWorker) Close() error {
+	tw.cancelTasks()
+	if tw.stateDB != nil {
+		return tw.stateDB.Close()
 	}
+	return nil
+}
+
+// RegisterHandler adds a task kind to the worker.
+func (tw *TaskWorker) RegisterHandler(h TaskHandler) error {
+	return tw.registry.Register(h)
 }
 
-func (tw *TaskWorker) ValidateTask(t *performerV1.TaskRequest) error {
+// SupportedKinds lists the task kinds this performer can handle.
+func (tw *TaskWorker) SupportedKinds() []string {
+	return tw.registry.Kinds()
+}
+
+func (tw *TaskWorker) ValidateTask(t *performerV1.TaskRequest) (err error) {
+	defer tw.metrics.observeTask(opValidate, tw.registry.KindLabel(t.GetPayload()), time.Now(), &err)
+	ctx, cancel := context.WithTimeout(tw.taskCtx, tw.config.Timeout)
+	defer cancel()
+	ctx, span := tracer().Start(ctx, "ValidateTask", trace.WithAttributes(taskIdAttr(t.GetTaskId())))
+	defer func() { endSpan(span, err) }()
+
+	if err := tw.tasks.enter(); err != nil {
+		return err
+	}
+	defer tw.tasks.leave()
+
 	tw.logger.Sugar().Infow("Validating task",
 		zap.Any("task", t),
 	)
@@ -74,36 +213,50 @@ func (tw *TaskWorker) ValidateTask(t *performerV1.TaskRequest) error {
 	if len(t.GetTaskId()) == 0 {
 		return fmt.Errorf("missing task id")
 	}
-	if len(t.GetData()) == 0 {
+	if len(t.GetPayload()) == 0 {
 		return fmt.Errorf("missing task payload")
 	}
 
-	if _, err := decodeTaskEnvelope(t.GetData()); err != nil {
+	ctx = withTaskId(ctx, t.GetTaskId())
+	env, h, err := tw.decodeTaskEnvelope(ctx, t.GetPayload())
+	if err != nil {
 		return fmt.Errorf("invalid task payload: %w", err)
 	}
+	hctx, hspan := startHandlerSpan(ctx, h, env, "Validate")
+	err = h.Validate(hctx, env.Payload)
+	endSpan(hspan, err)
+	if err != nil {
+		return fmt.Errorf("invalid %s task: %w", env.Kind, err)
+	}
 
 	return nil
 }
 
-func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskResponse, error) {
+func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (resp *performerV1.TaskResponse, err error) {
+	defer tw.metrics.observeTask(opHandle, tw.registry.KindLabel(t.GetPayload()), time.Now(), &err)
+	ctx, cancel := context.WithTimeout(tw.taskCtx, tw.config.Timeout)
+	defer cancel()
+	ctx, span := tracer().Start(ctx, "HandleTask", trace.WithAttributes(taskIdAttr(t.GetTaskId())))
+	defer func() { endSpan(span, err) }()
+
+	if err := tw.tasks.enter(); err != nil {
+		return nil, err
+	}
+	defer tw.tasks.leave()
+
 	tw.logger.Sugar().Infow("Handling task",
 		zap.Any("task", t),
 	)
 
-	env, err := decodeTaskEnvelope(t.GetData())
+	ctx = withTaskId(ctx, t.GetTaskId())
+	env, h, err := tw.decodeTaskEnvelope(ctx, t.GetPayload())
 	if err != nil {
 		return nil, fmt.Errorf("decode envelope: %w", err)
 	}
 
-	var resultBytes []byte
-	switch env.Kind {
-	case "auction_settlement":
-		resultBytes, err = tw.handleAuctionSettlement(env.Auction)
-	case "insurance_payout":
-		resultBytes, err = tw.handleInsurancePayout(env.Insurance)
-	default:
-		err = fmt.Errorf("unsupported task kind: %s", env.Kind)
-	}
+	hctx, hspan := startHandlerSpan(ctx, h, env, "Handle")
+	resultBytes, err := h.Handle(hctx, env.Payload)
+	endSpan(hspan, err)
 	if err != nil {
 		return nil, err
 	}
@@ -127,11 +280,7 @@ func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskR
 			}
 		}
 
-		// Example 2: Get custom contract addresses
-		// Replace this with your deployed contract addresses (e.g., AuctionService / SettlementVault).
-		_ = helloworldl1.NewHelloWorldL1
-
-		// Example 3: List available contracts
+		// Example 2: List available contracts
 		tw.logger.Info("Available contracts", zap.Strings("contracts", tw.contractStore.ListContracts()))
 	}
 
@@ -141,142 +290,52 @@ func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskR
 	}, nil
 }
 
-// Task envelope schema (JSON over TaskRequest.Data) to route auction vs insurance work.
-type TaskEnvelope struct {
-	Kind      string            `json:"kind"` // "auction_settlement" | "insurance_payout"
-	Auction   *AuctionTask      `json:"auction,omitempty"`
-	Insurance *InsuranceTask    `json:"insurance,omitempty"`
-	Metadata  map[string]string `json:"meta,omitempty"`
-}
-
-type AuctionTask struct {
-	AuctionId       uint64 `json:"auction_id"`
-	PoolId          string `json:"pool_id"`           // bytes32 hex
-	OracleUpdateId  string `json:"oracle_update_id"`  // bytes32 hex
-	SettlementData  string `json:"settlement_data"`   // hex-encoded payload to submit onchain
-	ExpectedBidWei  string `json:"expected_bid_wei"`  // hex or decimal string
-	AppId           string `json:"app_id"`            // EigenCompute appId (hex)
-	ImageDigest     string `json:"image_digest"`      // Docker digest (hex)
-	SubmissionNonce uint64 `json:"submission_nonce"`  // optional replay guard
-	AuctionService  string `json:"auction_service,omitempty"`   // optional override
-	SettlementVault string `json:"settlement_vault,omitempty"`  // optional override
-}
-
-type InsuranceTask struct {
-	PolicyBatchId string   `json:"policy_batch_id"`
-	Events        []string `json:"events"`        // descriptions / ids
-	Seed          uint64   `json:"seed"`          // for deterministic EigenAI call
-	AmountWei     string   `json:"amount_wei"`    // total pot to allocate
-	AppId         string   `json:"app_id"`        // EigenCompute appId (hex)
-	ImageDigest   string   `json:"image_digest"`  // Docker digest (hex)
-	SettlementVault string `json:"settlement_vault,omitempty"`
-}
-
-func decodeTaskEnvelope(data []byte) (*TaskEnvelope, error) {
-	var env TaskEnvelope
-	if err := json.Unmarshal(data, &env); err != nil {
-		return nil, err
-	}
+// decodeTaskEnvelope decodes a task payload through the handler registry under its own span.
+func (tw *TaskWorker) decodeTaskEnvelope(ctx context.Context, data []byte) (env *TaskEnvelope, h TaskHandler, err error) {
+	_, span := tracer().Start(ctx, "decodeTaskEnvelope")
+	defer func() { endSpan(span, err) }()
 
-	// Basic attestation presence checks (real verification happens onchain via registry/AuctionService).
-	if env.Kind == "auction_settlement" && env.Auction != nil {
-		if env.Auction.AppId == "" || env.Auction.ImageDigest == "" {
-			return nil, fmt.Errorf("auction task missing app attestation fields")
-		}
-	}
-	if env.Kind == "insurance_payout" && env.Insurance != nil {
-		if env.Insurance.AppId == "" || env.Insurance.ImageDigest == "" {
-			return nil, fmt.Errorf("insurance task missing app attestation fields")
-		}
+	env, h, err = tw.registry.Decode(data)
+	if err != nil {
+		return nil, nil, err
 	}
-	return &env, nil
+	span.SetAttributes(attrTaskKind.String(env.Kind))
+	return env, h, nil
 }
 
-func (tw *TaskWorker) handleAuctionSettlement(a *AuctionTask) ([]byte, error) {
-	if a == nil {
-		return nil, fmt.Errorf("auction task missing")
-	}
-	tw.logger.Sugar().Infow("Auction settlement task",
-		"auction_id", a.AuctionId,
-		"pool_id", a.PoolId,
-		"oracle_update_id", a.OracleUpdateId,
-	)
-
-	if err := requireHex("pool_id", a.PoolId, 66); err != nil {
-		return nil, err
-	}
-	if err := requireHex("oracle_update_id", a.OracleUpdateId, 66); err != nil {
-		return nil, err
-	}
-	if err := requireHex("app_id", a.AppId, 66); err != nil {
-		return nil, err
-	}
-	if err := requireHex("image_digest", a.ImageDigest, 66); err != nil {
-		return nil, err
-	}
-	if _, err := decodeHexBytes(a.SettlementData); err != nil {
-		return nil, fmt.Errorf("settlement_data invalid hex: %w", err)
-	}
-
-	auctionService := firstNonEmpty(a.AuctionService, os.Getenv("AUCTION_SERVICE_ADDRESS"))
-	if auctionService == "" {
-		return nil, fmt.Errorf("auction_service address missing (env AUCTION_SERVICE_ADDRESS)")
+// startHandlerSpan starts the span for a handler call and tags both it and the enclosing
+// task span with the kind and any identifiers the handler exposes.
+func startHandlerSpan(ctx context.Context, h TaskHandler, env *TaskEnvelope, op string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attrTaskKind.String(env.Kind)}
+	if sa, ok := h.(spanAttributer); ok {
+		attrs = append(attrs, sa.SpanAttributes(env.Payload)...)
 	}
-
-	commitment := hashStrings(a.SettlementData, a.OracleUpdateId, a.PoolId)
-	resp := map[string]interface{}{
-		"kind":            "auction_settlement",
-		"auction_id":      a.AuctionId,
-		"oracle_update_id": a.OracleUpdateId,
-		"pool_id":         a.PoolId,
-		"commitment":      fmt.Sprintf("0x%x", commitment),
-		"auction_service": auctionService,
-	}
-	return json.Marshal(resp)
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+	return tracer().Start(ctx, env.Kind+"."+op, trace.WithAttributes(attrs...))
 }
 
-func (tw *TaskWorker) handleInsurancePayout(ins *InsuranceTask) ([]byte, error) {
-	if ins == nil {
-		return nil, fmt.Errorf("insurance task missing")
-	}
-	tw.logger.Sugar().Infow("Insurance payout task",
-		"batch", ins.PolicyBatchId,
-		"events", ins.Events,
-		"seed", ins.Seed,
-	)
-
-	if err := requireHex("app_id", ins.AppId, 66); err != nil {
-		return nil, err
-	}
-	if err := requireHex("image_digest", ins.ImageDigest, 66); err != nil {
-		return nil, err
-	}
+type taskIdKey struct{}
 
-	settlementVault := firstNonEmpty(ins.SettlementVault, os.Getenv("SETTLEMENT_VAULT_ADDRESS"))
-	if settlementVault == "" {
-		return nil, fmt.Errorf("settlement_vault address missing (env SETTLEMENT_VAULT_ADDRESS)")
-	}
+// withTaskId passes the task id to the handler called with ctx.
+func withTaskId(ctx context.Context, taskId []byte) context.Context {
+	return context.WithValue(ctx, taskIdKey{}, "0x"+hex.EncodeToString(taskId))
+}
 
-	payoutCommitment := hashStrings(strings.Join(ins.Events, ","), fmt.Sprint(ins.Seed), ins.AmountWei)
-	resp := map[string]interface{}{
-		"kind":             "insurance_payout",
-		"policy_batch_id":  ins.PolicyBatchId,
-		"payout_commitment": fmt.Sprintf("0x%x", payoutCommitment),
-		"seed":             ins.Seed,
-		"settlement_vault": settlementVault,
-	}
-	return json.Marshal(resp)
+// taskIdFrom returns the hex id of the task being handled, or "" outside a task call.
+func taskIdFrom(ctx context.Context) string {
+	id, _ := ctx.Value(taskIdKey{}).(string)
+	return id
 }
 
 func requireHex(field string, val string, expectLen int) error {
 	if len(val) == 0 {
-		return fmt.Errorf("%s missing", field)
+		return classFieldErrorf(ErrMissingField, field, "missing")
 	}
 	if !strings.HasPrefix(val, "0x") {
-		return fmt.Errorf("%s must start with 0x", field)
+		return classFieldErrorf(ErrBadHex, field, "must start with 0x")
 	}
 	if expectLen > 0 && len(val) != expectLen {
-		return fmt.Errorf("%s length must be %d chars incl 0x", field, expectLen)
+		return classFieldErrorf(ErrBadHex, field, "length must be %d chars incl 0x", expectLen)
 	}
 	return nil
 }
@@ -288,36 +347,101 @@ func decodeHexBytes(s string) ([]byte, error) {
 	return hex.DecodeString(s)
 }
 
-func hashStrings(parts ...string) []byte {
-	h := sha256.New()
-	for _, p := range parts {
-		h.Write([]byte(p))
+func main() {
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "check-determinism":
+		err = runCheckDeterminism(os.Args[2:], os.Stdin, os.Stdout)
+	case len(os.Args) > 1 && os.Args[1] == "merkle-distribution":
+		err = runMerkleDistribution(os.Args[2:], os.Stdin, os.Stdout)
+	case len(os.Args) > 1 && os.Args[1] == "task-creator":
+		err = runTaskCreator(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "settlement-submitter":
+		err = runSettlementSubmitter(os.Args[2:])
+	default:
+		err = run(os.Args[1:])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	return h.Sum(nil)
 }
 
-func firstNonEmpty(a, b string) string {
-	if a != "" {
-		return a
+func run(args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
 	}
-	return b
-}
+	l, err := cfg.newLogger()
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	defer l.Sync()
 
-func main() {
-	ctx := context.Background()
-	l, _ := zap.NewProduction()
+	shutdownTracing, err := setupTracing(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			l.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
 
-	w := NewTaskWorker(l)
+	// SIGTERM/SIGINT cancel ctx, which stops the performer from accepting tasks.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	w := NewTaskWorkerWithConfig(l, cfg)
+	defer w.Close()
+	if err := w.requireState(); err != nil {
+		return err
+	}
+	l.Info("Supported task kinds", zap.Strings("kinds", w.SupportedKinds()))
+
+	go w.monitorRPC(ctx, rpcProbeInterval)
+	w.trackAuctions(ctx)
+	go w.runEventListener(ctx)
+
+	status := newStatusServer(cfg.StatusAddr, w)
+	go func() {
+		if err := status.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error("Status server stopped", zap.Error(err))
+		}
+	}()
 
 	pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
-		Port:    8080,
-		Timeout: 5 * time.Second,
+		Port:    cfg.Port,
+		Timeout: cfg.Timeout,
 	}, w, l)
 	if err != nil {
-		panic(fmt.Errorf("failed to create performer: %w", err))
+		return fmt.Errorf("failed to create performer: %w", err)
 	}
 
-	if err := pp.Start(ctx); err != nil {
-		panic(err)
+	l.Info("Starting performer", zap.Int("port", cfg.Port), zap.Duration("timeout", cfg.Timeout))
+	startErr := make(chan error, 1)
+	go func() { startErr <- pp.Start(ctx) }()
+
+	select {
+	case err := <-startErr:
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("performer stopped: %w", err)
+		}
+	case <-ctx.Done():
+	}
+	stop()
+
+	l.Info("Shutting down, draining in-flight tasks", zap.Duration("deadline", cfg.ShutdownTimeout))
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := w.Drain(drainCtx); err != nil {
+		l.Warn("Drain deadline exceeded, cancelled remaining tasks", zap.Error(err))
+	}
+	if err := status.Shutdown(drainCtx); err != nil {
+		l.Warn("Status server shutdown", zap.Error(err))
 	}
+	l.Info("Performer stopped")
+	return nil
 }
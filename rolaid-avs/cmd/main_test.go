@@ -1,8 +1,12 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
 	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
 	"go.uber.org/zap"
 )
@@ -21,7 +25,7 @@ func Test_TaskRequestPayload(t *testing.T) {
 
 	taskRequest := &performerV1.TaskRequest{
 		TaskId:  []byte("test-task-id"),
-		Payload: []byte("test-data"),
+		Payload: []byte(testAuctionEnvelope),
 	}
 
 	err = taskWorker.ValidateTask(taskRequest)
@@ -35,4 +39,54 @@ func Test_TaskRequestPayload(t *testing.T) {
 	}
 
 	t.Logf("Response: %v", resp)
+
+	result, err := results.DecodeAuctionSettlement(resp.GetResult())
+	if err != nil {
+		t.Fatalf("Failed to decode auction result: %v", err)
+	}
+	if result.AuctionId.Uint64() != 7 {
+		t.Errorf("auction id = %s, want 7", result.AuctionId)
+	}
+	if result.BidAmount.Int64() != 1000 {
+		t.Errorf("bid amount = %s, want 1000", result.BidAmount)
+	}
+	settlementHash := commitment.SettlementHash([]byte{0xde, 0xad, 0xbe, 0xef})
+	if !commitment.VerifyAuctionSettlement(result.Commitment, result.AuctionId, result.PoolId, result.OracleUpdateId, settlementHash) {
+		t.Errorf("commitment does not match settlement data")
+	}
+}
+
+func Test_StateRequired(t *testing.T) {
+	// No data dir, or one that cannot be created, must stop startup, not fall back to memory.
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), defaultConfig())
+	if err := tw.requireState(); err == nil {
+		t.Fatal("requireState accepted no data dir")
+	}
+	tw.Close()
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultConfig()
+	cfg.DataDir = filepath.Join(file, "data")
+	tw = NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	if err := tw.requireState(); err == nil {
+		t.Fatal("requireState accepted an unopenable data dir")
+	}
+	tw.Close()
+
+	// Each command keeps its own state file, so they can share a data dir.
+	cfg.DataDir = t.TempDir()
+	performer := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer performer.Close()
+	submitterCfg := *cfg
+	submitterCfg.stateFile = settlementStateDBFile
+	submitter := NewTaskWorkerWithConfig(zap.NewNop(), &submitterCfg)
+	defer submitter.Close()
+	for _, tw := range []*TaskWorker{performer, submitter} {
+		if err := tw.requireState(); err != nil {
+			t.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/merkle"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// distributionLeaf is one entry of a merkle-distribution input file.
+type distributionLeaf struct {
+	Account string `json:"account"`
+	Amount  string `json:"amount"` // wei amount, cumulative for the account
+}
+
+// runMerkleDistribution implements `performer merkle-distribution [flags] leaves.json`.
+// It builds the Merkle distribution for a JSON array of {"account", "amount"} leaves, or
+// for the payout vector of a signed insurance result with -insurance-result, and writes
+// the claims file. The root it reports is what MerkleDistributor.setRoot takes and what
+// auction tasks carry as distribution_root.
+func runMerkleDistribution(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("merkle-distribution", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	insuranceResult := fs.Bool("insurance-result", false, "input is a hex-encoded insurance_payout result")
+	out := fs.String("out", "", "write the claims file here and print only the root")
+	treeOut := fs.String("tree", "", "also write the tree in StandardMerkleTree.dump() format")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: performer merkle-distribution [-insurance-result] [-out claims.json] [-tree tree.json] input (- reads stdin)")
+	}
+
+	var input []byte
+	var err error
+	if file := fs.Arg(0); file == "-" {
+		input, err = io.ReadAll(stdin)
+	} else {
+		input, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+	var leaves []merkle.Leaf
+	if *insuranceResult {
+		leaves, err = insuranceResultLeaves(input)
+	} else {
+		leaves, err = parseDistributionLeaves(input)
+	}
+	if err != nil {
+		return err
+	}
+	tree, err := merkle.New(leaves)
+	if err != nil {
+		return err
+	}
+
+	if *treeOut != "" {
+		if err := writeJSONFile(*treeOut, tree.Dump()); err != nil {
+			return err
+		}
+	}
+	if *out != "" {
+		if err := writeJSONFile(*out, tree.Claims()); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(stdout, tree.Root().Hex())
+		return err
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tree.Claims())
+}
+
+// parseDistributionLeaves reads a JSON array of leaves; amounts take any pkg/wei form.
+func parseDistributionLeaves(data []byte) ([]merkle.Leaf, error) {
+	var in []distributionLeaf
+	if err := decodeStrict(data, &in); err != nil {
+		return nil, err
+	}
+	leaves := make([]merkle.Leaf, len(in))
+	for i, l := range in {
+		field := fmt.Sprintf("leaves[%d]", i)
+		if err := requireAddress(field+".account", l.Account); err != nil {
+			return nil, err
+		}
+		amount, err := parseWeiField(field+".amount", l.Amount)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = merkle.Leaf{Account: common.HexToAddress(l.Account), Amount: amount.Big()}
+	}
+	return leaves, nil
+}
+
+// insuranceResultLeaves reads the payout vector of a hex-encoded insurance result and
+// checks its signed distribution root matches the tree built from it.
+func insuranceResultLeaves(data []byte) ([]merkle.Leaf, error) {
+	raw, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("insurance result: %w", err)
+	}
+	res, err := results.DecodeInsurancePayout(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Beneficiaries) != len(res.Amounts) {
+		return nil, fmt.Errorf("insurance result has %d beneficiaries but %d amounts", len(res.Beneficiaries), len(res.Amounts))
+	}
+	leaves := make([]merkle.Leaf, len(res.Beneficiaries))
+	for i, b := range res.Beneficiaries {
+		leaves[i] = merkle.Leaf{Account: b, Amount: res.Amounts[i]}
+	}
+	root, err := merkle.Root(leaves)
+	if err != nil {
+		return nil, err
+	}
+	if root != res.DistributionRoot {
+		return nil, fmt.Errorf("insurance result distribution root %s does not match its payout vector (%s)",
+			common.Hash(res.DistributionRoot).Hex(), root.Hex())
+	}
+	return leaves, nil
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
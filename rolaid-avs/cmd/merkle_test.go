@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/merkle"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+func Test_MerkleDistribution(t *testing.T) {
+	leaves := `[
+		{"account": "0x00000000000000000000000000000000000000a1", "amount": "100"},
+		{"account": "0x00000000000000000000000000000000000000b2", "amount": "200wei"},
+		{"account": "0x00000000000000000000000000000000000000c3", "amount": "0x12c"}
+	]`
+	// Same tree as TestTreeGolden in pkg/merkle.
+	const root = "0x265e8ffdb13c337235907025a451c66d8fbc5eb898d8ce2aab834a67b5c939e0"
+
+	var out bytes.Buffer
+	if err := runMerkleDistribution([]string{"-"}, strings.NewReader(leaves), &out); err != nil {
+		t.Fatalf("runMerkleDistribution: %v", err)
+	}
+	var claims merkle.ClaimsFile
+	if err := json.Unmarshal(out.Bytes(), &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Root.Hex() != root || claims.Total != "600" {
+		t.Fatalf("claims root %s total %s, want %s 600", claims.Root.Hex(), claims.Total, root)
+	}
+	c := claims.Claims[common.HexToAddress("0xb2")]
+	if !merkle.Verify(claims.Root, merkle.Leaf{Account: common.HexToAddress("0xb2"), Amount: big.NewInt(200)}, c.Proof) {
+		t.Fatalf("claim for 0xb2 does not verify: %+v", c)
+	}
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "leaves.json")
+	if err := os.WriteFile(in, []byte(leaves), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	args := []string{"-out", filepath.Join(dir, "claims.json"), "-tree", filepath.Join(dir, "tree.json"), in}
+	if err := runMerkleDistribution(args, nil, &out); err != nil {
+		t.Fatalf("runMerkleDistribution -out: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != root {
+		t.Fatalf("printed %q, want root", got)
+	}
+	for _, f := range []string{"claims.json", "tree.json"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Fatalf("%s not written: %v", f, err)
+		}
+	}
+
+	for name, bad := range map[string]string{
+		"duplicate account": `[{"account": "0x00000000000000000000000000000000000000a1", "amount": "1"}, {"account": "0x00000000000000000000000000000000000000a1", "amount": "2"}]`,
+		"bad amount":        `[{"account": "0x00000000000000000000000000000000000000a1", "amount": "-1"}]`,
+		"empty":             `[]`,
+		"unknown field":     `[{"acount": "0x00000000000000000000000000000000000000a1", "amount": "1"}]`,
+	} {
+		if err := runMerkleDistribution([]string{"-"}, strings.NewReader(bad), &out); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func Test_MerkleDistributionFromInsuranceResult(t *testing.T) {
+	tw := NewTaskWorker(zap.NewNop())
+	defer tw.Close()
+	resp, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: []byte(testInsuranceEnvelope)})
+	if err != nil {
+		t.Fatalf("HandleTask: %v", err)
+	}
+
+	var out bytes.Buffer
+	in := strings.NewReader(hexutil.Encode(resp.GetResult()))
+	if err := runMerkleDistribution([]string{"-insurance-result", "-"}, in, &out); err != nil {
+		t.Fatalf("runMerkleDistribution: %v", err)
+	}
+	var claims merkle.ClaimsFile
+	if err := json.Unmarshal(out.Bytes(), &claims); err != nil {
+		t.Fatal(err)
+	}
+	if len(claims.Claims) != 2 || claims.Total != "1000" {
+		t.Fatalf("claims = %+v, want the 500/500 split", claims)
+	}
+}
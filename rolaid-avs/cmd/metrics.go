@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/preflight"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/replay"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Task operations observed by the metrics.
+const (
+	opValidate = "validate"
+	opHandle   = "handle"
+)
+
+// kindUnknown labels tasks whose kind could not be read or is not registered, so
+// arbitrary payloads cannot blow up label cardinality.
+const kindUnknown = "unknown"
+
+// performerMetrics holds the Prometheus collectors for a worker. Each worker has its own
+// registry so tests can create workers without colliding on global registration.
+type performerMetrics struct {
+	registry *prometheus.Registry
+
+	tasks      *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	errors     *prometheus.CounterVec
+	rpcUp      *prometheus.GaugeVec
+	rpcHead    *prometheus.GaugeVec
+	rpcHeadAge *prometheus.GaugeVec
+
+	protocolEvents *prometheus.CounterVec
+	eventReorgs    prometheus.Counter
+	eventBlock     prometheus.Gauge
+
+	auctions           *prometheus.GaugeVec
+	settlementRequeues prometheus.Counter
+}
+
+func newPerformerMetrics() *performerMetrics {
+	m := &performerMetrics{
+		registry: prometheus.NewRegistry(),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "performer",
+			Name:      "tasks_total",
+			Help:      "Task calls by operation, kind and outcome (ok or error).",
+		}, []string{"op", "kind", "outcome"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "performer",
+			Name:      "task_duration_seconds",
+			Help:      "Task call latency by operation and kind. The executor times out tasks after the server timeout (5s by default).",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 3, 4, 5, 7.5, 10},
+		}, []string{"op", "kind"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "performer",
+			Name:      "task_errors_total",
+			Help:      "Failed task calls by operation, kind and error class.",
+		}, []string{"op", "kind", "class"}),
+		rpcUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "performer",
+			Name:      "rpc_up",
+			Help:      "1 if the chain RPC answered the last probe with a fresh head, else 0.",
+		}, []string{"chain"}),
+		rpcHead: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "performer",
+			Name:      "rpc_head_block",
+			Help:      "Head block number seen on the last RPC probe.",
+		}, []string{"chain"}),
+		rpcHeadAge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "performer",
+			Name:      "rpc_head_age_seconds",
+			Help:      "Age of the head block seen on the last RPC probe.",
+		}, []string{"chain"}),
+		protocolEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "performer",
+			Name:      "protocol_events_total",
+			Help:      "Protocol events delivered by the event listener, by contract and event.",
+		}, []string{"contract", "event"}),
+		eventReorgs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "performer",
+			Name:      "event_reorgs_total",
+			Help:      "Reorgs that rolled back protocol events.",
+		}),
+		eventBlock: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "performer",
+			Name:      "event_block",
+			Help:      "Block of the last protocol event delivered by the event listener.",
+		}),
+		auctions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "performer",
+			Name:      "auctions",
+			Help:      "Tracked auctions by lifecycle status.",
+		}, []string{"status"}),
+		settlementRequeues: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "performer",
+			Name:      "settlement_requeues_total",
+			Help:      "Auction settlements removed by a reorg, putting the auction back to await settlement.",
+		}),
+	}
+	m.registry.MustRegister(
+		m.tasks, m.duration, m.errors, m.rpcUp, m.rpcHead, m.rpcHeadAge,
+		m.protocolEvents, m.eventReorgs, m.eventBlock, m.auctions, m.settlementRequeues,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// handler serves the metrics in the Prometheus exposition format.
+func (m *performerMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// observeTask records the outcome of a task call. It is deferred at the top of
+// ValidateTask and HandleTask with a pointer to their named error result.
+func (m *performerMetrics) observeTask(op, kind string, start time.Time, errp *error) {
+	m.duration.WithLabelValues(op, kind).Observe(time.Since(start).Seconds())
+	if *errp == nil {
+		m.tasks.WithLabelValues(op, kind, "ok").Inc()
+		return
+	}
+	m.tasks.WithLabelValues(op, kind, "error").Inc()
+	m.errors.WithLabelValues(op, kind, errorClass(*errp)).Inc()
+}
+
+// observeChain records the result of an RPC probe.
+func (m *performerMetrics) observeChain(chain string, st chainStatus) {
+	if !st.Configured {
+		return
+	}
+	up := 0.0
+	if st.OK {
+		up = 1
+	}
+	m.rpcUp.WithLabelValues(chain).Set(up)
+	if st.HeadBlock > 0 {
+		m.rpcHead.WithLabelValues(chain).Set(float64(st.HeadBlock))
+		m.rpcHeadAge.WithLabelValues(chain).Set(st.HeadAgeSeconds)
+	}
+}
+
+// errorClass maps a task error onto a small fixed set of metric labels.
+func errorClass(err error) string {
+	var fe *FieldError
+	var missing *missingAddressError
+	switch {
+	case errors.Is(err, errShuttingDown):
+		return "shutting_down"
+	case errors.As(err, &missing):
+		return "missing_" + contractClass(missing.contract) + "_address"
+	case errors.Is(err, ErrMissingField) && errors.As(err, &fe) && isAttestationField(fe.Field):
+		return "missing_attestation_field"
+	case errors.Is(err, ErrMissingField) && errors.As(err, &fe) && strings.HasSuffix(fe.Field, ".settlement_vault"):
+		return "missing_vault_address"
+	case errors.Is(err, ErrMissingField) && errors.As(err, &fe) && strings.HasSuffix(fe.Field, ".auction_service"):
+		return "missing_auction_service_address"
+	case errors.Is(err, ErrMissingField):
+		return "missing_field"
+	case errors.Is(err, ErrBadHex):
+		return "bad_hex"
+	case errors.Is(err, replay.ErrStaleNonce):
+		return "stale_nonce"
+	case errors.Is(err, preflight.ErrUnknownAuction),
+		errors.Is(err, preflight.ErrAuctionSettled),
+		errors.Is(err, preflight.ErrOutsideWindow),
+		errors.Is(err, preflight.ErrOracleMismatch),
+		errors.Is(err, preflight.ErrAttestationFails):
+		return "preflight"
+	case errors.As(err, &fe):
+		return "invalid_field"
+	}
+	return "other"
+}
+
+// isAttestationField reports whether a field is part of the EigenCompute attestation.
+func isAttestationField(field string) bool {
+	return strings.HasSuffix(field, ".app_id") || strings.HasSuffix(field, ".image_digest")
+}
+
+func contractClass(name string) string {
+	switch name {
+	case ContractSettlementVault:
+		return "vault"
+	case ContractAuctionService:
+		return "auction_service"
+	case ContractAttestationRegistry:
+		return "attestation_registry"
+	case ContractLVRAuctionHook:
+		return "hook"
+	}
+	return "contract"
+}
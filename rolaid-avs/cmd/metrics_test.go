@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func Test_TaskMetrics(t *testing.T) {
+	t.Setenv("PERFORMER_DATA_DIR", "")
+	t.Setenv("SETTLEMENT_VAULT_ADDRESS", "")
+	tw := NewTaskWorker(zap.NewNop())
+	defer tw.Close()
+
+	badHex := strings.Replace(testAuctionEnvelope, `"settlement_data": "0xdeadbeef"`, `"settlement_data": "0xzz"`, 1)
+	missingAppId := strings.Replace(testAuctionEnvelope, `"app_id": "`+testBytes32A+`"`, `"app_id": ""`, 1)
+	noVault := strings.Replace(testInsuranceEnvelope, `"settlement_vault": "`+testAddress+`"`, `"settlement_vault": ""`, 1)
+	if badHex == testAuctionEnvelope || missingAppId == testAuctionEnvelope || noVault == testInsuranceEnvelope {
+		t.Fatal("test envelope fixtures changed shape")
+	}
+
+	task := func(payload string) *performerV1.TaskRequest {
+		return &performerV1.TaskRequest{TaskId: []byte("task"), Payload: []byte(payload)}
+	}
+	if err := tw.ValidateTask(task(testAuctionEnvelope)); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := tw.ValidateTask(task(badHex)); err == nil {
+		t.Fatal("expected bad hex rejection")
+	}
+	if err := tw.ValidateTask(task(missingAppId)); err == nil {
+		t.Fatal("expected missing app_id rejection")
+	}
+	if _, err := tw.HandleTask(task(noVault)); err == nil {
+		t.Fatal("expected missing vault error")
+	}
+	if err := tw.ValidateTask(task(`{"kind": "nope"}`)); err == nil {
+		t.Fatal("expected unknown kind rejection")
+	}
+
+	m := tw.metrics
+	for _, c := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"validate ok", testutil.ToFloat64(m.tasks.WithLabelValues(opValidate, KindAuctionSettlement, "ok")), 1},
+		{"validate error", testutil.ToFloat64(m.tasks.WithLabelValues(opValidate, KindAuctionSettlement, "error")), 2},
+		{"bad hex", testutil.ToFloat64(m.errors.WithLabelValues(opValidate, KindAuctionSettlement, "bad_hex")), 1},
+		{"missing attestation", testutil.ToFloat64(m.errors.WithLabelValues(opValidate, KindAuctionSettlement, "missing_attestation_field")), 1},
+		{"missing vault", testutil.ToFloat64(m.errors.WithLabelValues(opHandle, KindInsurancePayout, "missing_vault_address")), 1},
+		{"unknown kind", testutil.ToFloat64(m.tasks.WithLabelValues(opValidate, kindUnknown, "error")), 1},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if n := testutil.CollectAndCount(m.duration); n != 3 {
+		t.Errorf("duration series = %d, want 3", n)
+	}
+
+	rec := httptest.NewRecorder()
+	newStatusServer("", tw).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `performer_task_errors_total{class="bad_hex",kind="auction_settlement",op="validate"} 1`) {
+		t.Fatalf("metrics endpoint code = %d, body missing bad_hex counter", rec.Code)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// TaskHandler implements a single task kind. Handlers own their payload schema:
+// the registry routes an envelope to the handler registered for its kind and
+// hands it the raw JSON found under the handler's payload key.
+type TaskHandler interface {
+	// Kind is the envelope "kind" value routed to this handler.
+	Kind() string
+	// PayloadKey is the envelope field carrying the kind payload (e.g. "auction").
+	PayloadKey() string
+	// Decode strictly decodes and schema-checks the payload for an envelope version.
+	Decode(version uint32, raw json.RawMessage) (interface{}, error)
+	// Validate runs kind-specific checks before an operator signs. Called from ValidateTask.
+	Validate(ctx context.Context, payload interface{}) error
+	// Handle executes the task and returns the result bytes to be signed.
+	Handle(ctx context.Context, payload interface{}) ([]byte, error)
+}
+
+// HandlerRegistry maps task kinds to their handlers.
+type HandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]TaskHandler
+}
+
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{handlers: make(map[string]TaskHandler)}
+}
+
+// Register adds a handler. Kinds and payload keys must be unique and must not
+// collide with the envelope's own fields.
+func (r *HandlerRegistry) Register(h TaskHandler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kind, key := h.Kind(), h.PayloadKey()
+	if kind == "" || key == "" {
+		return fmt.Errorf("handler must declare a kind and payload key")
+	}
+	if isEnvelopeField(key) {
+		return fmt.Errorf("payload key %q for kind %q collides with an envelope field", key, kind)
+	}
+	if _, ok := r.handlers[kind]; ok {
+		return fmt.Errorf("handler for kind %q already registered", kind)
+	}
+	for _, other := range r.handlers {
+		if other.PayloadKey() == key {
+			return fmt.Errorf("payload key %q already used by kind %q", key, other.Kind())
+		}
+	}
+	r.handlers[kind] = h
+	return nil
+}
+
+// Lookup returns the handler registered for kind.
+func (r *HandlerRegistry) Lookup(kind string) (TaskHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[kind]
+	return h, ok
+}
+
+// Kinds lists the registered task kinds in sorted order.
+func (r *HandlerRegistry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	kinds := make([]string, 0, len(r.handlers))
+	for k := range r.handlers {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// KindLabel returns the registered kind named by a task payload, or "unknown". It is
+// lenient so even malformed envelopes can be attributed in metrics.
+func (r *HandlerRegistry) KindLabel(data []byte) string {
+	var env struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return kindUnknown
+	}
+	if _, ok := r.Lookup(env.Kind); !ok {
+		return kindUnknown
+	}
+	return env.Kind
+}
+
+func (r *HandlerRegistry) isPayloadKey(key string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.handlers {
+		if h.PayloadKey() == key {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnvelopeField(key string) bool {
+	switch key {
+	case "version", "kind", "meta":
+		return true
+	}
+	return false
+}
+
+// Decode strictly decodes a task payload into an envelope and routes the kind
+// payload to its handler for decoding. Unknown fields, trailing data, unsupported
+// kinds and unsupported versions are rejected.
+func (r *HandlerRegistry) Decode(data []byte) (*TaskEnvelope, TaskHandler, error) {
+	var fields map[string]json.RawMessage
+	if err := decodeStrict(data, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	env := &TaskEnvelope{}
+	if raw, ok := fields["version"]; ok {
+		if err := json.Unmarshal(raw, &env.Version); err != nil {
+			return nil, nil, fieldErrorf("version", "must be an unsigned integer")
+		}
+	}
+	if env.Version == 0 {
+		env.Version = EnvelopeVersionV1
+	}
+	if raw, ok := fields["kind"]; ok {
+		if err := json.Unmarshal(raw, &env.Kind); err != nil {
+			return nil, nil, fieldErrorf("kind", "must be a string")
+		}
+	}
+	if env.Kind == "" {
+		return nil, nil, fieldErrorf("kind", "missing")
+	}
+	if raw, ok := fields["meta"]; ok {
+		if err := json.Unmarshal(raw, &env.Metadata); err != nil {
+			return nil, nil, fieldErrorf("meta", "must be an object of strings")
+		}
+	}
+
+	h, ok := r.Lookup(env.Kind)
+	if !ok {
+		return nil, nil, fieldErrorf("kind", "unsupported task kind %q", env.Kind)
+	}
+
+	for key := range fields {
+		if isEnvelopeField(key) || key == h.PayloadKey() {
+			continue
+		}
+		if r.isPayloadKey(key) {
+			return nil, nil, fieldErrorf(key, "not allowed for kind %q", env.Kind)
+		}
+		return nil, nil, fmt.Errorf("json: unknown field %q", key)
+	}
+
+	raw, ok := fields[h.PayloadKey()]
+	if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil, nil, fieldErrorf(h.PayloadKey(), "missing for kind %q", env.Kind)
+	}
+	payload, err := h.Decode(env.Version, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	env.Payload = payload
+	return env, h, nil
+}
+
+// decodeStrict decodes a single JSON value into v, rejecting unknown fields and trailing data.
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected trailing data")
+	}
+	return nil
+}
+
+// unsupportedVersion is returned by handlers that have no schema for an envelope version.
+func unsupportedVersion(kind string, version uint32) error {
+	return fieldErrorf("version", "unsupported version %d for kind %q", version, kind)
+}
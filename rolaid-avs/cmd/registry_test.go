@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+// echoHandler is a minimal custom kind used to exercise the registry.
+type echoHandler struct {
+	validated bool
+}
+
+type echoTask struct {
+	Message string `json:"message"`
+}
+
+func (h *echoHandler) Kind() string       { return "echo" }
+func (h *echoHandler) PayloadKey() string { return "echo" }
+
+func (h *echoHandler) Decode(version uint32, raw json.RawMessage) (interface{}, error) {
+	if version != EnvelopeVersionV1 {
+		return nil, unsupportedVersion(h.Kind(), version)
+	}
+	var e echoTask
+	if err := decodeStrict(raw, &e); err != nil {
+		return nil, fieldErrorf(h.PayloadKey(), "%v", err)
+	}
+	return &e, nil
+}
+
+func (h *echoHandler) Validate(ctx context.Context, payload interface{}) error {
+	h.validated = true
+	return nil
+}
+
+func (h *echoHandler) Handle(ctx context.Context, payload interface{}) ([]byte, error) {
+	return []byte(payload.(*echoTask).Message), nil
+}
+
+func Test_RegisterCustomHandler(t *testing.T) {
+	tw := NewTaskWorker(zap.NewNop())
+	h := &echoHandler{}
+	if err := tw.RegisterHandler(h); err != nil {
+		t.Fatalf("RegisterHandler failed: %v", err)
+	}
+	if err := tw.RegisterHandler(&echoHandler{}); err == nil {
+		t.Fatalf("expected duplicate registration to fail")
+	}
+
+	want := []string{"auction_settlement", "echo", "insurance_payout"}
+	if got := tw.SupportedKinds(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("SupportedKinds = %v, want %v", got, want)
+	}
+
+	req := &performerV1.TaskRequest{
+		TaskId:  []byte("echo-task"),
+		Payload: []byte(`{"version": 1, "kind": "echo", "echo": {"message": "hi"}}`),
+	}
+	if err := tw.ValidateTask(req); err != nil {
+		t.Fatalf("ValidateTask failed: %v", err)
+	}
+	if !h.validated {
+		t.Fatalf("handler Validate was not called")
+	}
+	resp, err := tw.HandleTask(req)
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+	if string(resp.Result) != "hi" {
+		t.Fatalf("result = %q, want %q", resp.Result, "hi")
+	}
+}
+
+func Test_StatusEndpoint(t *testing.T) {
+	tw := NewTaskWorker(zap.NewNop())
+	srv := newStatusServer("", tw)
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %d", rec.Code)
+	}
+
+	var got statusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	want := []string{KindAuctionSettlement, KindInsurancePayout}
+	if !reflect.DeepEqual(got.SupportedKinds, want) {
+		t.Fatalf("supported_kinds = %v, want %v", got.SupportedKinds, want)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/preflight"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/rpcpool"
+	"go.uber.org/zap"
+)
+
+// dialPool dials every endpoint of a chain into a pool. Endpoints that fail to dial are
+// left out; the pool fails when fewer than need dial, so a quorum is never read from
+// fewer endpoints than it names.
+func dialPool(logger *zap.Logger, urls []string, chain string, need int, cfg RPCConfig) (*rpcpool.Pool, error) {
+	var endpoints []rpcpool.Endpoint
+	var errs []error
+	for _, u := range urls {
+		name := endpointName(u)
+		client, err := dialTraced(u, chain)
+		if err != nil {
+			logger.Warn("Failed to dial RPC endpoint", zap.String("chain", chain), zap.String("endpoint", name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		endpoints = append(endpoints, rpcpool.Endpoint{Name: name, Client: client})
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(endpoints) < need {
+		return nil, fmt.Errorf("%d of %d endpoints dialed, need %d: %w", len(endpoints), len(urls), need, errors.Join(errs...))
+	}
+	return rpcpool.New(endpoints, rpcpool.Config{
+		HedgeAfter:  cfg.HedgeAfter,
+		MaxFailures: cfg.MaxFailures,
+		Cooldown:    cfg.Cooldown,
+	})
+}
+
+// endpointName identifies an RPC URL in logs and status without the path or query,
+// where providers put API keys.
+func endpointName(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "invalid-url"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// criticalL1 returns the L1 reader for checks that gate signing: with a configured
+// quorum, enough endpoints must agree on every read.
+func (tw *TaskWorker) criticalL1() preflight.Backend {
+	if q := tw.config.RPC.Quorum; q > 1 {
+		return tw.l1Client.Quorum(q)
+	}
+	return tw.l1Client
+}
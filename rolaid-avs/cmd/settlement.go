@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	taskmailbox "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/TaskMailbox"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/submitter"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// maxSettlementRequestSize bounds a POST /settlements body.
+const maxSettlementRequestSize = 1 << 20
+
+// taskStatusVerified is ITaskMailboxTypes.TaskStatus.VERIFIED: the task's result carries
+// a certificate the mailbox checked.
+const taskStatusVerified = 2
+
+func (c *SubmitterConfig) validate() error {
+	if _, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x")); err != nil {
+		return fmt.Errorf("private_key: %w", err)
+	}
+	if !common.IsHexAddress(c.AVS) {
+		return fmt.Errorf("avs: invalid address %q", c.AVS)
+	}
+	if c.ListenAddr == "" {
+		return fmt.Errorf("listen_addr missing")
+	}
+	if c.BumpPercent != 0 && c.BumpPercent < 10 {
+		return fmt.Errorf("bump_percent must be at least 10, got %d", c.BumpPercent)
+	}
+	if c.BumpAfter < 0 || c.PollInterval < 0 {
+		return fmt.Errorf("bump_after and poll_interval must not be negative")
+	}
+	if c.MaxFeePerGas != "" {
+		if _, err := wei.Parse(c.MaxFeePerGas); err != nil {
+			return fmt.Errorf("max_fee_per_gas: %w", err)
+		}
+	}
+	return nil
+}
+
+// SettlementRequest is the body of POST /settlements: a verified auction_settlement task
+// and the winning bid's settlement data its result commits to. Everything else is read
+// from the task on the TaskMailbox: the winner and bid from the certified result, the
+// app id and image digest from the task payload.
+type SettlementRequest struct {
+	TaskHash       string `json:"task_hash"`       // bytes32 hex, TaskMailbox task hash
+	SettlementData string `json:"settlement_data"` // hex
+}
+
+// taskReader reads tasks from the TaskMailbox. taskmailbox.TaskMailbox implements it.
+type taskReader interface {
+	GetTaskInfo(opts *bind.CallOpts, taskHash [32]byte) (taskmailbox.ITaskMailboxTypesTask, error)
+}
+
+// settlementStatus is how the API reports a settlement transaction.
+type settlementStatus struct {
+	ID          string           `json:"id"`
+	AuctionId   string           `json:"auction_id"`
+	Status      submitter.Status `json:"status"`
+	Nonce       *uint64          `json:"nonce,omitempty"`
+	TxHash      string           `json:"tx_hash,omitempty"` // the mined attempt, else the latest one
+	Attempts    int              `json:"attempts"`
+	BlockNumber uint64           `json:"block_number,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Created     time.Time        `json:"created"`
+	Updated     time.Time        `json:"updated"`
+}
+
+func newSettlementStatus(tx submitter.Tx) settlementStatus {
+	s := settlementStatus{
+		ID:        tx.ID,
+		AuctionId: strings.TrimPrefix(tx.ID, settlementIDPrefix),
+		Status:    tx.Status,
+		Attempts:  len(tx.Attempts),
+		Error:     tx.Error,
+		Created:   tx.Created,
+		Updated:   tx.Updated,
+	}
+	if len(tx.Attempts) > 0 {
+		nonce := tx.Nonce
+		s.Nonce = &nonce
+		s.TxHash = tx.Attempts[len(tx.Attempts)-1].Hash.Hex()
+	}
+	if tx.Mined != nil {
+		s.TxHash = tx.Mined.TxHash.Hex()
+		s.BlockNumber = tx.Mined.BlockNumber
+	}
+	return s
+}
+
+// settlementIDPrefix prefixes the auction id in submitter transaction ids, so each
+// auction is settled at most once.
+const settlementIDPrefix = "auction-"
+
+// settlementService checks settlements against verified TaskMailbox tasks and queues
+// their submitSettlement calls.
+type settlementService struct {
+	tw             *TaskWorker
+	submitter      *submitter.Submitter
+	tasks          taskReader
+	auctionService common.Address
+	avs            common.Address
+	operatorSetId  uint32
+	abi            *abi.ABI
+}
+
+func newSettlementService(tw *TaskWorker, sub *submitter.Submitter, tasks taskReader) (*settlementService, error) {
+	addr, err := tw.resolveContract(ContractAuctionService)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := auctionservice.AuctionServiceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &settlementService{
+		tw:             tw,
+		submitter:      sub,
+		tasks:          tasks,
+		auctionService: addr,
+		avs:            common.HexToAddress(tw.config.Submitter.AVS),
+		operatorSetId:  tw.config.Submitter.OperatorSetId,
+		abi:            parsed,
+	}, nil
+}
+
+// request checks a settlement against the verified task it names and builds the
+// submitSettlement call, paying the bid.
+func (s *settlementService) request(ctx context.Context, req *SettlementRequest) (submitter.Request, error) {
+	if err := requireBytes32("task_hash", req.TaskHash); err != nil {
+		return submitter.Request{}, err
+	}
+	task, err := s.tasks.GetTaskInfo(&bind.CallOpts{Context: ctx}, common.HexToHash(req.TaskHash))
+	if err != nil {
+		return submitter.Request{}, fmt.Errorf("read task: %w", err)
+	}
+	if task.Status != taskStatusVerified {
+		return submitter.Request{}, fieldErrorf("task_hash", "task is not verified (status %d)", task.Status)
+	}
+	if task.Avs != s.avs || task.ExecutorOperatorSetId != s.operatorSetId {
+		return submitter.Request{}, fieldErrorf("task_hash", "task is for AVS %s operator set %d, not %s operator set %d",
+			task.Avs.Hex(), task.ExecutorOperatorSetId, s.avs.Hex(), s.operatorSetId)
+	}
+	env, _, err := s.tw.registry.Decode(task.Payload)
+	if err != nil {
+		return submitter.Request{}, fieldErrorf("task_hash", "payload: %v", err)
+	}
+	a, ok := env.Payload.(*AuctionTask)
+	if !ok {
+		return submitter.Request{}, fieldErrorf("task_hash", "not an %s task", KindAuctionSettlement)
+	}
+	r, err := results.DecodeAuctionSettlement(task.Result)
+	if err != nil {
+		return submitter.Request{}, fieldErrorf("task_hash", "result: %v", err)
+	}
+	if !r.AuctionId.IsUint64() || r.AuctionId.Uint64() != a.AuctionId {
+		return submitter.Request{}, fieldErrorf("task_hash", "result settles auction %s, task names %d", r.AuctionId, a.AuctionId)
+	}
+	if r.Winner == (common.Address{}) {
+		return submitter.Request{}, fieldErrorf("task_hash", "result names no winner")
+	}
+	data, err := decodeHexBytes(req.SettlementData)
+	if err != nil {
+		return submitter.Request{}, classFieldErrorf(ErrBadHex, "settlement_data", "invalid hex: %v", err)
+	}
+	want := commitment.AuctionSettlement(r.AuctionId, r.PoolId, r.OracleUpdateId, commitment.SettlementHash(data))
+	if common.Hash(r.Commitment) != want {
+		return submitter.Request{}, fieldErrorf("settlement_data", "does not match the result's commitment")
+	}
+	if r.AuctionService != s.auctionService {
+		return submitter.Request{}, fieldErrorf("task_hash", "result settles AuctionService %s, not %s", r.AuctionService.Hex(), s.auctionService.Hex())
+	}
+	calldata, err := s.abi.Pack("submitSettlement", r.AuctionId,
+		common.HexToHash(a.AppId), common.HexToHash(a.ImageDigest), r.Winner, r.BidAmount, data)
+	if err != nil {
+		return submitter.Request{}, fmt.Errorf("pack submitSettlement: %w", err)
+	}
+	return submitter.Request{
+		ID:    settlementIDPrefix + r.AuctionId.String(),
+		To:    s.auctionService,
+		Data:  calldata,
+		Value: new(big.Int).Set(r.BidAmount),
+	}, nil
+}
+
+// handler serves the settlement API:
+//
+//	POST /settlements               queue a SettlementRequest, 202 with its status
+//	GET  /settlements               every settlement, oldest first
+//	GET  /settlements/{auction_id}  one settlement
+func (s *settlementService) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /settlements", s.post)
+	mux.HandleFunc("GET /settlements", func(w http.ResponseWriter, r *http.Request) {
+		out := []settlementStatus{}
+		for _, tx := range s.submitter.List() {
+			out = append(out, newSettlementStatus(tx))
+		}
+		writeJSON(w, s.tw.logger, http.StatusOK, out)
+	})
+	mux.HandleFunc("GET /settlements/{auction_id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("auction_id"), 10, 64)
+		if err != nil {
+			writeJSON(w, s.tw.logger, http.StatusBadRequest, errorResponse{Error: "auction_id must be a decimal integer"})
+			return
+		}
+		tx, ok := s.submitter.Get(settlementIDPrefix + strconv.FormatUint(id, 10))
+		if !ok {
+			writeJSON(w, s.tw.logger, http.StatusNotFound, errorResponse{Error: "no settlement for auction"})
+			return
+		}
+		writeJSON(w, s.tw.logger, http.StatusOK, newSettlementStatus(tx))
+	})
+	return mux
+}
+
+// errorResponse is the body of a failed settlement API call.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *settlementService) post(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSettlementRequestSize))
+	if err != nil {
+		writeJSON(w, s.tw.logger, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	var req SettlementRequest
+	if err := decodeStrict(bytes.TrimSpace(body), &req); err != nil {
+		writeJSON(w, s.tw.logger, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	call, err := s.request(r.Context(), &req)
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		writeJSON(w, s.tw.logger, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(w, s.tw.logger, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	tx, err := s.submitter.Enqueue(call)
+	if err != nil {
+		writeJSON(w, s.tw.logger, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	// Enqueue returns the queued call for a repeated auction, unless it failed; only the
+	// same call is a retry.
+	if !bytes.Equal(tx.Data, call.Data) || tx.Value.Cmp(call.Value) != 0 {
+		writeJSON(w, s.tw.logger, http.StatusConflict, errorResponse{Error: "auction already has a different settlement"})
+		return
+	}
+	writeJSON(w, s.tw.logger, http.StatusAccepted, newSettlementStatus(tx))
+}
+
+// newSubmitter builds the settlement submitter on the L1 client, persisting to the state
+// database when there is one.
+func (tw *TaskWorker) newSubmitter(ctx context.Context) (*submitter.Submitter, error) {
+	cfg := tw.config.Submitter
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("submitter private key: %w", err)
+	}
+	chainID, err := tw.l1Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("L1 chain id: %w", err)
+	}
+	if err := tw.requireState(); err != nil {
+		return nil, err
+	}
+	store, err := submitter.NewBoltStore(tw.stateDB)
+	if err != nil {
+		return nil, err
+	}
+	var maxFee *big.Int
+	if cfg.MaxFeePerGas != "" {
+		amount, err := wei.Parse(cfg.MaxFeePerGas)
+		if err != nil {
+			return nil, fmt.Errorf("submitter max fee per gas: %w", err)
+		}
+		maxFee = amount.Big()
+	}
+	return submitter.New(tw.l1Client, store, submitter.Config{
+		Key:           key,
+		ChainID:       chainID,
+		MaxFeePerGas:  maxFee,
+		BumpAfter:     cfg.BumpAfter,
+		BumpPercent:   cfg.BumpPercent,
+		Confirmations: cfg.Confirmations,
+		PollInterval:  cfg.PollInterval,
+		OnUpdate: func(tx submitter.Tx) {
+			st := newSettlementStatus(tx)
+			tw.logger.Info("Settlement updated",
+				zap.String("auction_id", st.AuctionId),
+				zap.String("status", string(st.Status)),
+				zap.String("tx_hash", st.TxHash),
+				zap.Int("attempts", st.Attempts),
+				zap.String("error", st.Error),
+			)
+		},
+		OnError: func(err error) {
+			tw.logger.Warn("Settlement sync failed", zap.Error(err))
+		},
+	})
+}
+
+// runSettlementSubmitter implements `performer settlement-submitter [flags]`. It serves
+// the settlement API and sends the queued settlements to AuctionService from the
+// configured key, which pays each winning bid.
+func runSettlementSubmitter(args []string) error {
+	cfg, _, err := loadConfigArgs("settlement-submitter", args)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := cfg.Submitter.validate(); err != nil {
+		return fmt.Errorf("submitter: %w", err)
+	}
+	l, err := cfg.newLogger()
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	defer l.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	cfg.stateFile = settlementStateDBFile
+	w := NewTaskWorkerWithConfig(l, cfg)
+	defer w.Close()
+	if err := w.requireState(); err != nil {
+		return err
+	}
+	if err := w.requireL1Client(); err != nil {
+		return err
+	}
+	sub, err := w.newSubmitter(ctx)
+	if err != nil {
+		return err
+	}
+	mailbox, err := w.TaskMailbox()
+	if err != nil {
+		return err
+	}
+	svc, err := newSettlementService(w, sub, mailbox)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              cfg.Submitter.ListenAddr,
+		Handler:           svc.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	l.Info("Submitting settlements",
+		zap.String("listen_addr", cfg.Submitter.ListenAddr),
+		zap.String("auction_service", svc.auctionService.Hex()),
+		zap.String("avs", cfg.Submitter.AVS),
+		zap.Uint32("operator_set_id", cfg.Submitter.OperatorSetId),
+		zap.String("from", sub.From().Hex()),
+	)
+	go sub.Run(ctx)
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("settlement API: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	taskmailbox "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/TaskMailbox"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/results"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/submitter"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// settlementBackend accepts every transaction and mines none.
+type settlementBackend struct {
+	sent []*types.Transaction
+}
+
+func (b *settlementBackend) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (b *settlementBackend) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func (b *settlementBackend) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 100_000, nil
+}
+
+func (b *settlementBackend) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (b *settlementBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1)}, nil
+}
+
+func (b *settlementBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *settlementBackend) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+// testTasks is a TaskMailbox holding tasks by hash.
+type testTasks map[common.Hash]taskmailbox.ITaskMailboxTypesTask
+
+func (m testTasks) GetTaskInfo(opts *bind.CallOpts, taskHash [32]byte) (taskmailbox.ITaskMailboxTypesTask, error) {
+	return m[taskHash], nil
+}
+
+// settlementTask is a verified task for auction id whose result is won by winner with
+// settlementData.
+func settlementTask(t *testing.T, id int64, winner common.Address, settlementData []byte, bid int64) taskmailbox.ITaskMailboxTypesTask {
+	t.Helper()
+	r := &results.AuctionSettlementResult{
+		AuctionId:      big.NewInt(id),
+		PoolId:         common.HexToHash(testBytes32A),
+		OracleUpdateId: common.HexToHash(testBytes32B),
+		AuctionService: testServiceAddress,
+		BidAmount:      big.NewInt(bid),
+		Winner:         winner,
+	}
+	r.Commitment = commitment.AuctionSettlement(r.AuctionId, r.PoolId, r.OracleUpdateId, commitment.SettlementHash(settlementData))
+	raw, err := results.EncodeAuctionSettlement(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"version": EnvelopeVersionV1,
+		"kind":    KindAuctionSettlement,
+		"auction": &AuctionTask{
+			AuctionId:       uint64(id),
+			PoolId:          testBytes32A,
+			OracleUpdateId:  testBytes32B,
+			AppId:           testBytes32A,
+			ImageDigest:     testBytes32B,
+			SettlementData:  hexutil.Encode(settlementData),
+			ExpectedBidWei:  strconv.FormatInt(bid, 10),
+			SubmissionNonce: 1,
+			AuctionService:  testServiceAddress.Hex(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return taskmailbox.ITaskMailboxTypesTask{
+		Avs:                   common.HexToAddress(testAddress),
+		ExecutorOperatorSetId: 1,
+		Status:                taskStatusVerified,
+		Payload:               payload,
+		Result:                raw,
+	}
+}
+
+func postSettlement(t *testing.T, h http.Handler, req SettlementRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/settlements", strings.NewReader(string(body))))
+	return rec
+}
+
+func Test_SettlementService(t *testing.T) {
+	tw := newEventTestWorker(t)
+	tw.config.Submitter.AVS = testAddress
+	tw.config.Submitter.OperatorSetId = 1
+	backend := &settlementBackend{}
+	key, _ := crypto.HexToECDSA(testBytes32A[2:])
+	sub, err := submitter.New(backend, submitter.NewMemoryStore(), submitter.Config{Key: key, ChainID: big.NewInt(31337)})
+	if err != nil {
+		t.Fatalf("submitter.New: %v", err)
+	}
+	data := []byte("bob")
+	winner := common.HexToAddress("0xb2")
+	tasks := testTasks{}
+	task := func(b byte, mutate func(*taskmailbox.ITaskMailboxTypesTask)) string {
+		task := settlementTask(t, 7, winner, data, 1000)
+		if mutate != nil {
+			mutate(&task)
+		}
+		h := common.BytesToHash([]byte{b})
+		tasks[h] = task
+		return h.Hex()
+	}
+	svc, err := newSettlementService(tw, sub, tasks)
+	if err != nil {
+		t.Fatalf("newSettlementService: %v", err)
+	}
+	h := svc.handler()
+	req := SettlementRequest{TaskHash: task(1, nil), SettlementData: hexutil.Encode(data)}
+
+	if rec := postSettlement(t, h, req); rec.Code != http.StatusAccepted {
+		t.Fatalf("POST = %d %s", rec.Code, rec.Body)
+	}
+	// A retry of the same settlement is accepted again.
+	if rec := postSettlement(t, h, req); rec.Code != http.StatusAccepted {
+		t.Fatalf("repeated POST = %d %s", rec.Code, rec.Body)
+	}
+	if err := sub.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(backend.sent) != 1 {
+		t.Fatalf("sent %d transactions, want 1", len(backend.sent))
+	}
+	tx := backend.sent[0]
+	if *tx.To() != testServiceAddress || tx.Value().Int64() != 1000 {
+		t.Fatalf("transaction to %s value %v", tx.To().Hex(), tx.Value())
+	}
+	parsed, _ := auctionservice.AuctionServiceMetaData.GetAbi()
+	args, err := parsed.Methods["submitSettlement"].Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		t.Fatalf("calldata: %v", err)
+	}
+	if args[0].(*big.Int).Int64() != 7 || common.Hash(args[1].([32]byte)) != common.HexToHash(testBytes32A) ||
+		common.Hash(args[2].([32]byte)) != common.HexToHash(testBytes32B) || args[3].(common.Address) != winner ||
+		args[4].(*big.Int).Int64() != 1000 || string(args[5].([]byte)) != "bob" {
+		t.Fatalf("submitSettlement args = %v", args)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/settlements/7", nil))
+	var st settlementStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("GET = %d %s", rec.Code, rec.Body)
+	}
+	if st.Status != submitter.StatusPending || st.TxHash != tx.Hash().Hex() || st.AuctionId != "7" {
+		t.Fatalf("status = %+v", st)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/settlements/8", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET unknown auction = %d", rec.Code)
+	}
+
+	for name, tc := range map[string]struct {
+		mutate func(*SettlementRequest)
+		code   int
+		field  string
+	}{
+		"settlement data": {func(r *SettlementRequest) { r.SettlementData = hexutil.Encode([]byte("eve")) }, http.StatusBadRequest, "settlement_data"},
+		"bad task hash":   {func(r *SettlementRequest) { r.TaskHash = "0x1234" }, http.StatusBadRequest, "task_hash"},
+		"unknown task":    {func(r *SettlementRequest) { r.TaskHash = common.HexToHash("0x99").Hex() }, http.StatusBadRequest, "not verified"},
+		"unverified": {func(r *SettlementRequest) {
+			r.TaskHash = task(2, func(t *taskmailbox.ITaskMailboxTypesTask) { t.Status = 1 })
+		}, http.StatusBadRequest, "not verified"},
+		"other avs": {func(r *SettlementRequest) {
+			r.TaskHash = task(3, func(t *taskmailbox.ITaskMailboxTypesTask) { t.Avs = common.HexToAddress("0xe1") })
+		}, http.StatusBadRequest, "operator set"},
+		"no winner": {func(r *SettlementRequest) {
+			r.TaskHash = task(4, func(tk *taskmailbox.ITaskMailboxTypesTask) {
+				tk.Result = settlementTask(t, 7, common.Address{}, data, 1000).Result
+			})
+		}, http.StatusBadRequest, "winner"},
+		"other auction": {func(r *SettlementRequest) {
+			r.TaskHash = task(5, func(tk *taskmailbox.ITaskMailboxTypesTask) {
+				tk.Result = settlementTask(t, 8, winner, data, 1000).Result
+			})
+		}, http.StatusBadRequest, "auction 8"},
+		"other bid": {func(r *SettlementRequest) {
+			r.TaskHash = task(6, func(tk *taskmailbox.ITaskMailboxTypesTask) {
+				tk.Result = settlementTask(t, 7, winner, data, 2000).Result
+			})
+		}, http.StatusConflict, ""},
+	} {
+		r := req
+		tc.mutate(&r)
+		rec := postSettlement(t, h, r)
+		if rec.Code != tc.code || !strings.Contains(rec.Body.String(), tc.field) {
+			t.Errorf("%s: POST = %d %s", name, rec.Code, rec.Body)
+		}
+	}
+}
+
+func Test_SubmitterConfig(t *testing.T) {
+	t.Setenv("SUBMITTER_PRIVATE_KEY", testBytes32A)
+	t.Setenv("SUBMITTER_AVS", testAddress)
+	t.Setenv("SUBMITTER_OPERATOR_SET_ID", "1")
+	t.Setenv("SUBMITTER_CONFIRMATIONS", "12")
+	t.Setenv("SUBMITTER_BUMP_AFTER", "90s")
+	t.Setenv("SUBMITTER_MAX_FEE_PER_GAS", "50 gwei")
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	sc := cfg.Submitter
+	if sc.OperatorSetId != 1 || sc.ListenAddr != "127.0.0.1:8082" || sc.Confirmations != 12 || sc.BumpAfter.Seconds() != 90 || sc.ListenAddr != defaultConfig().Submitter.ListenAddr {
+		t.Fatalf("submitter config = %+v", sc)
+	}
+	if err := sc.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	sc.BumpPercent = 5
+	if err := sc.validate(); err == nil || !strings.Contains(err.Error(), "bump_percent") {
+		t.Fatalf("validate = %v, want bump_percent error", err)
+	}
+}
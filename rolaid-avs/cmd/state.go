@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/replay"
+	bolt "go.etcd.io/bbolt"
+)
+
+// State database files under the data directory. bbolt locks its file, so each process
+// sharing a data dir keeps its own.
+const (
+	stateDBFile            = "performer.db"
+	settlementStateDBFile  = "settlement-submitter.db"
+	taskCreatorStateDBFile = "task-creator.db"
+)
+
+// openStateDB opens (creating if needed) the local state database file in dataDir.
+func openStateDB(dataDir, file string) (*bolt.DB, error) {
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return nil, fmt.Errorf("create data dir: %w", err)
+	}
+	db, err := bolt.Open(filepath.Join(dataDir, file), 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open state db: %w", err)
+	}
+	return db, nil
+}
+
+// newReplayGuard persists nonces in db. Without a data dir, which only offline runs such
+// as the determinism check have (commands refuse to start, see requireState), nonces are
+// kept in memory. A state db that exists but cannot hold the guard is an error: falling
+// back to memory would let a restart re-sign replayed envelopes.
+func newReplayGuard(db *bolt.DB) (replay.Guard, error) {
+	if db == nil {
+		return replay.NewMemoryGuard(), nil
+	}
+	g, err := replay.NewBoltGuard(db)
+	if err != nil {
+		return replay.NewMemoryGuard(), fmt.Errorf("replay guard: %w", err)
+	}
+	return g, nil
+}
+
+// requireState reports whether no data dir is set, or the configured one failed to open
+// or initialize. Commands refuse to start on it rather than run with in-memory state,
+// where a restart would forget replay nonces and queued transactions.
+func (tw *TaskWorker) requireState() error {
+	if tw.config.DataDir == "" {
+		return errors.New("no data dir: set -data-dir or PERFORMER_DATA_DIR")
+	}
+	if tw.stateErr != nil {
+		return fmt.Errorf("state in %s: %w", tw.config.DataDir, tw.stateErr)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// statusResponse is served on /status so operators can see what this performer supports.
+type statusResponse struct {
+	SupportedKinds []string `json:"supported_kinds"`
+}
+
+// livenessResponse is served on /healthz. It only reports that the process is serving.
+type livenessResponse struct {
+	Status string `json:"status"`
+}
+
+// newStatusServer builds the HTTP server exposing performer status alongside the gRPC performer.
+func newStatusServer(addr string, tw *TaskWorker) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, tw.logger, http.StatusOK, statusResponse{SupportedKinds: tw.SupportedKinds()})
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, tw.logger, http.StatusOK, livenessResponse{Status: "ok"})
+	})
+	mux.Handle("/metrics", tw.metrics.handler())
+	tw.handleAuctions(mux)
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		resp := tw.readiness(r.Context())
+		code := http.StatusOK
+		if !resp.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, tw.logger, code, resp)
+	})
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, logger *zap.Logger, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warn("Failed to write status response", zap.Error(err))
+	}
+}
@@ -0,0 +1,624 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	taskmailbox "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/TaskMailbox"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/rpcpool"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/submitter"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// auctionTasksBucket holds the task creator's auction records, keyed by auction id.
+const auctionTasksBucket = "auction_tasks"
+
+// maxBidBookSize bounds the sealed-bid book read from the bid relay.
+const maxBidBookSize = 4 << 20
+
+// taskIDPrefix keys createTask transactions in the submitter queue.
+const taskIDPrefix = "task-"
+
+var (
+	errTaskUnknown = errors.New("no task posted under key")
+	errTaskPending = errors.New("task not mined yet")
+	errTaskFailed  = errors.New("task transaction failed")
+)
+
+func (c *TaskCreatorConfig) validate() error {
+	if _, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x")); err != nil {
+		return fmt.Errorf("private_key: %w", err)
+	}
+	if !common.IsHexAddress(c.AVS) {
+		return fmt.Errorf("avs: invalid address %q", c.AVS)
+	}
+	if c.RefundCollector != "" && !common.IsHexAddress(c.RefundCollector) {
+		return fmt.Errorf("refund_collector: invalid address %q", c.RefundCollector)
+	}
+	// AuctionCreated does not name the pool, so a creator follows exactly one.
+	if len(c.Pools) != 1 {
+		return fmt.Errorf("pools: need exactly one pool id, got %d", len(c.Pools))
+	}
+	if err := requireBytes32("pools[0]", c.Pools[0]); err != nil {
+		return err
+	}
+	if err := requireBytes32("app_id", c.AppId); err != nil {
+		return err
+	}
+	if err := requireBytes32("image_digest", c.ImageDigest); err != nil {
+		return err
+	}
+	if u, err := url.Parse(c.BidsURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("bids_url: invalid URL %q", c.BidsURL)
+	}
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive")
+	}
+	return nil
+}
+
+// auctionTaskRecord tracks an AuctionService auction until its task is on the TaskMailbox.
+// The block fields record which event set each field, so a reorg can undo it.
+type auctionTaskRecord struct {
+	AuctionId      uint64      `json:"auction_id"`
+	OracleUpdateId common.Hash `json:"oracle_update_id"`
+	StartTime      uint64      `json:"start_time"`
+	EndTime        uint64      `json:"end_time"`
+	Block          uint64      `json:"block"` // block of AuctionCreated
+	PoolId         common.Hash `json:"pool_id"`
+
+	// SettledBlock is set when the auction was settled without a task from this creator.
+	SettledBlock uint64 `json:"settled_block,omitempty"`
+
+	// Posts counts the createTask transactions given up on; the next post is keyed past them.
+	Posts    uint32      `json:"posts,omitempty"`
+	TaskHash common.Hash `json:"task_hash"` // set once the task is on the mailbox
+}
+
+// postKey keys the auction's current createTask in the submitter queue.
+func (r *auctionTaskRecord) postKey() string {
+	return fmt.Sprintf("%s%d-%d", taskIDPrefix, r.AuctionId, r.Posts)
+}
+
+// pending reports whether the auction still needs a task.
+func (r *auctionTaskRecord) pending() bool {
+	return r.TaskHash == (common.Hash{}) && r.SettledBlock == 0
+}
+
+// bidBookSource returns the sealed-bid book of a closed auction.
+type bidBookSource interface {
+	SealedBids(ctx context.Context, auctionId uint64) (*SealedBidBook, error)
+}
+
+// taskSubmitter posts task payloads to the TaskMailbox under a key. The post is saved
+// before it is sent and followed across restarts, so asking for a key again reads the
+// outcome of the transaction already sent rather than creating a second task.
+type taskSubmitter interface {
+	// CreateTask queues a createTask for payload under key. Queuing a key again keeps
+	// the first payload.
+	CreateTask(key string, payload []byte) error
+	// TaskHash returns the hash of the task created under key. It returns errTaskUnknown
+	// when nothing was queued under key, errTaskPending until the transaction is mined
+	// and errTaskFailed once it can no longer create the task.
+	TaskHash(ctx context.Context, key string) (common.Hash, error)
+}
+
+// taskCreator turns protocol events into auction_settlement tasks. AuctionService opens
+// an auction per oracle update, and AuctionCreated carries the oracle update id. Nothing
+// in AuctionCreated names the pool, so a creator follows the one configured pool and
+// every auction is taken to be on it. Once an auction's window has closed, the creator fetches its
+// sealed-bid book, builds the envelope and posts it to the TaskMailbox. Auctions settled
+// some other way (SettlementSubmitted, or the hook authorizing a winner for the oracle
+// update) are skipped.
+type taskCreator struct {
+	tw             *TaskWorker
+	cfg            TaskCreatorConfig
+	auctionService common.Address
+	bids           bidBookSource
+	submitter      taskSubmitter
+	now            func() time.Time
+	pool           common.Hash // AuctionCreated does not name it, see TaskCreatorConfig.validate
+
+	mu       sync.Mutex
+	auctions map[uint64]*auctionTaskRecord
+}
+
+func newTaskCreator(tw *TaskWorker, bids bidBookSource, submitter taskSubmitter) (*taskCreator, error) {
+	// The records are what keeps a restart from posting an auction's task twice.
+	if err := tw.requireState(); err != nil {
+		return nil, err
+	}
+	if len(tw.config.TaskCreator.Pools) != 1 {
+		return nil, fmt.Errorf("task creator needs exactly one pool, got %d", len(tw.config.TaskCreator.Pools))
+	}
+	auctionService, err := tw.resolveContract(ContractAuctionService)
+	if err != nil {
+		return nil, err
+	}
+	c := &taskCreator{
+		tw:             tw,
+		cfg:            tw.config.TaskCreator,
+		auctionService: auctionService,
+		bids:           bids,
+		submitter:      submitter,
+		now:            time.Now,
+		pool:           common.HexToHash(tw.config.TaskCreator.Pools[0]),
+		auctions:       make(map[uint64]*auctionTaskRecord),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *taskCreator) HandleEvent(ctx context.Context, ev *ProtocolEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	block := ev.Log.BlockNumber
+	switch data := ev.Data.(type) {
+	case *auctionservice.AuctionServiceAuctionCreated:
+		if !data.Id.IsUint64() {
+			c.tw.logger.Warn("Skipping auction, id does not fit a task", zap.String("auction_id", data.Id.String()))
+			return nil
+		}
+		id := data.Id.Uint64()
+		if _, ok := c.auctions[id]; ok {
+			return nil // redelivered
+		}
+		r := &auctionTaskRecord{
+			AuctionId:      id,
+			OracleUpdateId: data.OracleUpdateId,
+			StartTime:      data.StartTime,
+			EndTime:        data.EndTime,
+			Block:          block,
+			PoolId:         c.pool,
+		}
+		c.auctions[id] = r
+		return c.save(r)
+	case *auctionservice.AuctionServiceSettlementSubmitted:
+		if !data.Id.IsUint64() {
+			return nil
+		}
+		if r, ok := c.auctions[data.Id.Uint64()]; ok && r.pending() {
+			r.SettledBlock = block
+			return c.save(r)
+		}
+	case *lvrauctionhook.LVRAuctionHookAuctionAuthorized:
+		// Authorizing a winner for the oracle update settles its auction.
+		if data.PoolId != c.pool {
+			return nil
+		}
+		for _, r := range c.auctions {
+			if r.OracleUpdateId != data.OracleUpdateId || !r.pending() {
+				continue
+			}
+			r.SettledBlock = block
+			if err := c.save(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RollbackEvents forgets what the events above block decided. Tasks already on the
+// mailbox stay there; the performers' preflight rejects them if their auction is gone.
+func (c *taskCreator) RollbackEvents(ctx context.Context, block uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, r := range c.auctions {
+		if r.Block > block {
+			if r.TaskHash != (common.Hash{}) {
+				c.tw.logger.Warn("Auction task posted for a reorged auction",
+					zap.Uint64("auction_id", id), zap.String("task_hash", r.TaskHash.Hex()))
+				continue
+			}
+			delete(c.auctions, id)
+			if err := c.remove(id); err != nil {
+				return err
+			}
+			continue
+		}
+		if r.SettledBlock > block {
+			r.SettledBlock = 0
+			if err := c.save(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// run posts the tasks of closed auctions every poll interval until ctx is done.
+func (c *taskCreator) run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		c.createDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// createDue posts a task for every pending auction whose window has closed. Failures
+// are retried on the next call.
+func (c *taskCreator) createDue(ctx context.Context) {
+	for _, r := range c.due() {
+		if err := c.create(ctx, r); err != nil {
+			c.tw.logger.Warn("Auction task not created", zap.Uint64("auction_id", r.AuctionId), zap.Error(err))
+		}
+	}
+}
+
+// due returns copies of the auctions ready for a task, by auction id.
+func (c *taskCreator) due() []auctionTaskRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := uint64(c.now().Unix())
+	var out []auctionTaskRecord
+	for _, r := range c.auctions {
+		if r.pending() && r.EndTime <= now {
+			out = append(out, *r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AuctionId < out[j].AuctionId })
+	return out
+}
+
+// create advances an auction's task by one step: it queues the createTask, or reads the
+// outcome of the one already queued.
+func (c *taskCreator) create(ctx context.Context, r auctionTaskRecord) error {
+	taskHash, err := c.submitter.TaskHash(ctx, r.postKey())
+	switch {
+	case errors.Is(err, errTaskUnknown):
+		return c.post(ctx, r)
+	case errors.Is(err, errTaskPending):
+		return nil
+	case errors.Is(err, errTaskFailed):
+		// Post again under the next key.
+		return c.update(r.AuctionId, func(rec *auctionTaskRecord) { rec.Posts++ }, err)
+	case err != nil:
+		return err
+	}
+	c.tw.logger.Info("Auction task created",
+		zap.Uint64("auction_id", r.AuctionId),
+		zap.String("pool_id", r.PoolId.Hex()),
+		zap.String("oracle_update_id", r.OracleUpdateId.Hex()),
+		zap.String("task_hash", taskHash.Hex()),
+	)
+	return c.update(r.AuctionId, func(rec *auctionTaskRecord) { rec.TaskHash = taskHash }, nil)
+}
+
+// update applies fn to the auction's record and saves it, then returns err. An auction
+// reorged out in the meantime is left alone.
+func (c *taskCreator) update(id uint64, fn func(*auctionTaskRecord), err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rec, ok := c.auctions[id]
+	if !ok {
+		c.tw.logger.Warn("Auction reorged out while its task was posted", zap.Uint64("auction_id", id))
+		return err
+	}
+	fn(rec)
+	if saveErr := c.save(rec); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// post builds the auction's task and queues it.
+func (c *taskCreator) post(ctx context.Context, r auctionTaskRecord) error {
+	book, err := c.bids.SealedBids(ctx, r.AuctionId)
+	if err != nil {
+		return fmt.Errorf("sealed bids: %w", err)
+	}
+	if book.StartTime != r.StartTime || book.EndTime != r.EndTime {
+		return fmt.Errorf("bid book window [%d, %d] does not match onchain [%d, %d]",
+			book.StartTime, book.EndTime, r.StartTime, r.EndTime)
+	}
+	payload, err := c.envelope(&r, book)
+	if err != nil {
+		return err
+	}
+	// Post only tasks the performers accept.
+	env, h, err := c.tw.registry.Decode(payload)
+	if err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+	if err := h.Validate(ctx, env.Payload); err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+
+	if err := c.submitter.CreateTask(r.postKey(), payload); err != nil {
+		return err
+	}
+	c.tw.logger.Info("Auction task queued", zap.Uint64("auction_id", r.AuctionId), zap.String("key", r.postKey()))
+	return nil
+}
+
+// envelope builds the auction_settlement task for an auction and its sealed-bid book.
+func (c *taskCreator) envelope(r *auctionTaskRecord, book *SealedBidBook) ([]byte, error) {
+	return json.Marshal(struct {
+		Version uint32       `json:"version"`
+		Kind    string       `json:"kind"`
+		Auction *AuctionTask `json:"auction"`
+	}{
+		Version: EnvelopeVersionV1,
+		Kind:    KindAuctionSettlement,
+		Auction: &AuctionTask{
+			AuctionId:      r.AuctionId,
+			PoolId:         r.PoolId.Hex(),
+			OracleUpdateId: r.OracleUpdateId.Hex(),
+			AppId:          common.HexToHash(c.cfg.AppId).Hex(),
+			ImageDigest:    common.HexToHash(c.cfg.ImageDigest).Hex(),
+			// The first task for the auction; tasks reposted by hand take higher nonces.
+			SubmissionNonce: 1,
+			AuctionService:  c.auctionService.Hex(),
+			SealedBids:      book,
+		},
+	})
+}
+
+func auctionTaskKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+// load reads the auction records from the state database.
+func (c *taskCreator) load() error {
+	return c.tw.stateDB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(auctionTasksBucket))
+		if err != nil {
+			return err
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var r auctionTaskRecord
+			if err := json.Unmarshal(v, &r); err != nil {
+				return fmt.Errorf("auction task %x: %w", k, err)
+			}
+			c.auctions[r.AuctionId] = &r
+			return nil
+		})
+	})
+}
+
+func (c *taskCreator) save(r *auctionTaskRecord) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return c.tw.stateDB.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(auctionTasksBucket)).Put(auctionTaskKey(r.AuctionId), v)
+	})
+}
+
+func (c *taskCreator) remove(id uint64) error {
+	return c.tw.stateDB.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(auctionTasksBucket)).Delete(auctionTaskKey(id))
+	})
+}
+
+// httpBidBooks reads sealed-bid books from a bid relay: a GET of the configured URL, with
+// {auction_id} replaced by the decimal auction id, returns the book as JSON.
+type httpBidBooks struct {
+	url    string
+	client *http.Client
+}
+
+func (b *httpBidBooks) SealedBids(ctx context.Context, auctionId uint64) (*SealedBidBook, error) {
+	u := strings.ReplaceAll(b.url, "{auction_id}", strconv.FormatUint(auctionId, 10))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bid relay: %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBidBookSize))
+	if err != nil {
+		return nil, err
+	}
+	var book SealedBidBook
+	if err := decodeStrict(bytes.TrimSpace(body), &book); err != nil {
+		return nil, fmt.Errorf("bid relay: %w", err)
+	}
+	return &book, nil
+}
+
+// mailboxSubmitter posts tasks with TaskMailbox.createTask through a submitter queue,
+// which saves each transaction before it is sent and bumps it until it is mined.
+type mailboxSubmitter struct {
+	client  *rpcpool.Pool
+	address common.Address
+	mailbox *taskmailbox.TaskMailbox
+	abi     *abi.ABI
+	txs     *submitter.Submitter
+	params  taskmailbox.ITaskMailboxTypesTaskParams
+}
+
+func (tw *TaskWorker) newMailboxSubmitter(ctx context.Context) (*mailboxSubmitter, error) {
+	cfg := tw.config.TaskCreator
+	mailbox, err := tw.TaskMailbox()
+	if err != nil {
+		return nil, err
+	}
+	address, err := tw.resolveContract(ContractTaskMailbox)
+	if err != nil {
+		return nil, err
+	}
+	mailboxABI, err := taskmailbox.TaskMailboxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("task creator private key: %w", err)
+	}
+	chainID, err := tw.l1Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("L1 chain id: %w", err)
+	}
+	if err := tw.requireState(); err != nil {
+		return nil, err
+	}
+	store, err := submitter.NewBoltStore(tw.stateDB)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := submitter.New(tw.l1Client, store, submitter.Config{
+		Key:     key,
+		ChainID: chainID,
+		OnUpdate: func(tx submitter.Tx) {
+			tw.logger.Info("Task transaction updated",
+				zap.String("key", tx.ID),
+				zap.String("status", string(tx.Status)),
+				zap.Int("attempts", len(tx.Attempts)),
+				zap.String("error", tx.Error),
+			)
+		},
+		OnError: func(err error) {
+			tw.logger.Warn("Task transaction sync failed", zap.Error(err))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	refund := txs.From()
+	if cfg.RefundCollector != "" {
+		refund = common.HexToAddress(cfg.RefundCollector)
+	}
+	return &mailboxSubmitter{
+		client:  tw.l1Client,
+		address: address,
+		mailbox: mailbox,
+		abi:     mailboxABI,
+		txs:     txs,
+		params: taskmailbox.ITaskMailboxTypesTaskParams{
+			RefundCollector:     refund,
+			ExecutorOperatorSet: taskmailbox.OperatorSet{Avs: common.HexToAddress(cfg.AVS), Id: cfg.OperatorSetId},
+		},
+	}, nil
+}
+
+func (m *mailboxSubmitter) CreateTask(key string, payload []byte) error {
+	params := m.params
+	params.Payload = payload
+	data, err := m.abi.Pack("createTask", params)
+	if err != nil {
+		return fmt.Errorf("pack createTask: %w", err)
+	}
+	_, err = m.txs.Enqueue(submitter.Request{ID: key, To: m.address, Data: data})
+	return err
+}
+
+func (m *mailboxSubmitter) TaskHash(ctx context.Context, key string) (common.Hash, error) {
+	tx, ok := m.txs.Get(key)
+	switch {
+	case !ok:
+		return common.Hash{}, errTaskUnknown
+	case tx.Status == submitter.StatusFailed || tx.Status == submitter.StatusReverted:
+		return common.Hash{}, fmt.Errorf("%w: %s %s", errTaskFailed, tx.Status, tx.Error)
+	case tx.Mined == nil || !tx.Mined.Success:
+		// A reverted transaction is failed only once its block is final.
+		return common.Hash{}, errTaskPending
+	}
+	receipt, err := m.client.TransactionReceipt(ctx, tx.Mined.TxHash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("createTask %s receipt: %w", tx.Mined.TxHash.Hex(), err)
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != m.address {
+			continue
+		}
+		if ev, err := m.mailbox.ParseTaskCreated(*l); err == nil {
+			return ev.TaskHash, nil
+		}
+	}
+	return common.Hash{}, fmt.Errorf("createTask %s: no TaskCreated event", tx.Mined.TxHash.Hex())
+}
+
+// runTaskCreator implements `performer task-creator [flags]`. It follows the protocol
+// events like the performer's event listener and posts an auction_settlement task to the
+// TaskMailbox for every closed auction. Run one creator per AVS: each posts its own task.
+func runTaskCreator(args []string) error {
+	cfg, _, err := loadConfigArgs("task-creator", args)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := cfg.TaskCreator.validate(); err != nil {
+		return fmt.Errorf("task_creator: %w", err)
+	}
+	l, err := cfg.newLogger()
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	defer l.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	cfg.stateFile = taskCreatorStateDBFile
+	w := NewTaskWorkerWithConfig(l, cfg)
+	defer w.Close()
+	if err := w.requireState(); err != nil {
+		return err
+	}
+	if err := w.requireL1Client(); err != nil {
+		return err
+	}
+	submitter, err := w.newMailboxSubmitter(ctx)
+	if err != nil {
+		return err
+	}
+	bids := &httpBidBooks{url: cfg.TaskCreator.BidsURL, client: &http.Client{Timeout: 30 * time.Second}}
+	creator, err := newTaskCreator(w, bids, submitter)
+	if err != nil {
+		return err
+	}
+	w.SubscribeEvents(creator)
+	listener, err := w.newEventListener()
+	if err != nil {
+		return err
+	}
+
+	l.Info("Creating auction tasks",
+		zap.String("task_mailbox", submitter.address.Hex()),
+		zap.String("avs", cfg.TaskCreator.AVS),
+		zap.Uint32("operator_set_id", cfg.TaskCreator.OperatorSetId),
+		zap.String("pool", cfg.TaskCreator.Pools[0]),
+	)
+	go submitter.txs.Run(ctx)
+	go creator.run(ctx)
+	if err := listener.Run(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("event listener: %w", err)
+	}
+	return nil
+}
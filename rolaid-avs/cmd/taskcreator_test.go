@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/lvrauctionhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/auction"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// testBidBooks serves a one-bid sealed book, signed for auctionService, for every auction.
+type testBidBooks struct {
+	auctionService common.Address
+}
+
+func (b testBidBooks) SealedBids(ctx context.Context, auctionId uint64) (*SealedBidBook, error) {
+	bidder := newTestBidder(2)
+	bid := auction.Bid{Bidder: bidder.address(), Amount: big.NewInt(1000), SettlementData: []byte("bob")}
+	salt := common.HexToHash(testBytes32A)
+	return &SealedBidBook{
+		ChainId:   testChainId,
+		StartTime: 100, RevealStart: 150, EndTime: 200,
+		Commits: []SealedBidCommit{bidder.commit(b.auctionService, auctionId, bid, salt, 110)},
+		Reveals: []SealedBidReveal{bidder.reveal(b.auctionService, auctionId, bid, salt, 160)},
+	}, nil
+}
+
+// recordingSubmitter keeps the task payloads it was given and mines each on the next
+// TaskHash, failing the keys in fail.
+type recordingSubmitter struct {
+	payloads [][]byte
+	keys     map[string]int
+	fail     map[string]bool
+}
+
+func (s *recordingSubmitter) CreateTask(key string, payload []byte) error {
+	if s.keys == nil {
+		s.keys = make(map[string]int)
+	}
+	if _, ok := s.keys[key]; !ok {
+		s.keys[key] = len(s.payloads)
+		s.payloads = append(s.payloads, payload)
+	}
+	return nil
+}
+
+func (s *recordingSubmitter) TaskHash(ctx context.Context, key string) (common.Hash, error) {
+	i, ok := s.keys[key]
+	switch {
+	case !ok:
+		return common.Hash{}, errTaskUnknown
+	case s.fail[key]:
+		return common.Hash{}, errTaskFailed
+	}
+	return crypto.Keccak256Hash(s.payloads[i]), nil
+}
+
+func newTestTaskCreator(t *testing.T, tw *TaskWorker, sub *recordingSubmitter, now uint64) *taskCreator {
+	t.Helper()
+	auctionService, err := tw.resolveContract(ContractAuctionService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newTaskCreator(tw, testBidBooks{auctionService: auctionService}, sub)
+	if err != nil {
+		t.Fatalf("newTaskCreator: %v", err)
+	}
+	c.now = func() time.Time { return time.Unix(int64(now), 0) }
+	return c
+}
+
+func auctionCreated(id uint64, oracleUpdate common.Hash, block uint64) *ProtocolEvent {
+	return &ProtocolEvent{
+		Contract: ContractAuctionService,
+		Name:     EventAuctionCreated,
+		Log:      types.Log{BlockNumber: block},
+		Data: &auctionservice.AuctionServiceAuctionCreated{
+			Id: new(big.Int).SetUint64(id), OracleUpdateId: oracleUpdate, StartTime: 100, EndTime: 200,
+		},
+	}
+}
+
+func hookEvent(name string, data interface{}, block uint64) *ProtocolEvent {
+	return &ProtocolEvent{Contract: ContractLVRAuctionHook, Name: name, Log: types.Log{BlockNumber: block}, Data: data}
+}
+
+func Test_TaskCreator(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.DataDir = t.TempDir()
+	cfg.Contracts[ContractAuctionService] = testServiceAddress.Hex()
+	cfg.TaskCreator.AppId = testBytes32A
+	cfg.TaskCreator.ImageDigest = testBytes32B
+	cfg.TaskCreator.Pools = []string{testBytes32A}
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+	sub := &recordingSubmitter{}
+	c := newTestTaskCreator(t, tw, sub, 150)
+	ctx := context.Background()
+
+	pool := common.HexToHash(testBytes32A)
+	oracleUpdate := common.HexToHash(testBytes32B)
+	otherUpdate := common.HexToHash("0x33")
+	for _, ev := range []*ProtocolEvent{
+		auctionCreated(7, oracleUpdate, 10),
+		// Hook activity on another pool says nothing about the auction.
+		hookEvent(EventSwapObserved, &lvrauctionhook.LVRAuctionHookSwapObserved{PoolId: common.HexToHash("0x44"), Delta: big.NewInt(1)}, 11),
+		auctionCreated(7, oracleUpdate, 10), // redelivered
+		// Settled by the hook authorizing a winner: no task.
+		auctionCreated(8, otherUpdate, 12),
+		hookEvent(EventAuctionAuthorized, &lvrauctionhook.LVRAuctionHookAuctionAuthorized{PoolId: pool, OracleUpdateId: otherUpdate}, 13),
+		auctionCreated(9, oracleUpdate, 20),
+	} {
+		if err := c.HandleEvent(ctx, ev); err != nil {
+			t.Fatalf("HandleEvent(%s): %v", ev.Name, err)
+		}
+	}
+	// Auction 9 is reorged out.
+	if err := c.RollbackEvents(ctx, 15); err != nil {
+		t.Fatalf("RollbackEvents: %v", err)
+	}
+
+	c.createDue(ctx)
+	if len(sub.payloads) != 0 {
+		t.Fatalf("posted %d tasks before the auction closed", len(sub.payloads))
+	}
+	c.now = func() time.Time { return time.Unix(200, 0) }
+	// The first post fails, so the task is posted again under the next key.
+	sub.fail = map[string]bool{"task-7-0": true}
+	for i := 0; i < 4; i++ {
+		c.createDue(ctx)
+	}
+	if len(sub.payloads) != 2 || c.auctions[7].Posts != 1 {
+		t.Fatalf("posted %d tasks in %d posts, want 2 in 1", len(sub.payloads), c.auctions[7].Posts)
+	}
+	sub.payloads = sub.payloads[1:]
+
+	env, _, err := tw.registry.Decode(sub.payloads[0])
+	if err != nil {
+		t.Fatalf("posted task does not decode: %v", err)
+	}
+	a := env.Payload.(*AuctionTask)
+	if env.Kind != KindAuctionSettlement || a.AuctionId != 7 || common.HexToHash(a.PoolId) != pool ||
+		common.HexToHash(a.OracleUpdateId) != oracleUpdate || common.HexToAddress(a.AuctionService) != testServiceAddress {
+		t.Fatalf("posted task = %s", sub.payloads[0])
+	}
+	resp, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: sub.payloads[0]})
+	if err != nil {
+		t.Fatalf("HandleTask on the posted task: %v", err)
+	}
+	if len(resp.Result) == 0 {
+		t.Fatal("empty result")
+	}
+
+	// The record survives a restart, so the task is not posted again.
+	c = newTestTaskCreator(t, tw, sub, 200)
+	if r := c.auctions[7]; r == nil || r.Posts != 1 || r.TaskHash != crypto.Keccak256Hash(sub.payloads[0]) {
+		t.Fatalf("auction 7 after restart = %+v", r)
+	}
+	c.createDue(ctx)
+	if len(sub.payloads) != 1 {
+		t.Fatalf("posted %d tasks after restart, want 1", len(sub.payloads))
+	}
+}
+
+func Test_HTTPBidBooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auctions/7/bids" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"chain_id": 31337, "start_time": 100, "reveal_start": 150, "end_time": 200, "commits": [], "reveals": []}`)
+	}))
+	defer srv.Close()
+	b := &httpBidBooks{url: srv.URL + "/auctions/{auction_id}/bids", client: srv.Client()}
+
+	book, err := b.SealedBids(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("SealedBids: %v", err)
+	}
+	if book.RevealStart != 150 || book.EndTime != 200 {
+		t.Fatalf("book = %+v", book)
+	}
+	if _, err := b.SealedBids(context.Background(), 8); err == nil {
+		t.Fatal("SealedBids accepted a 404")
+	}
+}
+
+func Test_TaskCreatorConfig(t *testing.T) {
+	t.Setenv("TASK_CREATOR_PRIVATE_KEY", "0x"+testBytes32A[2:])
+	t.Setenv("TASK_CREATOR_AVS", testAddress)
+	t.Setenv("TASK_CREATOR_OPERATOR_SET_ID", "2")
+	t.Setenv("TASK_CREATOR_POOLS", testBytes32A)
+	t.Setenv("TASK_CREATOR_BIDS_URL", "http://relay/auctions/{auction_id}/bids")
+	t.Setenv("APP_ID", testBytes32A)
+	t.Setenv("IMAGE_DIGEST", testBytes32B)
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	tc := cfg.TaskCreator
+	if tc.OperatorSetId != 2 || len(tc.Pools) != 1 || tc.PollInterval != defaultConfig().TaskCreator.PollInterval {
+		t.Fatalf("task creator config = %+v", tc)
+	}
+	if err := tc.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	// AuctionCreated does not name the pool, so the creator follows exactly one.
+	for _, pools := range [][]string{nil, {testBytes32A, testBytes32B}, {"0x1234"}} {
+		tc.Pools = pools
+		if err := tc.validate(); err == nil || !strings.Contains(err.Error(), "pools") {
+			t.Fatalf("validate(%v) = %v, want pools error", pools, err)
+		}
+	}
+}
+
+func Test_TaskCreatorOtherPool(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.DataDir = t.TempDir()
+	cfg.Contracts[ContractAuctionService] = testServiceAddress.Hex()
+	cfg.TaskCreator.AppId = testBytes32A
+	cfg.TaskCreator.ImageDigest = testBytes32B
+	cfg.TaskCreator.Pools = []string{testBytes32A}
+	tw := NewTaskWorkerWithConfig(zap.NewNop(), cfg)
+	defer tw.Close()
+	sub := &recordingSubmitter{}
+	c := newTestTaskCreator(t, tw, sub, 100)
+	ctx := context.Background()
+
+	// Another pool's authorization for the same oracle update id leaves the auction pending.
+	oracleUpdate := common.HexToHash("0x33")
+	for _, ev := range []*ProtocolEvent{
+		auctionCreated(7, oracleUpdate, 10),
+		hookEvent(EventAuctionAuthorized, &lvrauctionhook.LVRAuctionHookAuctionAuthorized{PoolId: common.HexToHash(testBytes32B), OracleUpdateId: oracleUpdate}, 11),
+	} {
+		if err := c.HandleEvent(ctx, ev); err != nil {
+			t.Fatalf("HandleEvent(%s): %v", ev.Name, err)
+		}
+	}
+	if r := c.auctions[7]; r.PoolId != common.HexToHash(testBytes32A) || !r.pending() {
+		t.Fatalf("auction = %+v", r)
+	}
+
+	tw.config.TaskCreator.Pools = []string{testBytes32A, testBytes32B}
+	if _, err := newTaskCreator(tw, testBidBooks{}, sub); err == nil {
+		t.Fatal("newTaskCreator accepted two pools")
+	}
+}
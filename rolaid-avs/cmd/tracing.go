@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Layr-Labs/hourglass-avs-template/cmd"
+
+// Span attribute keys shared across the task pipeline.
+const (
+	attrTaskId        = attribute.Key("task.id")
+	attrTaskKind      = attribute.Key("task.kind")
+	attrAuctionId     = attribute.Key("auction.id")
+	attrPolicyBatchId = attribute.Key("insurance.policy_batch_id")
+	attrChain         = attribute.Key("chain")
+	attrRPCMethod     = attribute.Key("rpc.method")
+	attrCallTo        = attribute.Key("eth.call.to")
+)
+
+// spanAttributer is optionally implemented by task handlers to tag spans with
+// identifiers from the decoded payload.
+type spanAttributer interface {
+	SpanAttributes(payload interface{}) []attribute.KeyValue
+}
+
+// tracer looks the tracer up on each use so the global provider can be swapped (tests).
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// setupTracing installs an OTLP/gRPC exporter as the global tracer provider. Tracing is
+// disabled (no-op provider) when no endpoint is configured. The returned function flushes
+// and stops the exporter.
+func setupTracing(ctx context.Context, cfg *Config) (func(context.Context) error, error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", cfg.ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, nil
+}
+
+// endSpan records err on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func taskIdAttr(taskId []byte) attribute.KeyValue {
+	return attrTaskId.String("0x" + hex.EncodeToString(taskId))
+}
+
+// dialTraced dials an RPC endpoint. For HTTP endpoints every JSON-RPC request gets a
+// client span, so contract calls made through bindings show up under the task span.
+func dialTraced(url, chain string) (*ethclient.Client, error) {
+	var opts []rpc.ClientOption
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		opts = append(opts, rpc.WithHTTPClient(&http.Client{
+			Transport: &rpcTracingTransport{base: http.DefaultTransport, chain: chain},
+		}))
+	}
+	c, err := rpc.DialOptions(context.Background(), url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(c), nil
+}
+
+// rpcTracingTransport starts a span per JSON-RPC request, named after the method.
+type rpcTracingTransport struct {
+	base  http.RoundTripper
+	chain string
+}
+
+func (t *rpcTracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	method, attrs := rpcSpanInfo(body)
+	ctx, span := tracer().Start(req.Context(), "rpc "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, attrChain.String(t.chain))...),
+	)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	endSpan(span, err)
+	return resp, err
+}
+
+// rpcSpanInfo extracts the method (or "batch") and call target from a JSON-RPC body.
+func rpcSpanInfo(body []byte) (string, []attribute.KeyValue) {
+	var msg struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		if len(bytes.TrimSpace(body)) > 0 && bytes.TrimSpace(body)[0] == '[' {
+			return "batch", nil
+		}
+		return "unknown", nil
+	}
+	attrs := []attribute.KeyValue{attrRPCMethod.String(msg.Method)}
+	if (msg.Method == "eth_call" || msg.Method == "eth_estimateGas") && len(msg.Params) > 0 {
+		var call struct {
+			To string `json:"to"`
+		}
+		if json.Unmarshal(msg.Params[0], &call) == nil && call.To != "" {
+			attrs = append(attrs, attrCallTo.String(call.To))
+		}
+	}
+	return msg.Method, attrs
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func spanAttr(s sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func Test_TaskSpans(t *testing.T) {
+	rec := recordSpans(t)
+	tw := NewTaskWorker(zap.NewNop())
+	defer tw.Close()
+
+	_, err := tw.HandleTask(&performerV1.TaskRequest{TaskId: []byte{0xab}, Payload: []byte(testAuctionEnvelope)})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		spans[s.Name()] = s
+	}
+	root, ok := spans["HandleTask"]
+	if !ok {
+		t.Fatalf("missing HandleTask span, got %v", spans)
+	}
+	for _, name := range []string{"decodeTaskEnvelope", KindAuctionSettlement + ".Handle"} {
+		s, ok := spans[name]
+		if !ok {
+			t.Fatalf("missing %s span", name)
+		}
+		if s.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Fatalf("%s is not a child of HandleTask", name)
+		}
+	}
+	if v, _ := spanAttr(root, attrTaskId); v.AsString() != "0xab" {
+		t.Fatalf("task.id = %q", v.AsString())
+	}
+	if v, _ := spanAttr(root, attrTaskKind); v.AsString() != KindAuctionSettlement {
+		t.Fatalf("task.kind = %q", v.AsString())
+	}
+	if v, _ := spanAttr(spans[KindAuctionSettlement+".Handle"], attrAuctionId); v.AsInt64() != 7 {
+		t.Fatalf("auction.id = %d", v.AsInt64())
+	}
+}
+
+func Test_RPCSpans(t *testing.T) {
+	rec := recordSpans(t)
+	rpc := fakeRPC(t, time.Now(), nil)
+	defer rpc.Close()
+
+	client, err := dialTraced(rpc.URL, "l1")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	if _, err := client.ChainID(ctx); err != nil {
+		t.Fatalf("chain id: %v", err)
+	}
+	parent.End()
+
+	for _, s := range rec.Ended() {
+		if s.Name() != "rpc eth_chainId" {
+			continue
+		}
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Fatal("rpc span is not a child of the caller span")
+		}
+		if v, _ := spanAttr(s, attrChain); v.AsString() != "l1" {
+			t.Fatalf("chain = %q", v.AsString())
+		}
+		return
+	}
+	t.Fatal("missing rpc eth_chainId span")
+}
@@ -0,0 +1,637 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package attestationregistry
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AttestationRegistryMetaData contains all meta data concerning the AttestationRegistry contract.
+var AttestationRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"error\",\"name\":\"NotOwner\",\"inputs\":[]},{\"type\":\"event\",\"name\":\"AppSet\",\"inputs\":[{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true},{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"function\",\"name\":\"apps\",\"inputs\":[{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"setApp\",\"inputs\":[{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"verify\",\"inputs\":[{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"}]",
+}
+
+// AttestationRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use AttestationRegistryMetaData.ABI instead.
+var AttestationRegistryABI = AttestationRegistryMetaData.ABI
+
+// AttestationRegistry is an auto generated Go binding around an Ethereum contract.
+type AttestationRegistry struct {
+	AttestationRegistryCaller     // Read-only binding to the contract
+	AttestationRegistryTransactor // Write-only binding to the contract
+	AttestationRegistryFilterer   // Log filterer for contract events
+}
+
+// AttestationRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AttestationRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AttestationRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AttestationRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AttestationRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AttestationRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AttestationRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AttestationRegistrySession struct {
+	Contract     *AttestationRegistry // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts        // Call options to use throughout this session
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// AttestationRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AttestationRegistryCallerSession struct {
+	Contract *AttestationRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts              // Call options to use throughout this session
+}
+
+// AttestationRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AttestationRegistryTransactorSession struct {
+	Contract     *AttestationRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts              // Transaction auth options to use throughout this session
+}
+
+// AttestationRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AttestationRegistryRaw struct {
+	Contract *AttestationRegistry // Generic contract binding to access the raw methods on
+}
+
+// AttestationRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AttestationRegistryCallerRaw struct {
+	Contract *AttestationRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AttestationRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AttestationRegistryTransactorRaw struct {
+	Contract *AttestationRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAttestationRegistry creates a new instance of AttestationRegistry, bound to a specific deployed contract.
+func NewAttestationRegistry(address common.Address, backend bind.ContractBackend) (*AttestationRegistry, error) {
+	contract, err := bindAttestationRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistry{AttestationRegistryCaller: AttestationRegistryCaller{contract: contract}, AttestationRegistryTransactor: AttestationRegistryTransactor{contract: contract}, AttestationRegistryFilterer: AttestationRegistryFilterer{contract: contract}}, nil
+}
+
+// NewAttestationRegistryCaller creates a new read-only instance of AttestationRegistry, bound to a specific deployed contract.
+func NewAttestationRegistryCaller(address common.Address, caller bind.ContractCaller) (*AttestationRegistryCaller, error) {
+	contract, err := bindAttestationRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistryCaller{contract: contract}, nil
+}
+
+// NewAttestationRegistryTransactor creates a new write-only instance of AttestationRegistry, bound to a specific deployed contract.
+func NewAttestationRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*AttestationRegistryTransactor, error) {
+	contract, err := bindAttestationRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistryTransactor{contract: contract}, nil
+}
+
+// NewAttestationRegistryFilterer creates a new log filterer instance of AttestationRegistry, bound to a specific deployed contract.
+func NewAttestationRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*AttestationRegistryFilterer, error) {
+	contract, err := bindAttestationRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistryFilterer{contract: contract}, nil
+}
+
+// bindAttestationRegistry binds a generic wrapper to an already deployed contract.
+func bindAttestationRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AttestationRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AttestationRegistry *AttestationRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AttestationRegistry.Contract.AttestationRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AttestationRegistry *AttestationRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.AttestationRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AttestationRegistry *AttestationRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.AttestationRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AttestationRegistry *AttestationRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AttestationRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AttestationRegistry *AttestationRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AttestationRegistry *AttestationRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// Apps is a free data retrieval call binding the contract method 0x38bb6def.
+//
+// Solidity: function apps(bytes32 appId) view returns(bytes32 imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistryCaller) Apps(opts *bind.CallOpts, appId [32]byte) (struct {
+	ImageDigest [32]byte
+	Active      bool
+}, error) {
+	var out []interface{}
+	err := _AttestationRegistry.contract.Call(opts, &out, "apps", appId)
+
+	outstruct := new(struct {
+		ImageDigest [32]byte
+		Active      bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.ImageDigest = *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	outstruct.Active = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Apps is a free data retrieval call binding the contract method 0x38bb6def.
+//
+// Solidity: function apps(bytes32 appId) view returns(bytes32 imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistrySession) Apps(appId [32]byte) (struct {
+	ImageDigest [32]byte
+	Active      bool
+}, error) {
+	return _AttestationRegistry.Contract.Apps(&_AttestationRegistry.CallOpts, appId)
+}
+
+// Apps is a free data retrieval call binding the contract method 0x38bb6def.
+//
+// Solidity: function apps(bytes32 appId) view returns(bytes32 imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistryCallerSession) Apps(appId [32]byte) (struct {
+	ImageDigest [32]byte
+	Active      bool
+}, error) {
+	return _AttestationRegistry.Contract.Apps(&_AttestationRegistry.CallOpts, appId)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AttestationRegistry *AttestationRegistryCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AttestationRegistry.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AttestationRegistry *AttestationRegistrySession) Owner() (common.Address, error) {
+	return _AttestationRegistry.Contract.Owner(&_AttestationRegistry.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AttestationRegistry *AttestationRegistryCallerSession) Owner() (common.Address, error) {
+	return _AttestationRegistry.Contract.Owner(&_AttestationRegistry.CallOpts)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x4e8fee00.
+//
+// Solidity: function verify(bytes32 appId, bytes32 imageDigest) view returns(bool)
+func (_AttestationRegistry *AttestationRegistryCaller) Verify(opts *bind.CallOpts, appId [32]byte, imageDigest [32]byte) (bool, error) {
+	var out []interface{}
+	err := _AttestationRegistry.contract.Call(opts, &out, "verify", appId, imageDigest)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Verify is a free data retrieval call binding the contract method 0x4e8fee00.
+//
+// Solidity: function verify(bytes32 appId, bytes32 imageDigest) view returns(bool)
+func (_AttestationRegistry *AttestationRegistrySession) Verify(appId [32]byte, imageDigest [32]byte) (bool, error) {
+	return _AttestationRegistry.Contract.Verify(&_AttestationRegistry.CallOpts, appId, imageDigest)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x4e8fee00.
+//
+// Solidity: function verify(bytes32 appId, bytes32 imageDigest) view returns(bool)
+func (_AttestationRegistry *AttestationRegistryCallerSession) Verify(appId [32]byte, imageDigest [32]byte) (bool, error) {
+	return _AttestationRegistry.Contract.Verify(&_AttestationRegistry.CallOpts, appId, imageDigest)
+}
+
+// SetApp is a paid mutator transaction binding the contract method 0xd20c3216.
+//
+// Solidity: function setApp(bytes32 appId, bytes32 imageDigest, bool active) returns()
+func (_AttestationRegistry *AttestationRegistryTransactor) SetApp(opts *bind.TransactOpts, appId [32]byte, imageDigest [32]byte, active bool) (*types.Transaction, error) {
+	return _AttestationRegistry.contract.Transact(opts, "setApp", appId, imageDigest, active)
+}
+
+// SetApp is a paid mutator transaction binding the contract method 0xd20c3216.
+//
+// Solidity: function setApp(bytes32 appId, bytes32 imageDigest, bool active) returns()
+func (_AttestationRegistry *AttestationRegistrySession) SetApp(appId [32]byte, imageDigest [32]byte, active bool) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.SetApp(&_AttestationRegistry.TransactOpts, appId, imageDigest, active)
+}
+
+// SetApp is a paid mutator transaction binding the contract method 0xd20c3216.
+//
+// Solidity: function setApp(bytes32 appId, bytes32 imageDigest, bool active) returns()
+func (_AttestationRegistry *AttestationRegistryTransactorSession) SetApp(appId [32]byte, imageDigest [32]byte, active bool) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.SetApp(&_AttestationRegistry.TransactOpts, appId, imageDigest, active)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AttestationRegistry *AttestationRegistryTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _AttestationRegistry.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AttestationRegistry *AttestationRegistrySession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.TransferOwnership(&_AttestationRegistry.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AttestationRegistry *AttestationRegistryTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AttestationRegistry.Contract.TransferOwnership(&_AttestationRegistry.TransactOpts, newOwner)
+}
+
+// AttestationRegistryAppSetIterator is returned from FilterAppSet and is used to iterate over the raw logs and unpacked data for AppSet events raised by the AttestationRegistry contract.
+type AttestationRegistryAppSetIterator struct {
+	Event *AttestationRegistryAppSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AttestationRegistryAppSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AttestationRegistryAppSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AttestationRegistryAppSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AttestationRegistryAppSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AttestationRegistryAppSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AttestationRegistryAppSet represents a AppSet event raised by the AttestationRegistry contract.
+type AttestationRegistryAppSet struct {
+	AppId       [32]byte
+	ImageDigest [32]byte
+	Active      bool
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterAppSet is a free log retrieval operation binding the contract event 0x84d1a53cdf7427957cc1157b495f7672a2cbbe09c6fc7bf4ffae628e7a8f82d5.
+//
+// Solidity: event AppSet(bytes32 indexed appId, bytes32 indexed imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistryFilterer) FilterAppSet(opts *bind.FilterOpts, appId [][32]byte, imageDigest [][32]byte) (*AttestationRegistryAppSetIterator, error) {
+
+	var appIdRule []interface{}
+	for _, appIdItem := range appId {
+		appIdRule = append(appIdRule, appIdItem)
+	}
+	var imageDigestRule []interface{}
+	for _, imageDigestItem := range imageDigest {
+		imageDigestRule = append(imageDigestRule, imageDigestItem)
+	}
+
+	logs, sub, err := _AttestationRegistry.contract.FilterLogs(opts, "AppSet", appIdRule, imageDigestRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistryAppSetIterator{contract: _AttestationRegistry.contract, event: "AppSet", logs: logs, sub: sub}, nil
+}
+
+// WatchAppSet is a free log subscription operation binding the contract event 0x84d1a53cdf7427957cc1157b495f7672a2cbbe09c6fc7bf4ffae628e7a8f82d5.
+//
+// Solidity: event AppSet(bytes32 indexed appId, bytes32 indexed imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistryFilterer) WatchAppSet(opts *bind.WatchOpts, sink chan<- *AttestationRegistryAppSet, appId [][32]byte, imageDigest [][32]byte) (event.Subscription, error) {
+
+	var appIdRule []interface{}
+	for _, appIdItem := range appId {
+		appIdRule = append(appIdRule, appIdItem)
+	}
+	var imageDigestRule []interface{}
+	for _, imageDigestItem := range imageDigest {
+		imageDigestRule = append(imageDigestRule, imageDigestItem)
+	}
+
+	logs, sub, err := _AttestationRegistry.contract.WatchLogs(opts, "AppSet", appIdRule, imageDigestRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AttestationRegistryAppSet)
+				if err := _AttestationRegistry.contract.UnpackLog(event, "AppSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAppSet is a log parse operation binding the contract event 0x84d1a53cdf7427957cc1157b495f7672a2cbbe09c6fc7bf4ffae628e7a8f82d5.
+//
+// Solidity: event AppSet(bytes32 indexed appId, bytes32 indexed imageDigest, bool active)
+func (_AttestationRegistry *AttestationRegistryFilterer) ParseAppSet(log types.Log) (*AttestationRegistryAppSet, error) {
+	event := new(AttestationRegistryAppSet)
+	if err := _AttestationRegistry.contract.UnpackLog(event, "AppSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AttestationRegistryOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the AttestationRegistry contract.
+type AttestationRegistryOwnershipTransferredIterator struct {
+	Event *AttestationRegistryOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AttestationRegistryOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AttestationRegistryOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AttestationRegistryOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AttestationRegistryOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AttestationRegistryOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AttestationRegistryOwnershipTransferred represents a OwnershipTransferred event raised by the AttestationRegistry contract.
+type AttestationRegistryOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AttestationRegistry *AttestationRegistryFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*AttestationRegistryOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AttestationRegistry.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestationRegistryOwnershipTransferredIterator{contract: _AttestationRegistry.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AttestationRegistry *AttestationRegistryFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *AttestationRegistryOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AttestationRegistry.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AttestationRegistryOwnershipTransferred)
+				if err := _AttestationRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AttestationRegistry *AttestationRegistryFilterer) ParseOwnershipTransferred(log types.Log) (*AttestationRegistryOwnershipTransferred, error) {
+	event := new(AttestationRegistryOwnershipTransferred)
+	if err := _AttestationRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,955 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package auctionservice
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AuctionServiceMetaData contains all meta data concerning the AuctionService contract.
+var AuctionServiceMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_attestationRegistry\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_settlementVault\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"error\",\"name\":\"NotOwner\",\"inputs\":[]},{\"type\":\"event\",\"name\":\"AuctionCreated\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":true},{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true},{\"name\":\"startTime\",\"type\":\"uint64\",\"internalType\":\"uint64\",\"indexed\":false},{\"name\":\"endTime\",\"type\":\"uint64\",\"internalType\":\"uint64\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SettlementSubmitted\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":true},{\"name\":\"winner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"bidAmount\",\"type\":\"uint96\",\"internalType\":\"uint96\",\"indexed\":false},{\"name\":\"settlementHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":false},{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":false},{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"function\",\"name\":\"attestationRegistry\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractAttestationRegistry\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"auctionCount\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"auctions\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"startTime\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"endTime\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"winner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"bidAmount\",\"type\":\"uint96\",\"internalType\":\"uint96\"},{\"name\":\"settlementHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"settled\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"createAuction\",\"inputs\":[{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"startTime\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"endTime\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"setSubmissionGracePeriod\",\"inputs\":[{\"name\":\"grace\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"settlementVault\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractSettlementVault\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"submissionGracePeriod\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"submitSettlement\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"appId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"imageDigest\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"bidder\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"bidAmount\",\"type\":\"uint96\",\"internalType\":\"uint96\"},{\"name\":\"settlementData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+}
+
+// AuctionServiceABI is the input ABI used to generate the binding from.
+// Deprecated: Use AuctionServiceMetaData.ABI instead.
+var AuctionServiceABI = AuctionServiceMetaData.ABI
+
+// AuctionService is an auto generated Go binding around an Ethereum contract.
+type AuctionService struct {
+	AuctionServiceCaller     // Read-only binding to the contract
+	AuctionServiceTransactor // Write-only binding to the contract
+	AuctionServiceFilterer   // Log filterer for contract events
+}
+
+// AuctionServiceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AuctionServiceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuctionServiceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AuctionServiceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuctionServiceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AuctionServiceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuctionServiceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AuctionServiceSession struct {
+	Contract     *AuctionService   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// AuctionServiceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AuctionServiceCallerSession struct {
+	Contract *AuctionServiceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// AuctionServiceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AuctionServiceTransactorSession struct {
+	Contract     *AuctionServiceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// AuctionServiceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AuctionServiceRaw struct {
+	Contract *AuctionService // Generic contract binding to access the raw methods on
+}
+
+// AuctionServiceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AuctionServiceCallerRaw struct {
+	Contract *AuctionServiceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AuctionServiceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AuctionServiceTransactorRaw struct {
+	Contract *AuctionServiceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAuctionService creates a new instance of AuctionService, bound to a specific deployed contract.
+func NewAuctionService(address common.Address, backend bind.ContractBackend) (*AuctionService, error) {
+	contract, err := bindAuctionService(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionService{AuctionServiceCaller: AuctionServiceCaller{contract: contract}, AuctionServiceTransactor: AuctionServiceTransactor{contract: contract}, AuctionServiceFilterer: AuctionServiceFilterer{contract: contract}}, nil
+}
+
+// NewAuctionServiceCaller creates a new read-only instance of AuctionService, bound to a specific deployed contract.
+func NewAuctionServiceCaller(address common.Address, caller bind.ContractCaller) (*AuctionServiceCaller, error) {
+	contract, err := bindAuctionService(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceCaller{contract: contract}, nil
+}
+
+// NewAuctionServiceTransactor creates a new write-only instance of AuctionService, bound to a specific deployed contract.
+func NewAuctionServiceTransactor(address common.Address, transactor bind.ContractTransactor) (*AuctionServiceTransactor, error) {
+	contract, err := bindAuctionService(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceTransactor{contract: contract}, nil
+}
+
+// NewAuctionServiceFilterer creates a new log filterer instance of AuctionService, bound to a specific deployed contract.
+func NewAuctionServiceFilterer(address common.Address, filterer bind.ContractFilterer) (*AuctionServiceFilterer, error) {
+	contract, err := bindAuctionService(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceFilterer{contract: contract}, nil
+}
+
+// bindAuctionService binds a generic wrapper to an already deployed contract.
+func bindAuctionService(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AuctionServiceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AuctionService *AuctionServiceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AuctionService.Contract.AuctionServiceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AuctionService *AuctionServiceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AuctionService.Contract.AuctionServiceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AuctionService *AuctionServiceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AuctionService.Contract.AuctionServiceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AuctionService *AuctionServiceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AuctionService.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AuctionService *AuctionServiceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AuctionService.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AuctionService *AuctionServiceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AuctionService.Contract.contract.Transact(opts, method, params...)
+}
+
+// AttestationRegistry is a free data retrieval call binding the contract method 0xed6d73f9.
+//
+// Solidity: function attestationRegistry() view returns(address)
+func (_AuctionService *AuctionServiceCaller) AttestationRegistry(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "attestationRegistry")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AttestationRegistry is a free data retrieval call binding the contract method 0xed6d73f9.
+//
+// Solidity: function attestationRegistry() view returns(address)
+func (_AuctionService *AuctionServiceSession) AttestationRegistry() (common.Address, error) {
+	return _AuctionService.Contract.AttestationRegistry(&_AuctionService.CallOpts)
+}
+
+// AttestationRegistry is a free data retrieval call binding the contract method 0xed6d73f9.
+//
+// Solidity: function attestationRegistry() view returns(address)
+func (_AuctionService *AuctionServiceCallerSession) AttestationRegistry() (common.Address, error) {
+	return _AuctionService.Contract.AttestationRegistry(&_AuctionService.CallOpts)
+}
+
+// AuctionCount is a free data retrieval call binding the contract method 0x2ad71573.
+//
+// Solidity: function auctionCount() view returns(uint256)
+func (_AuctionService *AuctionServiceCaller) AuctionCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "auctionCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AuctionCount is a free data retrieval call binding the contract method 0x2ad71573.
+//
+// Solidity: function auctionCount() view returns(uint256)
+func (_AuctionService *AuctionServiceSession) AuctionCount() (*big.Int, error) {
+	return _AuctionService.Contract.AuctionCount(&_AuctionService.CallOpts)
+}
+
+// AuctionCount is a free data retrieval call binding the contract method 0x2ad71573.
+//
+// Solidity: function auctionCount() view returns(uint256)
+func (_AuctionService *AuctionServiceCallerSession) AuctionCount() (*big.Int, error) {
+	return _AuctionService.Contract.AuctionCount(&_AuctionService.CallOpts)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 id) view returns(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime, address winner, uint96 bidAmount, bytes32 settlementHash, bool settled)
+func (_AuctionService *AuctionServiceCaller) Auctions(opts *bind.CallOpts, id *big.Int) (struct {
+	OracleUpdateId [32]byte
+	StartTime      uint64
+	EndTime        uint64
+	Winner         common.Address
+	BidAmount      *big.Int
+	SettlementHash [32]byte
+	Settled        bool
+}, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "auctions", id)
+
+	outstruct := new(struct {
+		OracleUpdateId [32]byte
+		StartTime      uint64
+		EndTime        uint64
+		Winner         common.Address
+		BidAmount      *big.Int
+		SettlementHash [32]byte
+		Settled        bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.OracleUpdateId = *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	outstruct.StartTime = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.EndTime = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+	outstruct.Winner = *abi.ConvertType(out[3], new(common.Address)).(*common.Address)
+	outstruct.BidAmount = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.SettlementHash = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Settled = *abi.ConvertType(out[6], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 id) view returns(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime, address winner, uint96 bidAmount, bytes32 settlementHash, bool settled)
+func (_AuctionService *AuctionServiceSession) Auctions(id *big.Int) (struct {
+	OracleUpdateId [32]byte
+	StartTime      uint64
+	EndTime        uint64
+	Winner         common.Address
+	BidAmount      *big.Int
+	SettlementHash [32]byte
+	Settled        bool
+}, error) {
+	return _AuctionService.Contract.Auctions(&_AuctionService.CallOpts, id)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 id) view returns(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime, address winner, uint96 bidAmount, bytes32 settlementHash, bool settled)
+func (_AuctionService *AuctionServiceCallerSession) Auctions(id *big.Int) (struct {
+	OracleUpdateId [32]byte
+	StartTime      uint64
+	EndTime        uint64
+	Winner         common.Address
+	BidAmount      *big.Int
+	SettlementHash [32]byte
+	Settled        bool
+}, error) {
+	return _AuctionService.Contract.Auctions(&_AuctionService.CallOpts, id)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AuctionService *AuctionServiceCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AuctionService *AuctionServiceSession) Owner() (common.Address, error) {
+	return _AuctionService.Contract.Owner(&_AuctionService.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AuctionService *AuctionServiceCallerSession) Owner() (common.Address, error) {
+	return _AuctionService.Contract.Owner(&_AuctionService.CallOpts)
+}
+
+// SettlementVault is a free data retrieval call binding the contract method 0x2aa84ce6.
+//
+// Solidity: function settlementVault() view returns(address)
+func (_AuctionService *AuctionServiceCaller) SettlementVault(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "settlementVault")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// SettlementVault is a free data retrieval call binding the contract method 0x2aa84ce6.
+//
+// Solidity: function settlementVault() view returns(address)
+func (_AuctionService *AuctionServiceSession) SettlementVault() (common.Address, error) {
+	return _AuctionService.Contract.SettlementVault(&_AuctionService.CallOpts)
+}
+
+// SettlementVault is a free data retrieval call binding the contract method 0x2aa84ce6.
+//
+// Solidity: function settlementVault() view returns(address)
+func (_AuctionService *AuctionServiceCallerSession) SettlementVault() (common.Address, error) {
+	return _AuctionService.Contract.SettlementVault(&_AuctionService.CallOpts)
+}
+
+// SubmissionGracePeriod is a free data retrieval call binding the contract method 0x19f8932b.
+//
+// Solidity: function submissionGracePeriod() view returns(uint64)
+func (_AuctionService *AuctionServiceCaller) SubmissionGracePeriod(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _AuctionService.contract.Call(opts, &out, "submissionGracePeriod")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// SubmissionGracePeriod is a free data retrieval call binding the contract method 0x19f8932b.
+//
+// Solidity: function submissionGracePeriod() view returns(uint64)
+func (_AuctionService *AuctionServiceSession) SubmissionGracePeriod() (uint64, error) {
+	return _AuctionService.Contract.SubmissionGracePeriod(&_AuctionService.CallOpts)
+}
+
+// SubmissionGracePeriod is a free data retrieval call binding the contract method 0x19f8932b.
+//
+// Solidity: function submissionGracePeriod() view returns(uint64)
+func (_AuctionService *AuctionServiceCallerSession) SubmissionGracePeriod() (uint64, error) {
+	return _AuctionService.Contract.SubmissionGracePeriod(&_AuctionService.CallOpts)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xcbb776e6.
+//
+// Solidity: function createAuction(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime) returns(uint256)
+func (_AuctionService *AuctionServiceTransactor) CreateAuction(opts *bind.TransactOpts, oracleUpdateId [32]byte, startTime uint64, endTime uint64) (*types.Transaction, error) {
+	return _AuctionService.contract.Transact(opts, "createAuction", oracleUpdateId, startTime, endTime)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xcbb776e6.
+//
+// Solidity: function createAuction(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime) returns(uint256)
+func (_AuctionService *AuctionServiceSession) CreateAuction(oracleUpdateId [32]byte, startTime uint64, endTime uint64) (*types.Transaction, error) {
+	return _AuctionService.Contract.CreateAuction(&_AuctionService.TransactOpts, oracleUpdateId, startTime, endTime)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xcbb776e6.
+//
+// Solidity: function createAuction(bytes32 oracleUpdateId, uint64 startTime, uint64 endTime) returns(uint256)
+func (_AuctionService *AuctionServiceTransactorSession) CreateAuction(oracleUpdateId [32]byte, startTime uint64, endTime uint64) (*types.Transaction, error) {
+	return _AuctionService.Contract.CreateAuction(&_AuctionService.TransactOpts, oracleUpdateId, startTime, endTime)
+}
+
+// SetSubmissionGracePeriod is a paid mutator transaction binding the contract method 0xbb5a1cd5.
+//
+// Solidity: function setSubmissionGracePeriod(uint64 grace) returns()
+func (_AuctionService *AuctionServiceTransactor) SetSubmissionGracePeriod(opts *bind.TransactOpts, grace uint64) (*types.Transaction, error) {
+	return _AuctionService.contract.Transact(opts, "setSubmissionGracePeriod", grace)
+}
+
+// SetSubmissionGracePeriod is a paid mutator transaction binding the contract method 0xbb5a1cd5.
+//
+// Solidity: function setSubmissionGracePeriod(uint64 grace) returns()
+func (_AuctionService *AuctionServiceSession) SetSubmissionGracePeriod(grace uint64) (*types.Transaction, error) {
+	return _AuctionService.Contract.SetSubmissionGracePeriod(&_AuctionService.TransactOpts, grace)
+}
+
+// SetSubmissionGracePeriod is a paid mutator transaction binding the contract method 0xbb5a1cd5.
+//
+// Solidity: function setSubmissionGracePeriod(uint64 grace) returns()
+func (_AuctionService *AuctionServiceTransactorSession) SetSubmissionGracePeriod(grace uint64) (*types.Transaction, error) {
+	return _AuctionService.Contract.SetSubmissionGracePeriod(&_AuctionService.TransactOpts, grace)
+}
+
+// SubmitSettlement is a paid mutator transaction binding the contract method 0x7b13361f.
+//
+// Solidity: function submitSettlement(uint256 id, bytes32 appId, bytes32 imageDigest, address bidder, uint96 bidAmount, bytes settlementData) payable returns()
+func (_AuctionService *AuctionServiceTransactor) SubmitSettlement(opts *bind.TransactOpts, id *big.Int, appId [32]byte, imageDigest [32]byte, bidder common.Address, bidAmount *big.Int, settlementData []byte) (*types.Transaction, error) {
+	return _AuctionService.contract.Transact(opts, "submitSettlement", id, appId, imageDigest, bidder, bidAmount, settlementData)
+}
+
+// SubmitSettlement is a paid mutator transaction binding the contract method 0x7b13361f.
+//
+// Solidity: function submitSettlement(uint256 id, bytes32 appId, bytes32 imageDigest, address bidder, uint96 bidAmount, bytes settlementData) payable returns()
+func (_AuctionService *AuctionServiceSession) SubmitSettlement(id *big.Int, appId [32]byte, imageDigest [32]byte, bidder common.Address, bidAmount *big.Int, settlementData []byte) (*types.Transaction, error) {
+	return _AuctionService.Contract.SubmitSettlement(&_AuctionService.TransactOpts, id, appId, imageDigest, bidder, bidAmount, settlementData)
+}
+
+// SubmitSettlement is a paid mutator transaction binding the contract method 0x7b13361f.
+//
+// Solidity: function submitSettlement(uint256 id, bytes32 appId, bytes32 imageDigest, address bidder, uint96 bidAmount, bytes settlementData) payable returns()
+func (_AuctionService *AuctionServiceTransactorSession) SubmitSettlement(id *big.Int, appId [32]byte, imageDigest [32]byte, bidder common.Address, bidAmount *big.Int, settlementData []byte) (*types.Transaction, error) {
+	return _AuctionService.Contract.SubmitSettlement(&_AuctionService.TransactOpts, id, appId, imageDigest, bidder, bidAmount, settlementData)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AuctionService *AuctionServiceTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _AuctionService.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AuctionService *AuctionServiceSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AuctionService.Contract.TransferOwnership(&_AuctionService.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AuctionService *AuctionServiceTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AuctionService.Contract.TransferOwnership(&_AuctionService.TransactOpts, newOwner)
+}
+
+// AuctionServiceAuctionCreatedIterator is returned from FilterAuctionCreated and is used to iterate over the raw logs and unpacked data for AuctionCreated events raised by the AuctionService contract.
+type AuctionServiceAuctionCreatedIterator struct {
+	Event *AuctionServiceAuctionCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AuctionServiceAuctionCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AuctionServiceAuctionCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AuctionServiceAuctionCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AuctionServiceAuctionCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AuctionServiceAuctionCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AuctionServiceAuctionCreated represents a AuctionCreated event raised by the AuctionService contract.
+type AuctionServiceAuctionCreated struct {
+	Id             *big.Int
+	OracleUpdateId [32]byte
+	StartTime      uint64
+	EndTime        uint64
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionCreated is a free log retrieval operation binding the contract event 0xd495b46aaf6811d2ca7e087f22b0bbd487e87f6291f21b485f3e09a0b9289c68.
+//
+// Solidity: event AuctionCreated(uint256 indexed id, bytes32 indexed oracleUpdateId, uint64 startTime, uint64 endTime)
+func (_AuctionService *AuctionServiceFilterer) FilterAuctionCreated(opts *bind.FilterOpts, id []*big.Int, oracleUpdateId [][32]byte) (*AuctionServiceAuctionCreatedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var oracleUpdateIdRule []interface{}
+	for _, oracleUpdateIdItem := range oracleUpdateId {
+		oracleUpdateIdRule = append(oracleUpdateIdRule, oracleUpdateIdItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.FilterLogs(opts, "AuctionCreated", idRule, oracleUpdateIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceAuctionCreatedIterator{contract: _AuctionService.contract, event: "AuctionCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionCreated is a free log subscription operation binding the contract event 0xd495b46aaf6811d2ca7e087f22b0bbd487e87f6291f21b485f3e09a0b9289c68.
+//
+// Solidity: event AuctionCreated(uint256 indexed id, bytes32 indexed oracleUpdateId, uint64 startTime, uint64 endTime)
+func (_AuctionService *AuctionServiceFilterer) WatchAuctionCreated(opts *bind.WatchOpts, sink chan<- *AuctionServiceAuctionCreated, id []*big.Int, oracleUpdateId [][32]byte) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var oracleUpdateIdRule []interface{}
+	for _, oracleUpdateIdItem := range oracleUpdateId {
+		oracleUpdateIdRule = append(oracleUpdateIdRule, oracleUpdateIdItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.WatchLogs(opts, "AuctionCreated", idRule, oracleUpdateIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AuctionServiceAuctionCreated)
+				if err := _AuctionService.contract.UnpackLog(event, "AuctionCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionCreated is a log parse operation binding the contract event 0xd495b46aaf6811d2ca7e087f22b0bbd487e87f6291f21b485f3e09a0b9289c68.
+//
+// Solidity: event AuctionCreated(uint256 indexed id, bytes32 indexed oracleUpdateId, uint64 startTime, uint64 endTime)
+func (_AuctionService *AuctionServiceFilterer) ParseAuctionCreated(log types.Log) (*AuctionServiceAuctionCreated, error) {
+	event := new(AuctionServiceAuctionCreated)
+	if err := _AuctionService.contract.UnpackLog(event, "AuctionCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AuctionServiceOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the AuctionService contract.
+type AuctionServiceOwnershipTransferredIterator struct {
+	Event *AuctionServiceOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AuctionServiceOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AuctionServiceOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AuctionServiceOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AuctionServiceOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AuctionServiceOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AuctionServiceOwnershipTransferred represents a OwnershipTransferred event raised by the AuctionService contract.
+type AuctionServiceOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AuctionService *AuctionServiceFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*AuctionServiceOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceOwnershipTransferredIterator{contract: _AuctionService.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AuctionService *AuctionServiceFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *AuctionServiceOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AuctionServiceOwnershipTransferred)
+				if err := _AuctionService.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AuctionService *AuctionServiceFilterer) ParseOwnershipTransferred(log types.Log) (*AuctionServiceOwnershipTransferred, error) {
+	event := new(AuctionServiceOwnershipTransferred)
+	if err := _AuctionService.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AuctionServiceSettlementSubmittedIterator is returned from FilterSettlementSubmitted and is used to iterate over the raw logs and unpacked data for SettlementSubmitted events raised by the AuctionService contract.
+type AuctionServiceSettlementSubmittedIterator struct {
+	Event *AuctionServiceSettlementSubmitted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AuctionServiceSettlementSubmittedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AuctionServiceSettlementSubmitted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AuctionServiceSettlementSubmitted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AuctionServiceSettlementSubmittedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AuctionServiceSettlementSubmittedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AuctionServiceSettlementSubmitted represents a SettlementSubmitted event raised by the AuctionService contract.
+type AuctionServiceSettlementSubmitted struct {
+	Id             *big.Int
+	Winner         common.Address
+	BidAmount      *big.Int
+	SettlementHash [32]byte
+	AppId          [32]byte
+	ImageDigest    [32]byte
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterSettlementSubmitted is a free log retrieval operation binding the contract event 0x549e85095d1ecfc4c844c8617fac37ea8ff9bf75114243ef7f36d05bd6526c34.
+//
+// Solidity: event SettlementSubmitted(uint256 indexed id, address indexed winner, uint96 bidAmount, bytes32 settlementHash, bytes32 appId, bytes32 imageDigest)
+func (_AuctionService *AuctionServiceFilterer) FilterSettlementSubmitted(opts *bind.FilterOpts, id []*big.Int, winner []common.Address) (*AuctionServiceSettlementSubmittedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.FilterLogs(opts, "SettlementSubmitted", idRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AuctionServiceSettlementSubmittedIterator{contract: _AuctionService.contract, event: "SettlementSubmitted", logs: logs, sub: sub}, nil
+}
+
+// WatchSettlementSubmitted is a free log subscription operation binding the contract event 0x549e85095d1ecfc4c844c8617fac37ea8ff9bf75114243ef7f36d05bd6526c34.
+//
+// Solidity: event SettlementSubmitted(uint256 indexed id, address indexed winner, uint96 bidAmount, bytes32 settlementHash, bytes32 appId, bytes32 imageDigest)
+func (_AuctionService *AuctionServiceFilterer) WatchSettlementSubmitted(opts *bind.WatchOpts, sink chan<- *AuctionServiceSettlementSubmitted, id []*big.Int, winner []common.Address) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _AuctionService.contract.WatchLogs(opts, "SettlementSubmitted", idRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AuctionServiceSettlementSubmitted)
+				if err := _AuctionService.contract.UnpackLog(event, "SettlementSubmitted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSettlementSubmitted is a log parse operation binding the contract event 0x549e85095d1ecfc4c844c8617fac37ea8ff9bf75114243ef7f36d05bd6526c34.
+//
+// Solidity: event SettlementSubmitted(uint256 indexed id, address indexed winner, uint96 bidAmount, bytes32 settlementHash, bytes32 appId, bytes32 imageDigest)
+func (_AuctionService *AuctionServiceFilterer) ParseSettlementSubmitted(log types.Log) (*AuctionServiceSettlementSubmitted, error) {
+	event := new(AuctionServiceSettlementSubmitted)
+	if err := _AuctionService.contract.UnpackLog(event, "SettlementSubmitted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,1464 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package lvrauctionhook
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// HooksPermissions is an auto generated low-level Go binding around an user-defined struct.
+type HooksPermissions struct {
+	BeforeInitialize                bool
+	AfterInitialize                 bool
+	BeforeAddLiquidity              bool
+	AfterAddLiquidity               bool
+	BeforeRemoveLiquidity           bool
+	AfterRemoveLiquidity            bool
+	BeforeSwap                      bool
+	AfterSwap                       bool
+	BeforeDonate                    bool
+	AfterDonate                     bool
+	BeforeSwapReturnDelta           bool
+	AfterSwapReturnDelta            bool
+	AfterAddLiquidityReturnDelta    bool
+	AfterRemoveLiquidityReturnDelta bool
+}
+
+// ModifyLiquidityParams is an auto generated low-level Go binding around an user-defined struct.
+type ModifyLiquidityParams struct {
+	TickLower      *big.Int
+	TickUpper      *big.Int
+	LiquidityDelta *big.Int
+	Salt           [32]byte
+}
+
+// PoolKey is an auto generated low-level Go binding around an user-defined struct.
+type PoolKey struct {
+	Currency0   common.Address
+	Currency1   common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Hooks       common.Address
+}
+
+// SwapParams is an auto generated low-level Go binding around an user-defined struct.
+type SwapParams struct {
+	ZeroForOne        bool
+	AmountSpecified   *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// LVRAuctionHookMetaData contains all meta data concerning the LVRAuctionHook contract.
+var LVRAuctionHookMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_poolManager\",\"type\":\"address\",\"internalType\":\"contractIPoolManager\"},{\"name\":\"_auctionListener\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"access\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"PoolId\"}],\"outputs\":[{\"name\":\"winner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"afterAddLiquidity\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structModifyLiquidityParams\",\"components\":[{\"name\":\"tickLower\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"tickUpper\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"liquidityDelta\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"salt\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}]},{\"name\":\"delta\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"},{\"name\":\"feesAccrued\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"},{\"name\":\"\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"afterDonate\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"amount0\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount1\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"afterInitialize\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"sqrtPriceX96\",\"type\":\"uint160\",\"internalType\":\"uint160\"},{\"name\":\"tick\",\"type\":\"int24\",\"internalType\":\"int24\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"afterRemoveLiquidity\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structModifyLiquidityParams\",\"components\":[{\"name\":\"tickLower\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"tickUpper\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"liquidityDelta\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"salt\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}]},{\"name\":\"delta\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"},{\"name\":\"feesAccrued\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"},{\"name\":\"\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"afterSwap\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structSwapParams\",\"components\":[{\"name\":\"zeroForOne\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"amountSpecified\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"sqrtPriceLimitX96\",\"type\":\"uint160\",\"internalType\":\"uint160\"}]},{\"name\":\"delta\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\"},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"},{\"name\":\"\",\"type\":\"int128\",\"internalType\":\"int128\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"auctionListener\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"auctionService\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"authorizeAuction\",\"inputs\":[{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"winner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"beforeAddLiquidity\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structModifyLiquidityParams\",\"components\":[{\"name\":\"tickLower\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"tickUpper\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"liquidityDelta\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"salt\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}]},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"beforeDonate\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"amount0\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount1\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"beforeInitialize\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"sqrtPriceX96\",\"type\":\"uint160\",\"internalType\":\"uint160\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"beforeRemoveLiquidity\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structModifyLiquidityParams\",\"components\":[{\"name\":\"tickLower\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"tickUpper\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"liquidityDelta\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"salt\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}]},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"beforeSwap\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]},{\"name\":\"params\",\"type\":\"tuple\",\"internalType\":\"structSwapParams\",\"components\":[{\"name\":\"zeroForOne\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"amountSpecified\",\"type\":\"int256\",\"internalType\":\"int256\"},{\"name\":\"sqrtPriceLimitX96\",\"type\":\"uint160\",\"internalType\":\"uint160\"}]},{\"name\":\"hookData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"},{\"name\":\"\",\"type\":\"int256\",\"internalType\":\"BeforeSwapDelta\"},{\"name\":\"\",\"type\":\"uint24\",\"internalType\":\"uint24\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"getHookPermissions\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"tuple\",\"internalType\":\"structHooks.Permissions\",\"components\":[{\"name\":\"beforeInitialize\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterInitialize\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"beforeAddLiquidity\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterAddLiquidity\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"beforeRemoveLiquidity\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterRemoveLiquidity\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"beforeSwap\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterSwap\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"beforeDonate\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterDonate\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"beforeSwapReturnDelta\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterSwapReturnDelta\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterAddLiquidityReturnDelta\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"afterRemoveLiquidityReturnDelta\",\"type\":\"bool\",\"internalType\":\"bool\"}]}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"poolManager\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractIPoolManager\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"revokeAuction\",\"inputs\":[{\"name\":\"key\",\"type\":\"tuple\",\"internalType\":\"structPoolKey\",\"components\":[{\"name\":\"currency0\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"currency1\",\"type\":\"address\",\"internalType\":\"Currency\"},{\"name\":\"fee\",\"type\":\"uint24\",\"internalType\":\"uint24\"},{\"name\":\"tickSpacing\",\"type\":\"int24\",\"internalType\":\"int24\"},{\"name\":\"hooks\",\"type\":\"address\",\"internalType\":\"contractIHooks\"}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setAuctionService\",\"inputs\":[{\"name\":\"service\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"AuctionAuthorized\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true},{\"name\":\"winner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\",\"indexed\":false},{\"name\":\"oracleUpdateId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"AuctionRevoked\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"AuctionServiceSet\",\"inputs\":[{\"name\":\"auctionService\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SwapObserved\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":true},{\"name\":\"delta\",\"type\":\"int256\",\"internalType\":\"BalanceDelta\",\"indexed\":false},{\"name\":\"payloadHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"HookNotImplemented\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NotOwner\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NotPoolManager\",\"inputs\":[]}]",
+}
+
+// LVRAuctionHookABI is the input ABI used to generate the binding from.
+// Deprecated: Use LVRAuctionHookMetaData.ABI instead.
+var LVRAuctionHookABI = LVRAuctionHookMetaData.ABI
+
+// LVRAuctionHook is an auto generated Go binding around an Ethereum contract.
+type LVRAuctionHook struct {
+	LVRAuctionHookCaller     // Read-only binding to the contract
+	LVRAuctionHookTransactor // Write-only binding to the contract
+	LVRAuctionHookFilterer   // Log filterer for contract events
+}
+
+// LVRAuctionHookCaller is an auto generated read-only Go binding around an Ethereum contract.
+type LVRAuctionHookCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LVRAuctionHookTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type LVRAuctionHookTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LVRAuctionHookFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type LVRAuctionHookFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LVRAuctionHookSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type LVRAuctionHookSession struct {
+	Contract     *LVRAuctionHook   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// LVRAuctionHookCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type LVRAuctionHookCallerSession struct {
+	Contract *LVRAuctionHookCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// LVRAuctionHookTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type LVRAuctionHookTransactorSession struct {
+	Contract     *LVRAuctionHookTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// LVRAuctionHookRaw is an auto generated low-level Go binding around an Ethereum contract.
+type LVRAuctionHookRaw struct {
+	Contract *LVRAuctionHook // Generic contract binding to access the raw methods on
+}
+
+// LVRAuctionHookCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type LVRAuctionHookCallerRaw struct {
+	Contract *LVRAuctionHookCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// LVRAuctionHookTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type LVRAuctionHookTransactorRaw struct {
+	Contract *LVRAuctionHookTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewLVRAuctionHook creates a new instance of LVRAuctionHook, bound to a specific deployed contract.
+func NewLVRAuctionHook(address common.Address, backend bind.ContractBackend) (*LVRAuctionHook, error) {
+	contract, err := bindLVRAuctionHook(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHook{LVRAuctionHookCaller: LVRAuctionHookCaller{contract: contract}, LVRAuctionHookTransactor: LVRAuctionHookTransactor{contract: contract}, LVRAuctionHookFilterer: LVRAuctionHookFilterer{contract: contract}}, nil
+}
+
+// NewLVRAuctionHookCaller creates a new read-only instance of LVRAuctionHook, bound to a specific deployed contract.
+func NewLVRAuctionHookCaller(address common.Address, caller bind.ContractCaller) (*LVRAuctionHookCaller, error) {
+	contract, err := bindLVRAuctionHook(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookCaller{contract: contract}, nil
+}
+
+// NewLVRAuctionHookTransactor creates a new write-only instance of LVRAuctionHook, bound to a specific deployed contract.
+func NewLVRAuctionHookTransactor(address common.Address, transactor bind.ContractTransactor) (*LVRAuctionHookTransactor, error) {
+	contract, err := bindLVRAuctionHook(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookTransactor{contract: contract}, nil
+}
+
+// NewLVRAuctionHookFilterer creates a new log filterer instance of LVRAuctionHook, bound to a specific deployed contract.
+func NewLVRAuctionHookFilterer(address common.Address, filterer bind.ContractFilterer) (*LVRAuctionHookFilterer, error) {
+	contract, err := bindLVRAuctionHook(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookFilterer{contract: contract}, nil
+}
+
+// bindLVRAuctionHook binds a generic wrapper to an already deployed contract.
+func bindLVRAuctionHook(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := LVRAuctionHookMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LVRAuctionHook *LVRAuctionHookRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LVRAuctionHook.Contract.LVRAuctionHookCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LVRAuctionHook *LVRAuctionHookRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.LVRAuctionHookTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LVRAuctionHook *LVRAuctionHookRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.LVRAuctionHookTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LVRAuctionHook *LVRAuctionHookCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LVRAuctionHook.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LVRAuctionHook *LVRAuctionHookTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LVRAuctionHook *LVRAuctionHookTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.contract.Transact(opts, method, params...)
+}
+
+// Access is a free data retrieval call binding the contract method 0x6d43c4c9.
+//
+// Solidity: function access(bytes32 ) view returns(address winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookCaller) Access(opts *bind.CallOpts, arg0 [32]byte) (struct {
+	Winner         common.Address
+	Expiry         uint64
+	OracleUpdateId [32]byte
+}, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "access", arg0)
+
+	outstruct := new(struct {
+		Winner         common.Address
+		Expiry         uint64
+		OracleUpdateId [32]byte
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Winner = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Expiry = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.OracleUpdateId = *abi.ConvertType(out[2], new([32]byte)).(*[32]byte)
+
+	return *outstruct, err
+
+}
+
+// Access is a free data retrieval call binding the contract method 0x6d43c4c9.
+//
+// Solidity: function access(bytes32 ) view returns(address winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookSession) Access(arg0 [32]byte) (struct {
+	Winner         common.Address
+	Expiry         uint64
+	OracleUpdateId [32]byte
+}, error) {
+	return _LVRAuctionHook.Contract.Access(&_LVRAuctionHook.CallOpts, arg0)
+}
+
+// Access is a free data retrieval call binding the contract method 0x6d43c4c9.
+//
+// Solidity: function access(bytes32 ) view returns(address winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) Access(arg0 [32]byte) (struct {
+	Winner         common.Address
+	Expiry         uint64
+	OracleUpdateId [32]byte
+}, error) {
+	return _LVRAuctionHook.Contract.Access(&_LVRAuctionHook.CallOpts, arg0)
+}
+
+// AuctionListener is a free data retrieval call binding the contract method 0x0fc32b2b.
+//
+// Solidity: function auctionListener() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCaller) AuctionListener(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "auctionListener")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AuctionListener is a free data retrieval call binding the contract method 0x0fc32b2b.
+//
+// Solidity: function auctionListener() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookSession) AuctionListener() (common.Address, error) {
+	return _LVRAuctionHook.Contract.AuctionListener(&_LVRAuctionHook.CallOpts)
+}
+
+// AuctionListener is a free data retrieval call binding the contract method 0x0fc32b2b.
+//
+// Solidity: function auctionListener() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) AuctionListener() (common.Address, error) {
+	return _LVRAuctionHook.Contract.AuctionListener(&_LVRAuctionHook.CallOpts)
+}
+
+// AuctionService is a free data retrieval call binding the contract method 0x7aaa315c.
+//
+// Solidity: function auctionService() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCaller) AuctionService(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "auctionService")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AuctionService is a free data retrieval call binding the contract method 0x7aaa315c.
+//
+// Solidity: function auctionService() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookSession) AuctionService() (common.Address, error) {
+	return _LVRAuctionHook.Contract.AuctionService(&_LVRAuctionHook.CallOpts)
+}
+
+// AuctionService is a free data retrieval call binding the contract method 0x7aaa315c.
+//
+// Solidity: function auctionService() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) AuctionService() (common.Address, error) {
+	return _LVRAuctionHook.Contract.AuctionService(&_LVRAuctionHook.CallOpts)
+}
+
+// GetHookPermissions is a free data retrieval call binding the contract method 0xc4e833ce.
+//
+// Solidity: function getHookPermissions() pure returns((bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool))
+func (_LVRAuctionHook *LVRAuctionHookCaller) GetHookPermissions(opts *bind.CallOpts) (HooksPermissions, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "getHookPermissions")
+
+	if err != nil {
+		return *new(HooksPermissions), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(HooksPermissions)).(*HooksPermissions)
+
+	return out0, err
+
+}
+
+// GetHookPermissions is a free data retrieval call binding the contract method 0xc4e833ce.
+//
+// Solidity: function getHookPermissions() pure returns((bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool))
+func (_LVRAuctionHook *LVRAuctionHookSession) GetHookPermissions() (HooksPermissions, error) {
+	return _LVRAuctionHook.Contract.GetHookPermissions(&_LVRAuctionHook.CallOpts)
+}
+
+// GetHookPermissions is a free data retrieval call binding the contract method 0xc4e833ce.
+//
+// Solidity: function getHookPermissions() pure returns((bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool,bool))
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) GetHookPermissions() (HooksPermissions, error) {
+	return _LVRAuctionHook.Contract.GetHookPermissions(&_LVRAuctionHook.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookSession) Owner() (common.Address, error) {
+	return _LVRAuctionHook.Contract.Owner(&_LVRAuctionHook.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) Owner() (common.Address, error) {
+	return _LVRAuctionHook.Contract.Owner(&_LVRAuctionHook.CallOpts)
+}
+
+// PoolManager is a free data retrieval call binding the contract method 0xdc4c90d3.
+//
+// Solidity: function poolManager() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCaller) PoolManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LVRAuctionHook.contract.Call(opts, &out, "poolManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PoolManager is a free data retrieval call binding the contract method 0xdc4c90d3.
+//
+// Solidity: function poolManager() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookSession) PoolManager() (common.Address, error) {
+	return _LVRAuctionHook.Contract.PoolManager(&_LVRAuctionHook.CallOpts)
+}
+
+// PoolManager is a free data retrieval call binding the contract method 0xdc4c90d3.
+//
+// Solidity: function poolManager() view returns(address)
+func (_LVRAuctionHook *LVRAuctionHookCallerSession) PoolManager() (common.Address, error) {
+	return _LVRAuctionHook.Contract.PoolManager(&_LVRAuctionHook.CallOpts)
+}
+
+// AfterAddLiquidity is a paid mutator transaction binding the contract method 0x9f063efc.
+//
+// Solidity: function afterAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AfterAddLiquidity(opts *bind.TransactOpts, sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "afterAddLiquidity", sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterAddLiquidity is a paid mutator transaction binding the contract method 0x9f063efc.
+//
+// Solidity: function afterAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookSession) AfterAddLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterAddLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterAddLiquidity is a paid mutator transaction binding the contract method 0x9f063efc.
+//
+// Solidity: function afterAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AfterAddLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterAddLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterDonate is a paid mutator transaction binding the contract method 0xe1b4af69.
+//
+// Solidity: function afterDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AfterDonate(opts *bind.TransactOpts, sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "afterDonate", sender, key, amount0, amount1, hookData)
+}
+
+// AfterDonate is a paid mutator transaction binding the contract method 0xe1b4af69.
+//
+// Solidity: function afterDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) AfterDonate(sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterDonate(&_LVRAuctionHook.TransactOpts, sender, key, amount0, amount1, hookData)
+}
+
+// AfterDonate is a paid mutator transaction binding the contract method 0xe1b4af69.
+//
+// Solidity: function afterDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AfterDonate(sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterDonate(&_LVRAuctionHook.TransactOpts, sender, key, amount0, amount1, hookData)
+}
+
+// AfterInitialize is a paid mutator transaction binding the contract method 0x6fe7e6eb.
+//
+// Solidity: function afterInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96, int24 tick) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AfterInitialize(opts *bind.TransactOpts, sender common.Address, key PoolKey, sqrtPriceX96 *big.Int, tick *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "afterInitialize", sender, key, sqrtPriceX96, tick)
+}
+
+// AfterInitialize is a paid mutator transaction binding the contract method 0x6fe7e6eb.
+//
+// Solidity: function afterInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96, int24 tick) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) AfterInitialize(sender common.Address, key PoolKey, sqrtPriceX96 *big.Int, tick *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterInitialize(&_LVRAuctionHook.TransactOpts, sender, key, sqrtPriceX96, tick)
+}
+
+// AfterInitialize is a paid mutator transaction binding the contract method 0x6fe7e6eb.
+//
+// Solidity: function afterInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96, int24 tick) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AfterInitialize(sender common.Address, key PoolKey, sqrtPriceX96 *big.Int, tick *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterInitialize(&_LVRAuctionHook.TransactOpts, sender, key, sqrtPriceX96, tick)
+}
+
+// AfterRemoveLiquidity is a paid mutator transaction binding the contract method 0x6c2bbe7e.
+//
+// Solidity: function afterRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AfterRemoveLiquidity(opts *bind.TransactOpts, sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "afterRemoveLiquidity", sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterRemoveLiquidity is a paid mutator transaction binding the contract method 0x6c2bbe7e.
+//
+// Solidity: function afterRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookSession) AfterRemoveLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterRemoveLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterRemoveLiquidity is a paid mutator transaction binding the contract method 0x6c2bbe7e.
+//
+// Solidity: function afterRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, int256 delta, int256 feesAccrued, bytes hookData) returns(bytes4, int256)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AfterRemoveLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, delta *big.Int, feesAccrued *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterRemoveLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, feesAccrued, hookData)
+}
+
+// AfterSwap is a paid mutator transaction binding the contract method 0xb47b2fb1.
+//
+// Solidity: function afterSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, int256 delta, bytes hookData) returns(bytes4, int128)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AfterSwap(opts *bind.TransactOpts, sender common.Address, key PoolKey, params SwapParams, delta *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "afterSwap", sender, key, params, delta, hookData)
+}
+
+// AfterSwap is a paid mutator transaction binding the contract method 0xb47b2fb1.
+//
+// Solidity: function afterSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, int256 delta, bytes hookData) returns(bytes4, int128)
+func (_LVRAuctionHook *LVRAuctionHookSession) AfterSwap(sender common.Address, key PoolKey, params SwapParams, delta *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterSwap(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, hookData)
+}
+
+// AfterSwap is a paid mutator transaction binding the contract method 0xb47b2fb1.
+//
+// Solidity: function afterSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, int256 delta, bytes hookData) returns(bytes4, int128)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AfterSwap(sender common.Address, key PoolKey, params SwapParams, delta *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AfterSwap(&_LVRAuctionHook.TransactOpts, sender, key, params, delta, hookData)
+}
+
+// AuthorizeAuction is a paid mutator transaction binding the contract method 0x7e004021.
+//
+// Solidity: function authorizeAuction((address,address,uint24,int24,address) key, address winner, uint64 expiry, bytes32 oracleUpdateId) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactor) AuthorizeAuction(opts *bind.TransactOpts, key PoolKey, winner common.Address, expiry uint64, oracleUpdateId [32]byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "authorizeAuction", key, winner, expiry, oracleUpdateId)
+}
+
+// AuthorizeAuction is a paid mutator transaction binding the contract method 0x7e004021.
+//
+// Solidity: function authorizeAuction((address,address,uint24,int24,address) key, address winner, uint64 expiry, bytes32 oracleUpdateId) returns()
+func (_LVRAuctionHook *LVRAuctionHookSession) AuthorizeAuction(key PoolKey, winner common.Address, expiry uint64, oracleUpdateId [32]byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AuthorizeAuction(&_LVRAuctionHook.TransactOpts, key, winner, expiry, oracleUpdateId)
+}
+
+// AuthorizeAuction is a paid mutator transaction binding the contract method 0x7e004021.
+//
+// Solidity: function authorizeAuction((address,address,uint24,int24,address) key, address winner, uint64 expiry, bytes32 oracleUpdateId) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) AuthorizeAuction(key PoolKey, winner common.Address, expiry uint64, oracleUpdateId [32]byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.AuthorizeAuction(&_LVRAuctionHook.TransactOpts, key, winner, expiry, oracleUpdateId)
+}
+
+// BeforeAddLiquidity is a paid mutator transaction binding the contract method 0x259982e5.
+//
+// Solidity: function beforeAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) BeforeAddLiquidity(opts *bind.TransactOpts, sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "beforeAddLiquidity", sender, key, params, hookData)
+}
+
+// BeforeAddLiquidity is a paid mutator transaction binding the contract method 0x259982e5.
+//
+// Solidity: function beforeAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) BeforeAddLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeAddLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// BeforeAddLiquidity is a paid mutator transaction binding the contract method 0x259982e5.
+//
+// Solidity: function beforeAddLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) BeforeAddLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeAddLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// BeforeDonate is a paid mutator transaction binding the contract method 0xb6a8b0fa.
+//
+// Solidity: function beforeDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) BeforeDonate(opts *bind.TransactOpts, sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "beforeDonate", sender, key, amount0, amount1, hookData)
+}
+
+// BeforeDonate is a paid mutator transaction binding the contract method 0xb6a8b0fa.
+//
+// Solidity: function beforeDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) BeforeDonate(sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeDonate(&_LVRAuctionHook.TransactOpts, sender, key, amount0, amount1, hookData)
+}
+
+// BeforeDonate is a paid mutator transaction binding the contract method 0xb6a8b0fa.
+//
+// Solidity: function beforeDonate(address sender, (address,address,uint24,int24,address) key, uint256 amount0, uint256 amount1, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) BeforeDonate(sender common.Address, key PoolKey, amount0 *big.Int, amount1 *big.Int, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeDonate(&_LVRAuctionHook.TransactOpts, sender, key, amount0, amount1, hookData)
+}
+
+// BeforeInitialize is a paid mutator transaction binding the contract method 0xdc98354e.
+//
+// Solidity: function beforeInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) BeforeInitialize(opts *bind.TransactOpts, sender common.Address, key PoolKey, sqrtPriceX96 *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "beforeInitialize", sender, key, sqrtPriceX96)
+}
+
+// BeforeInitialize is a paid mutator transaction binding the contract method 0xdc98354e.
+//
+// Solidity: function beforeInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) BeforeInitialize(sender common.Address, key PoolKey, sqrtPriceX96 *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeInitialize(&_LVRAuctionHook.TransactOpts, sender, key, sqrtPriceX96)
+}
+
+// BeforeInitialize is a paid mutator transaction binding the contract method 0xdc98354e.
+//
+// Solidity: function beforeInitialize(address sender, (address,address,uint24,int24,address) key, uint160 sqrtPriceX96) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) BeforeInitialize(sender common.Address, key PoolKey, sqrtPriceX96 *big.Int) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeInitialize(&_LVRAuctionHook.TransactOpts, sender, key, sqrtPriceX96)
+}
+
+// BeforeRemoveLiquidity is a paid mutator transaction binding the contract method 0x21d0ee70.
+//
+// Solidity: function beforeRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) BeforeRemoveLiquidity(opts *bind.TransactOpts, sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "beforeRemoveLiquidity", sender, key, params, hookData)
+}
+
+// BeforeRemoveLiquidity is a paid mutator transaction binding the contract method 0x21d0ee70.
+//
+// Solidity: function beforeRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookSession) BeforeRemoveLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeRemoveLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// BeforeRemoveLiquidity is a paid mutator transaction binding the contract method 0x21d0ee70.
+//
+// Solidity: function beforeRemoveLiquidity(address sender, (address,address,uint24,int24,address) key, (int24,int24,int256,bytes32) params, bytes hookData) returns(bytes4)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) BeforeRemoveLiquidity(sender common.Address, key PoolKey, params ModifyLiquidityParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeRemoveLiquidity(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// BeforeSwap is a paid mutator transaction binding the contract method 0x575e24b4.
+//
+// Solidity: function beforeSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, bytes hookData) returns(bytes4, int256, uint24)
+func (_LVRAuctionHook *LVRAuctionHookTransactor) BeforeSwap(opts *bind.TransactOpts, sender common.Address, key PoolKey, params SwapParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "beforeSwap", sender, key, params, hookData)
+}
+
+// BeforeSwap is a paid mutator transaction binding the contract method 0x575e24b4.
+//
+// Solidity: function beforeSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, bytes hookData) returns(bytes4, int256, uint24)
+func (_LVRAuctionHook *LVRAuctionHookSession) BeforeSwap(sender common.Address, key PoolKey, params SwapParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeSwap(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// BeforeSwap is a paid mutator transaction binding the contract method 0x575e24b4.
+//
+// Solidity: function beforeSwap(address sender, (address,address,uint24,int24,address) key, (bool,int256,uint160) params, bytes hookData) returns(bytes4, int256, uint24)
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) BeforeSwap(sender common.Address, key PoolKey, params SwapParams, hookData []byte) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.BeforeSwap(&_LVRAuctionHook.TransactOpts, sender, key, params, hookData)
+}
+
+// RevokeAuction is a paid mutator transaction binding the contract method 0xb1808a8c.
+//
+// Solidity: function revokeAuction((address,address,uint24,int24,address) key) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactor) RevokeAuction(opts *bind.TransactOpts, key PoolKey) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "revokeAuction", key)
+}
+
+// RevokeAuction is a paid mutator transaction binding the contract method 0xb1808a8c.
+//
+// Solidity: function revokeAuction((address,address,uint24,int24,address) key) returns()
+func (_LVRAuctionHook *LVRAuctionHookSession) RevokeAuction(key PoolKey) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.RevokeAuction(&_LVRAuctionHook.TransactOpts, key)
+}
+
+// RevokeAuction is a paid mutator transaction binding the contract method 0xb1808a8c.
+//
+// Solidity: function revokeAuction((address,address,uint24,int24,address) key) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) RevokeAuction(key PoolKey) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.RevokeAuction(&_LVRAuctionHook.TransactOpts, key)
+}
+
+// SetAuctionService is a paid mutator transaction binding the contract method 0x4c5740d3.
+//
+// Solidity: function setAuctionService(address service) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactor) SetAuctionService(opts *bind.TransactOpts, service common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "setAuctionService", service)
+}
+
+// SetAuctionService is a paid mutator transaction binding the contract method 0x4c5740d3.
+//
+// Solidity: function setAuctionService(address service) returns()
+func (_LVRAuctionHook *LVRAuctionHookSession) SetAuctionService(service common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.SetAuctionService(&_LVRAuctionHook.TransactOpts, service)
+}
+
+// SetAuctionService is a paid mutator transaction binding the contract method 0x4c5740d3.
+//
+// Solidity: function setAuctionService(address service) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) SetAuctionService(service common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.SetAuctionService(&_LVRAuctionHook.TransactOpts, service)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LVRAuctionHook *LVRAuctionHookSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.TransferOwnership(&_LVRAuctionHook.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LVRAuctionHook *LVRAuctionHookTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LVRAuctionHook.Contract.TransferOwnership(&_LVRAuctionHook.TransactOpts, newOwner)
+}
+
+// LVRAuctionHookAuctionAuthorizedIterator is returned from FilterAuctionAuthorized and is used to iterate over the raw logs and unpacked data for AuctionAuthorized events raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionAuthorizedIterator struct {
+	Event *LVRAuctionHookAuctionAuthorized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LVRAuctionHookAuctionAuthorizedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LVRAuctionHookAuctionAuthorized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LVRAuctionHookAuctionAuthorized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LVRAuctionHookAuctionAuthorizedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LVRAuctionHookAuctionAuthorizedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LVRAuctionHookAuctionAuthorized represents a AuctionAuthorized event raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionAuthorized struct {
+	PoolId         [32]byte
+	Winner         common.Address
+	Expiry         uint64
+	OracleUpdateId [32]byte
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionAuthorized is a free log retrieval operation binding the contract event 0x45b1e1ee613251eed4f3bca17a4832b617b1e1ff141418fb45dc162c9b18b8d6.
+//
+// Solidity: event AuctionAuthorized(bytes32 indexed poolId, address indexed winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) FilterAuctionAuthorized(opts *bind.FilterOpts, poolId [][32]byte, winner []common.Address) (*LVRAuctionHookAuctionAuthorizedIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.FilterLogs(opts, "AuctionAuthorized", poolIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookAuctionAuthorizedIterator{contract: _LVRAuctionHook.contract, event: "AuctionAuthorized", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionAuthorized is a free log subscription operation binding the contract event 0x45b1e1ee613251eed4f3bca17a4832b617b1e1ff141418fb45dc162c9b18b8d6.
+//
+// Solidity: event AuctionAuthorized(bytes32 indexed poolId, address indexed winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) WatchAuctionAuthorized(opts *bind.WatchOpts, sink chan<- *LVRAuctionHookAuctionAuthorized, poolId [][32]byte, winner []common.Address) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.WatchLogs(opts, "AuctionAuthorized", poolIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LVRAuctionHookAuctionAuthorized)
+				if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionAuthorized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionAuthorized is a log parse operation binding the contract event 0x45b1e1ee613251eed4f3bca17a4832b617b1e1ff141418fb45dc162c9b18b8d6.
+//
+// Solidity: event AuctionAuthorized(bytes32 indexed poolId, address indexed winner, uint64 expiry, bytes32 oracleUpdateId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) ParseAuctionAuthorized(log types.Log) (*LVRAuctionHookAuctionAuthorized, error) {
+	event := new(LVRAuctionHookAuctionAuthorized)
+	if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionAuthorized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LVRAuctionHookAuctionRevokedIterator is returned from FilterAuctionRevoked and is used to iterate over the raw logs and unpacked data for AuctionRevoked events raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionRevokedIterator struct {
+	Event *LVRAuctionHookAuctionRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LVRAuctionHookAuctionRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LVRAuctionHookAuctionRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LVRAuctionHookAuctionRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LVRAuctionHookAuctionRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LVRAuctionHookAuctionRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LVRAuctionHookAuctionRevoked represents a AuctionRevoked event raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionRevoked struct {
+	PoolId [32]byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionRevoked is a free log retrieval operation binding the contract event 0xc7592d5e8a1d2ed9f09ca1f9b2fab7ee79c15e90fb9abeb5b58ec62de80e6fd1.
+//
+// Solidity: event AuctionRevoked(bytes32 indexed poolId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) FilterAuctionRevoked(opts *bind.FilterOpts, poolId [][32]byte) (*LVRAuctionHookAuctionRevokedIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.FilterLogs(opts, "AuctionRevoked", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookAuctionRevokedIterator{contract: _LVRAuctionHook.contract, event: "AuctionRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionRevoked is a free log subscription operation binding the contract event 0xc7592d5e8a1d2ed9f09ca1f9b2fab7ee79c15e90fb9abeb5b58ec62de80e6fd1.
+//
+// Solidity: event AuctionRevoked(bytes32 indexed poolId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) WatchAuctionRevoked(opts *bind.WatchOpts, sink chan<- *LVRAuctionHookAuctionRevoked, poolId [][32]byte) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.WatchLogs(opts, "AuctionRevoked", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LVRAuctionHookAuctionRevoked)
+				if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionRevoked is a log parse operation binding the contract event 0xc7592d5e8a1d2ed9f09ca1f9b2fab7ee79c15e90fb9abeb5b58ec62de80e6fd1.
+//
+// Solidity: event AuctionRevoked(bytes32 indexed poolId)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) ParseAuctionRevoked(log types.Log) (*LVRAuctionHookAuctionRevoked, error) {
+	event := new(LVRAuctionHookAuctionRevoked)
+	if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LVRAuctionHookAuctionServiceSetIterator is returned from FilterAuctionServiceSet and is used to iterate over the raw logs and unpacked data for AuctionServiceSet events raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionServiceSetIterator struct {
+	Event *LVRAuctionHookAuctionServiceSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LVRAuctionHookAuctionServiceSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LVRAuctionHookAuctionServiceSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LVRAuctionHookAuctionServiceSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LVRAuctionHookAuctionServiceSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LVRAuctionHookAuctionServiceSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LVRAuctionHookAuctionServiceSet represents a AuctionServiceSet event raised by the LVRAuctionHook contract.
+type LVRAuctionHookAuctionServiceSet struct {
+	AuctionService common.Address
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionServiceSet is a free log retrieval operation binding the contract event 0x7193f08fd28e999bbc6a3e85f702fe58366018c7fa962c5454ec24ec3ad0d545.
+//
+// Solidity: event AuctionServiceSet(address indexed auctionService)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) FilterAuctionServiceSet(opts *bind.FilterOpts, auctionService []common.Address) (*LVRAuctionHookAuctionServiceSetIterator, error) {
+
+	var auctionServiceRule []interface{}
+	for _, auctionServiceItem := range auctionService {
+		auctionServiceRule = append(auctionServiceRule, auctionServiceItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.FilterLogs(opts, "AuctionServiceSet", auctionServiceRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookAuctionServiceSetIterator{contract: _LVRAuctionHook.contract, event: "AuctionServiceSet", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionServiceSet is a free log subscription operation binding the contract event 0x7193f08fd28e999bbc6a3e85f702fe58366018c7fa962c5454ec24ec3ad0d545.
+//
+// Solidity: event AuctionServiceSet(address indexed auctionService)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) WatchAuctionServiceSet(opts *bind.WatchOpts, sink chan<- *LVRAuctionHookAuctionServiceSet, auctionService []common.Address) (event.Subscription, error) {
+
+	var auctionServiceRule []interface{}
+	for _, auctionServiceItem := range auctionService {
+		auctionServiceRule = append(auctionServiceRule, auctionServiceItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.WatchLogs(opts, "AuctionServiceSet", auctionServiceRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LVRAuctionHookAuctionServiceSet)
+				if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionServiceSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionServiceSet is a log parse operation binding the contract event 0x7193f08fd28e999bbc6a3e85f702fe58366018c7fa962c5454ec24ec3ad0d545.
+//
+// Solidity: event AuctionServiceSet(address indexed auctionService)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) ParseAuctionServiceSet(log types.Log) (*LVRAuctionHookAuctionServiceSet, error) {
+	event := new(LVRAuctionHookAuctionServiceSet)
+	if err := _LVRAuctionHook.contract.UnpackLog(event, "AuctionServiceSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LVRAuctionHookOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the LVRAuctionHook contract.
+type LVRAuctionHookOwnershipTransferredIterator struct {
+	Event *LVRAuctionHookOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LVRAuctionHookOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LVRAuctionHookOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LVRAuctionHookOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LVRAuctionHookOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LVRAuctionHookOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LVRAuctionHookOwnershipTransferred represents a OwnershipTransferred event raised by the LVRAuctionHook contract.
+type LVRAuctionHookOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*LVRAuctionHookOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookOwnershipTransferredIterator{contract: _LVRAuctionHook.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *LVRAuctionHookOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LVRAuctionHookOwnershipTransferred)
+				if err := _LVRAuctionHook.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) ParseOwnershipTransferred(log types.Log) (*LVRAuctionHookOwnershipTransferred, error) {
+	event := new(LVRAuctionHookOwnershipTransferred)
+	if err := _LVRAuctionHook.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LVRAuctionHookSwapObservedIterator is returned from FilterSwapObserved and is used to iterate over the raw logs and unpacked data for SwapObserved events raised by the LVRAuctionHook contract.
+type LVRAuctionHookSwapObservedIterator struct {
+	Event *LVRAuctionHookSwapObserved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LVRAuctionHookSwapObservedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LVRAuctionHookSwapObserved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LVRAuctionHookSwapObserved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LVRAuctionHookSwapObservedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LVRAuctionHookSwapObservedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LVRAuctionHookSwapObserved represents a SwapObserved event raised by the LVRAuctionHook contract.
+type LVRAuctionHookSwapObserved struct {
+	PoolId      [32]byte
+	Delta       *big.Int
+	PayloadHash [32]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterSwapObserved is a free log retrieval operation binding the contract event 0x959bc8d4a0a919e6b208effa9f82519dd36e0ee3284cbb046bfb040dfcd52594.
+//
+// Solidity: event SwapObserved(bytes32 indexed poolId, int256 delta, bytes32 payloadHash)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) FilterSwapObserved(opts *bind.FilterOpts, poolId [][32]byte) (*LVRAuctionHookSwapObservedIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.FilterLogs(opts, "SwapObserved", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LVRAuctionHookSwapObservedIterator{contract: _LVRAuctionHook.contract, event: "SwapObserved", logs: logs, sub: sub}, nil
+}
+
+// WatchSwapObserved is a free log subscription operation binding the contract event 0x959bc8d4a0a919e6b208effa9f82519dd36e0ee3284cbb046bfb040dfcd52594.
+//
+// Solidity: event SwapObserved(bytes32 indexed poolId, int256 delta, bytes32 payloadHash)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) WatchSwapObserved(opts *bind.WatchOpts, sink chan<- *LVRAuctionHookSwapObserved, poolId [][32]byte) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _LVRAuctionHook.contract.WatchLogs(opts, "SwapObserved", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LVRAuctionHookSwapObserved)
+				if err := _LVRAuctionHook.contract.UnpackLog(event, "SwapObserved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSwapObserved is a log parse operation binding the contract event 0x959bc8d4a0a919e6b208effa9f82519dd36e0ee3284cbb046bfb040dfcd52594.
+//
+// Solidity: event SwapObserved(bytes32 indexed poolId, int256 delta, bytes32 payloadHash)
+func (_LVRAuctionHook *LVRAuctionHookFilterer) ParseSwapObserved(log types.Log) (*LVRAuctionHookSwapObserved, error) {
+	event := new(LVRAuctionHookSwapObserved)
+	if err := _LVRAuctionHook.contract.UnpackLog(event, "SwapObserved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,1193 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package settlementvault
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SettlementVaultMetaData contains all meta data concerning the SettlementVault contract.
+var SettlementVaultMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_lpSink\",\"type\":\"address\",\"internalType\":\"addresspayable\"},{\"name\":\"_insuranceSink\",\"type\":\"address\",\"internalType\":\"addresspayable\"},{\"name\":\"_lpShareBps\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"error\",\"name\":\"NotOwner\",\"inputs\":[]},{\"type\":\"event\",\"name\":\"Authorized\",\"inputs\":[{\"name\":\"caller\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"allowed\",\"type\":\"bool\",\"internalType\":\"bool\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"ProceedsRecorded\",\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":false},{\"name\":\"lpAmount\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":false},{\"name\":\"insuranceAmount\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SinksUpdated\",\"inputs\":[{\"name\":\"lpSink\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true},{\"name\":\"insuranceSink\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SplitUpdated\",\"inputs\":[{\"name\":\"lpShareBps\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"function\",\"name\":\"BPS_DENOMINATOR\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"insuranceSink\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"addresspayable\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"isAuthorized\",\"inputs\":[{\"name\":\"caller\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"allowed\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"lpShareBps\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"lpSink\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"addresspayable\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"recordProceeds\",\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"setAuthorized\",\"inputs\":[{\"name\":\"caller\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"allowed\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setSinks\",\"inputs\":[{\"name\":\"_lpSink\",\"type\":\"address\",\"internalType\":\"addresspayable\"},{\"name\":\"_insuranceSink\",\"type\":\"address\",\"internalType\":\"addresspayable\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setSplit\",\"inputs\":[{\"name\":\"_lpShareBps\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+}
+
+// SettlementVaultABI is the input ABI used to generate the binding from.
+// Deprecated: Use SettlementVaultMetaData.ABI instead.
+var SettlementVaultABI = SettlementVaultMetaData.ABI
+
+// SettlementVault is an auto generated Go binding around an Ethereum contract.
+type SettlementVault struct {
+	SettlementVaultCaller     // Read-only binding to the contract
+	SettlementVaultTransactor // Write-only binding to the contract
+	SettlementVaultFilterer   // Log filterer for contract events
+}
+
+// SettlementVaultCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SettlementVaultCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SettlementVaultTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SettlementVaultTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SettlementVaultFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SettlementVaultFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SettlementVaultSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SettlementVaultSession struct {
+	Contract     *SettlementVault  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SettlementVaultCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SettlementVaultCallerSession struct {
+	Contract *SettlementVaultCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// SettlementVaultTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SettlementVaultTransactorSession struct {
+	Contract     *SettlementVaultTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// SettlementVaultRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SettlementVaultRaw struct {
+	Contract *SettlementVault // Generic contract binding to access the raw methods on
+}
+
+// SettlementVaultCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SettlementVaultCallerRaw struct {
+	Contract *SettlementVaultCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SettlementVaultTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SettlementVaultTransactorRaw struct {
+	Contract *SettlementVaultTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSettlementVault creates a new instance of SettlementVault, bound to a specific deployed contract.
+func NewSettlementVault(address common.Address, backend bind.ContractBackend) (*SettlementVault, error) {
+	contract, err := bindSettlementVault(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVault{SettlementVaultCaller: SettlementVaultCaller{contract: contract}, SettlementVaultTransactor: SettlementVaultTransactor{contract: contract}, SettlementVaultFilterer: SettlementVaultFilterer{contract: contract}}, nil
+}
+
+// NewSettlementVaultCaller creates a new read-only instance of SettlementVault, bound to a specific deployed contract.
+func NewSettlementVaultCaller(address common.Address, caller bind.ContractCaller) (*SettlementVaultCaller, error) {
+	contract, err := bindSettlementVault(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultCaller{contract: contract}, nil
+}
+
+// NewSettlementVaultTransactor creates a new write-only instance of SettlementVault, bound to a specific deployed contract.
+func NewSettlementVaultTransactor(address common.Address, transactor bind.ContractTransactor) (*SettlementVaultTransactor, error) {
+	contract, err := bindSettlementVault(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultTransactor{contract: contract}, nil
+}
+
+// NewSettlementVaultFilterer creates a new log filterer instance of SettlementVault, bound to a specific deployed contract.
+func NewSettlementVaultFilterer(address common.Address, filterer bind.ContractFilterer) (*SettlementVaultFilterer, error) {
+	contract, err := bindSettlementVault(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultFilterer{contract: contract}, nil
+}
+
+// bindSettlementVault binds a generic wrapper to an already deployed contract.
+func bindSettlementVault(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SettlementVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SettlementVault *SettlementVaultRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SettlementVault.Contract.SettlementVaultCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SettlementVault *SettlementVaultRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SettlementVaultTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SettlementVault *SettlementVaultRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SettlementVaultTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SettlementVault *SettlementVaultCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SettlementVault.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SettlementVault *SettlementVaultTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SettlementVault.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SettlementVault *SettlementVaultTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SettlementVault.Contract.contract.Transact(opts, method, params...)
+}
+
+// BPSDENOMINATOR is a free data retrieval call binding the contract method 0xe1a45218.
+//
+// Solidity: function BPS_DENOMINATOR() view returns(uint256)
+func (_SettlementVault *SettlementVaultCaller) BPSDENOMINATOR(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "BPS_DENOMINATOR")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BPSDENOMINATOR is a free data retrieval call binding the contract method 0xe1a45218.
+//
+// Solidity: function BPS_DENOMINATOR() view returns(uint256)
+func (_SettlementVault *SettlementVaultSession) BPSDENOMINATOR() (*big.Int, error) {
+	return _SettlementVault.Contract.BPSDENOMINATOR(&_SettlementVault.CallOpts)
+}
+
+// BPSDENOMINATOR is a free data retrieval call binding the contract method 0xe1a45218.
+//
+// Solidity: function BPS_DENOMINATOR() view returns(uint256)
+func (_SettlementVault *SettlementVaultCallerSession) BPSDENOMINATOR() (*big.Int, error) {
+	return _SettlementVault.Contract.BPSDENOMINATOR(&_SettlementVault.CallOpts)
+}
+
+// InsuranceSink is a free data retrieval call binding the contract method 0xdae3fd44.
+//
+// Solidity: function insuranceSink() view returns(address)
+func (_SettlementVault *SettlementVaultCaller) InsuranceSink(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "insuranceSink")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// InsuranceSink is a free data retrieval call binding the contract method 0xdae3fd44.
+//
+// Solidity: function insuranceSink() view returns(address)
+func (_SettlementVault *SettlementVaultSession) InsuranceSink() (common.Address, error) {
+	return _SettlementVault.Contract.InsuranceSink(&_SettlementVault.CallOpts)
+}
+
+// InsuranceSink is a free data retrieval call binding the contract method 0xdae3fd44.
+//
+// Solidity: function insuranceSink() view returns(address)
+func (_SettlementVault *SettlementVaultCallerSession) InsuranceSink() (common.Address, error) {
+	return _SettlementVault.Contract.InsuranceSink(&_SettlementVault.CallOpts)
+}
+
+// IsAuthorized is a free data retrieval call binding the contract method 0xfe9fbb80.
+//
+// Solidity: function isAuthorized(address caller) view returns(bool allowed)
+func (_SettlementVault *SettlementVaultCaller) IsAuthorized(opts *bind.CallOpts, caller common.Address) (bool, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "isAuthorized", caller)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsAuthorized is a free data retrieval call binding the contract method 0xfe9fbb80.
+//
+// Solidity: function isAuthorized(address caller) view returns(bool allowed)
+func (_SettlementVault *SettlementVaultSession) IsAuthorized(caller common.Address) (bool, error) {
+	return _SettlementVault.Contract.IsAuthorized(&_SettlementVault.CallOpts, caller)
+}
+
+// IsAuthorized is a free data retrieval call binding the contract method 0xfe9fbb80.
+//
+// Solidity: function isAuthorized(address caller) view returns(bool allowed)
+func (_SettlementVault *SettlementVaultCallerSession) IsAuthorized(caller common.Address) (bool, error) {
+	return _SettlementVault.Contract.IsAuthorized(&_SettlementVault.CallOpts, caller)
+}
+
+// LpShareBps is a free data retrieval call binding the contract method 0x4c7ac03f.
+//
+// Solidity: function lpShareBps() view returns(uint16)
+func (_SettlementVault *SettlementVaultCaller) LpShareBps(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "lpShareBps")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// LpShareBps is a free data retrieval call binding the contract method 0x4c7ac03f.
+//
+// Solidity: function lpShareBps() view returns(uint16)
+func (_SettlementVault *SettlementVaultSession) LpShareBps() (uint16, error) {
+	return _SettlementVault.Contract.LpShareBps(&_SettlementVault.CallOpts)
+}
+
+// LpShareBps is a free data retrieval call binding the contract method 0x4c7ac03f.
+//
+// Solidity: function lpShareBps() view returns(uint16)
+func (_SettlementVault *SettlementVaultCallerSession) LpShareBps() (uint16, error) {
+	return _SettlementVault.Contract.LpShareBps(&_SettlementVault.CallOpts)
+}
+
+// LpSink is a free data retrieval call binding the contract method 0x3977a239.
+//
+// Solidity: function lpSink() view returns(address)
+func (_SettlementVault *SettlementVaultCaller) LpSink(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "lpSink")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LpSink is a free data retrieval call binding the contract method 0x3977a239.
+//
+// Solidity: function lpSink() view returns(address)
+func (_SettlementVault *SettlementVaultSession) LpSink() (common.Address, error) {
+	return _SettlementVault.Contract.LpSink(&_SettlementVault.CallOpts)
+}
+
+// LpSink is a free data retrieval call binding the contract method 0x3977a239.
+//
+// Solidity: function lpSink() view returns(address)
+func (_SettlementVault *SettlementVaultCallerSession) LpSink() (common.Address, error) {
+	return _SettlementVault.Contract.LpSink(&_SettlementVault.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SettlementVault *SettlementVaultCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SettlementVault.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SettlementVault *SettlementVaultSession) Owner() (common.Address, error) {
+	return _SettlementVault.Contract.Owner(&_SettlementVault.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SettlementVault *SettlementVaultCallerSession) Owner() (common.Address, error) {
+	return _SettlementVault.Contract.Owner(&_SettlementVault.CallOpts)
+}
+
+// RecordProceeds is a paid mutator transaction binding the contract method 0x197978fd.
+//
+// Solidity: function recordProceeds(uint256 amount) payable returns()
+func (_SettlementVault *SettlementVaultTransactor) RecordProceeds(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _SettlementVault.contract.Transact(opts, "recordProceeds", amount)
+}
+
+// RecordProceeds is a paid mutator transaction binding the contract method 0x197978fd.
+//
+// Solidity: function recordProceeds(uint256 amount) payable returns()
+func (_SettlementVault *SettlementVaultSession) RecordProceeds(amount *big.Int) (*types.Transaction, error) {
+	return _SettlementVault.Contract.RecordProceeds(&_SettlementVault.TransactOpts, amount)
+}
+
+// RecordProceeds is a paid mutator transaction binding the contract method 0x197978fd.
+//
+// Solidity: function recordProceeds(uint256 amount) payable returns()
+func (_SettlementVault *SettlementVaultTransactorSession) RecordProceeds(amount *big.Int) (*types.Transaction, error) {
+	return _SettlementVault.Contract.RecordProceeds(&_SettlementVault.TransactOpts, amount)
+}
+
+// SetAuthorized is a paid mutator transaction binding the contract method 0x711bf9b2.
+//
+// Solidity: function setAuthorized(address caller, bool allowed) returns()
+func (_SettlementVault *SettlementVaultTransactor) SetAuthorized(opts *bind.TransactOpts, caller common.Address, allowed bool) (*types.Transaction, error) {
+	return _SettlementVault.contract.Transact(opts, "setAuthorized", caller, allowed)
+}
+
+// SetAuthorized is a paid mutator transaction binding the contract method 0x711bf9b2.
+//
+// Solidity: function setAuthorized(address caller, bool allowed) returns()
+func (_SettlementVault *SettlementVaultSession) SetAuthorized(caller common.Address, allowed bool) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetAuthorized(&_SettlementVault.TransactOpts, caller, allowed)
+}
+
+// SetAuthorized is a paid mutator transaction binding the contract method 0x711bf9b2.
+//
+// Solidity: function setAuthorized(address caller, bool allowed) returns()
+func (_SettlementVault *SettlementVaultTransactorSession) SetAuthorized(caller common.Address, allowed bool) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetAuthorized(&_SettlementVault.TransactOpts, caller, allowed)
+}
+
+// SetSinks is a paid mutator transaction binding the contract method 0x5e4e0ae8.
+//
+// Solidity: function setSinks(address _lpSink, address _insuranceSink) returns()
+func (_SettlementVault *SettlementVaultTransactor) SetSinks(opts *bind.TransactOpts, _lpSink common.Address, _insuranceSink common.Address) (*types.Transaction, error) {
+	return _SettlementVault.contract.Transact(opts, "setSinks", _lpSink, _insuranceSink)
+}
+
+// SetSinks is a paid mutator transaction binding the contract method 0x5e4e0ae8.
+//
+// Solidity: function setSinks(address _lpSink, address _insuranceSink) returns()
+func (_SettlementVault *SettlementVaultSession) SetSinks(_lpSink common.Address, _insuranceSink common.Address) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetSinks(&_SettlementVault.TransactOpts, _lpSink, _insuranceSink)
+}
+
+// SetSinks is a paid mutator transaction binding the contract method 0x5e4e0ae8.
+//
+// Solidity: function setSinks(address _lpSink, address _insuranceSink) returns()
+func (_SettlementVault *SettlementVaultTransactorSession) SetSinks(_lpSink common.Address, _insuranceSink common.Address) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetSinks(&_SettlementVault.TransactOpts, _lpSink, _insuranceSink)
+}
+
+// SetSplit is a paid mutator transaction binding the contract method 0x733fc03d.
+//
+// Solidity: function setSplit(uint16 _lpShareBps) returns()
+func (_SettlementVault *SettlementVaultTransactor) SetSplit(opts *bind.TransactOpts, _lpShareBps uint16) (*types.Transaction, error) {
+	return _SettlementVault.contract.Transact(opts, "setSplit", _lpShareBps)
+}
+
+// SetSplit is a paid mutator transaction binding the contract method 0x733fc03d.
+//
+// Solidity: function setSplit(uint16 _lpShareBps) returns()
+func (_SettlementVault *SettlementVaultSession) SetSplit(_lpShareBps uint16) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetSplit(&_SettlementVault.TransactOpts, _lpShareBps)
+}
+
+// SetSplit is a paid mutator transaction binding the contract method 0x733fc03d.
+//
+// Solidity: function setSplit(uint16 _lpShareBps) returns()
+func (_SettlementVault *SettlementVaultTransactorSession) SetSplit(_lpShareBps uint16) (*types.Transaction, error) {
+	return _SettlementVault.Contract.SetSplit(&_SettlementVault.TransactOpts, _lpShareBps)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_SettlementVault *SettlementVaultTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _SettlementVault.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_SettlementVault *SettlementVaultSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _SettlementVault.Contract.TransferOwnership(&_SettlementVault.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_SettlementVault *SettlementVaultTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _SettlementVault.Contract.TransferOwnership(&_SettlementVault.TransactOpts, newOwner)
+}
+
+// SettlementVaultAuthorizedIterator is returned from FilterAuthorized and is used to iterate over the raw logs and unpacked data for Authorized events raised by the SettlementVault contract.
+type SettlementVaultAuthorizedIterator struct {
+	Event *SettlementVaultAuthorized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SettlementVaultAuthorizedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SettlementVaultAuthorized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SettlementVaultAuthorized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SettlementVaultAuthorizedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SettlementVaultAuthorizedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SettlementVaultAuthorized represents a Authorized event raised by the SettlementVault contract.
+type SettlementVaultAuthorized struct {
+	Caller  common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuthorized is a free log retrieval operation binding the contract event 0x4c0079b9bcd37cd5d29a13938effd97c881798cbc6bd52a3026a29d94b27d1bf.
+//
+// Solidity: event Authorized(address indexed caller, bool allowed)
+func (_SettlementVault *SettlementVaultFilterer) FilterAuthorized(opts *bind.FilterOpts, caller []common.Address) (*SettlementVaultAuthorizedIterator, error) {
+
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.FilterLogs(opts, "Authorized", callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultAuthorizedIterator{contract: _SettlementVault.contract, event: "Authorized", logs: logs, sub: sub}, nil
+}
+
+// WatchAuthorized is a free log subscription operation binding the contract event 0x4c0079b9bcd37cd5d29a13938effd97c881798cbc6bd52a3026a29d94b27d1bf.
+//
+// Solidity: event Authorized(address indexed caller, bool allowed)
+func (_SettlementVault *SettlementVaultFilterer) WatchAuthorized(opts *bind.WatchOpts, sink chan<- *SettlementVaultAuthorized, caller []common.Address) (event.Subscription, error) {
+
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.WatchLogs(opts, "Authorized", callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SettlementVaultAuthorized)
+				if err := _SettlementVault.contract.UnpackLog(event, "Authorized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuthorized is a log parse operation binding the contract event 0x4c0079b9bcd37cd5d29a13938effd97c881798cbc6bd52a3026a29d94b27d1bf.
+//
+// Solidity: event Authorized(address indexed caller, bool allowed)
+func (_SettlementVault *SettlementVaultFilterer) ParseAuthorized(log types.Log) (*SettlementVaultAuthorized, error) {
+	event := new(SettlementVaultAuthorized)
+	if err := _SettlementVault.contract.UnpackLog(event, "Authorized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SettlementVaultOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the SettlementVault contract.
+type SettlementVaultOwnershipTransferredIterator struct {
+	Event *SettlementVaultOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SettlementVaultOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SettlementVaultOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SettlementVaultOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SettlementVaultOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SettlementVaultOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SettlementVaultOwnershipTransferred represents a OwnershipTransferred event raised by the SettlementVault contract.
+type SettlementVaultOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_SettlementVault *SettlementVaultFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*SettlementVaultOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultOwnershipTransferredIterator{contract: _SettlementVault.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_SettlementVault *SettlementVaultFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *SettlementVaultOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SettlementVaultOwnershipTransferred)
+				if err := _SettlementVault.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_SettlementVault *SettlementVaultFilterer) ParseOwnershipTransferred(log types.Log) (*SettlementVaultOwnershipTransferred, error) {
+	event := new(SettlementVaultOwnershipTransferred)
+	if err := _SettlementVault.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SettlementVaultProceedsRecordedIterator is returned from FilterProceedsRecorded and is used to iterate over the raw logs and unpacked data for ProceedsRecorded events raised by the SettlementVault contract.
+type SettlementVaultProceedsRecordedIterator struct {
+	Event *SettlementVaultProceedsRecorded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SettlementVaultProceedsRecordedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SettlementVaultProceedsRecorded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SettlementVaultProceedsRecorded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SettlementVaultProceedsRecordedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SettlementVaultProceedsRecordedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SettlementVaultProceedsRecorded represents a ProceedsRecorded event raised by the SettlementVault contract.
+type SettlementVaultProceedsRecorded struct {
+	Amount          *big.Int
+	LpAmount        *big.Int
+	InsuranceAmount *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterProceedsRecorded is a free log retrieval operation binding the contract event 0x5d0559dfceceafe161ada616ba9010e39ab147fa1fa0a9f40f37946ccb71a754.
+//
+// Solidity: event ProceedsRecorded(uint256 amount, uint256 lpAmount, uint256 insuranceAmount)
+func (_SettlementVault *SettlementVaultFilterer) FilterProceedsRecorded(opts *bind.FilterOpts) (*SettlementVaultProceedsRecordedIterator, error) {
+
+	logs, sub, err := _SettlementVault.contract.FilterLogs(opts, "ProceedsRecorded")
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultProceedsRecordedIterator{contract: _SettlementVault.contract, event: "ProceedsRecorded", logs: logs, sub: sub}, nil
+}
+
+// WatchProceedsRecorded is a free log subscription operation binding the contract event 0x5d0559dfceceafe161ada616ba9010e39ab147fa1fa0a9f40f37946ccb71a754.
+//
+// Solidity: event ProceedsRecorded(uint256 amount, uint256 lpAmount, uint256 insuranceAmount)
+func (_SettlementVault *SettlementVaultFilterer) WatchProceedsRecorded(opts *bind.WatchOpts, sink chan<- *SettlementVaultProceedsRecorded) (event.Subscription, error) {
+
+	logs, sub, err := _SettlementVault.contract.WatchLogs(opts, "ProceedsRecorded")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SettlementVaultProceedsRecorded)
+				if err := _SettlementVault.contract.UnpackLog(event, "ProceedsRecorded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProceedsRecorded is a log parse operation binding the contract event 0x5d0559dfceceafe161ada616ba9010e39ab147fa1fa0a9f40f37946ccb71a754.
+//
+// Solidity: event ProceedsRecorded(uint256 amount, uint256 lpAmount, uint256 insuranceAmount)
+func (_SettlementVault *SettlementVaultFilterer) ParseProceedsRecorded(log types.Log) (*SettlementVaultProceedsRecorded, error) {
+	event := new(SettlementVaultProceedsRecorded)
+	if err := _SettlementVault.contract.UnpackLog(event, "ProceedsRecorded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SettlementVaultSinksUpdatedIterator is returned from FilterSinksUpdated and is used to iterate over the raw logs and unpacked data for SinksUpdated events raised by the SettlementVault contract.
+type SettlementVaultSinksUpdatedIterator struct {
+	Event *SettlementVaultSinksUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SettlementVaultSinksUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SettlementVaultSinksUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SettlementVaultSinksUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SettlementVaultSinksUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SettlementVaultSinksUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SettlementVaultSinksUpdated represents a SinksUpdated event raised by the SettlementVault contract.
+type SettlementVaultSinksUpdated struct {
+	LpSink        common.Address
+	InsuranceSink common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterSinksUpdated is a free log retrieval operation binding the contract event 0xa8fe7adf95229c64d870b0941b7d2a75d9c95b03ad2fffa2c4ca36be2369a812.
+//
+// Solidity: event SinksUpdated(address indexed lpSink, address indexed insuranceSink)
+func (_SettlementVault *SettlementVaultFilterer) FilterSinksUpdated(opts *bind.FilterOpts, lpSink []common.Address, insuranceSink []common.Address) (*SettlementVaultSinksUpdatedIterator, error) {
+
+	var lpSinkRule []interface{}
+	for _, lpSinkItem := range lpSink {
+		lpSinkRule = append(lpSinkRule, lpSinkItem)
+	}
+	var insuranceSinkRule []interface{}
+	for _, insuranceSinkItem := range insuranceSink {
+		insuranceSinkRule = append(insuranceSinkRule, insuranceSinkItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.FilterLogs(opts, "SinksUpdated", lpSinkRule, insuranceSinkRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultSinksUpdatedIterator{contract: _SettlementVault.contract, event: "SinksUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchSinksUpdated is a free log subscription operation binding the contract event 0xa8fe7adf95229c64d870b0941b7d2a75d9c95b03ad2fffa2c4ca36be2369a812.
+//
+// Solidity: event SinksUpdated(address indexed lpSink, address indexed insuranceSink)
+func (_SettlementVault *SettlementVaultFilterer) WatchSinksUpdated(opts *bind.WatchOpts, sink chan<- *SettlementVaultSinksUpdated, lpSink []common.Address, insuranceSink []common.Address) (event.Subscription, error) {
+
+	var lpSinkRule []interface{}
+	for _, lpSinkItem := range lpSink {
+		lpSinkRule = append(lpSinkRule, lpSinkItem)
+	}
+	var insuranceSinkRule []interface{}
+	for _, insuranceSinkItem := range insuranceSink {
+		insuranceSinkRule = append(insuranceSinkRule, insuranceSinkItem)
+	}
+
+	logs, sub, err := _SettlementVault.contract.WatchLogs(opts, "SinksUpdated", lpSinkRule, insuranceSinkRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SettlementVaultSinksUpdated)
+				if err := _SettlementVault.contract.UnpackLog(event, "SinksUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSinksUpdated is a log parse operation binding the contract event 0xa8fe7adf95229c64d870b0941b7d2a75d9c95b03ad2fffa2c4ca36be2369a812.
+//
+// Solidity: event SinksUpdated(address indexed lpSink, address indexed insuranceSink)
+func (_SettlementVault *SettlementVaultFilterer) ParseSinksUpdated(log types.Log) (*SettlementVaultSinksUpdated, error) {
+	event := new(SettlementVaultSinksUpdated)
+	if err := _SettlementVault.contract.UnpackLog(event, "SinksUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SettlementVaultSplitUpdatedIterator is returned from FilterSplitUpdated and is used to iterate over the raw logs and unpacked data for SplitUpdated events raised by the SettlementVault contract.
+type SettlementVaultSplitUpdatedIterator struct {
+	Event *SettlementVaultSplitUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SettlementVaultSplitUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SettlementVaultSplitUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SettlementVaultSplitUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SettlementVaultSplitUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SettlementVaultSplitUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SettlementVaultSplitUpdated represents a SplitUpdated event raised by the SettlementVault contract.
+type SettlementVaultSplitUpdated struct {
+	LpShareBps uint16
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterSplitUpdated is a free log retrieval operation binding the contract event 0x96bcd82a7097a1ecf42e848d65159d42bbe6abd8e499e0eeb8d21b795ab2b36e.
+//
+// Solidity: event SplitUpdated(uint16 lpShareBps)
+func (_SettlementVault *SettlementVaultFilterer) FilterSplitUpdated(opts *bind.FilterOpts) (*SettlementVaultSplitUpdatedIterator, error) {
+
+	logs, sub, err := _SettlementVault.contract.FilterLogs(opts, "SplitUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &SettlementVaultSplitUpdatedIterator{contract: _SettlementVault.contract, event: "SplitUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchSplitUpdated is a free log subscription operation binding the contract event 0x96bcd82a7097a1ecf42e848d65159d42bbe6abd8e499e0eeb8d21b795ab2b36e.
+//
+// Solidity: event SplitUpdated(uint16 lpShareBps)
+func (_SettlementVault *SettlementVaultFilterer) WatchSplitUpdated(opts *bind.WatchOpts, sink chan<- *SettlementVaultSplitUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _SettlementVault.contract.WatchLogs(opts, "SplitUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SettlementVaultSplitUpdated)
+				if err := _SettlementVault.contract.UnpackLog(event, "SplitUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSplitUpdated is a log parse operation binding the contract event 0x96bcd82a7097a1ecf42e848d65159d42bbe6abd8e499e0eeb8d21b795ab2b36e.
+//
+// Solidity: event SplitUpdated(uint16 lpShareBps)
+func (_SettlementVault *SettlementVaultFilterer) ParseSplitUpdated(log types.Log) (*SettlementVaultSplitUpdated, error) {
+	event := new(SettlementVaultSplitUpdated)
+	if err := _SettlementVault.contract.UnpackLog(event, "SplitUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
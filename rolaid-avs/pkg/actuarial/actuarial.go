@@ -0,0 +1,131 @@
+// Package actuarial is the rule-based insurance model. It decides insurance claims when a
+// task names no EigenAI model, and stands in for EigenAI when it cannot be reached.
+//
+// Each event is given a severity in basis points from the price moves reported for it: a
+// move up to DeductibleBps pays nothing, a move of FullLossBps or more pays in full and
+// the range between is linear, rounded down. An event reported without price moves pays
+// in full. A policy claims its coverage scaled by the worst event it covers, and the pot
+// is then split across claims by payout.Allocate. Only integer arithmetic is used, so
+// every operator computes the same schedule byte for byte.
+//
+// The formula is fixed for a Version. Changing it, or any of its parameters, changes
+// payouts and needs a new Version so results stay attributable.
+package actuarial
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Version identifies the formula and parameters below. It is recorded in task results.
+const Version = "rolaid-actuarial-v1"
+
+// Parameters of Version, in basis points.
+const (
+	FullBps       = 10_000
+	DeductibleBps = 100   // moves up to 1% pay nothing
+	FullLossBps   = 2_000 // moves of 20% or more pay the full coverage
+)
+
+var ErrInvalidMove = errors.New("invalid price move")
+
+// PriceMove is the price change of an asset during an event.
+type PriceMove struct {
+	Event string
+	Asset string
+	// MoveBps is the signed change in basis points; a price cannot fall by more than
+	// FullBps. Only the size of the move counts, as a peg can break either way.
+	MoveBps int64
+}
+
+// ValidateMoves checks every move belongs to one of events and does not fall below zero.
+func ValidateMoves(events []string, moves []PriceMove) error {
+	known := make(map[string]bool, len(events))
+	for _, e := range events {
+		known[e] = true
+	}
+	for i, m := range moves {
+		switch {
+		case !known[m.Event]:
+			return fmt.Errorf("%w: move %d is for unknown event %q", ErrInvalidMove, i, m.Event)
+		case m.MoveBps < -FullBps:
+			return fmt.Errorf("%w: move %d falls %d bps, more than %d", ErrInvalidMove, i, -m.MoveBps, FullBps)
+		}
+	}
+	return nil
+}
+
+// Severity returns the share of coverage, in basis points, that event pays given moves.
+func Severity(event string, moves []PriceMove) int64 {
+	size, reported := int64(0), false
+	for _, m := range moves {
+		if m.Event != event {
+			continue
+		}
+		reported = true
+		abs := m.MoveBps
+		if abs < 0 {
+			abs = -abs
+		}
+		if abs > size {
+			size = abs
+		}
+	}
+	switch {
+	case !reported, size >= FullLossBps:
+		return FullBps
+	case size <= DeductibleBps:
+		return 0
+	default:
+		return (size - DeductibleBps) * FullBps / (FullLossBps - DeductibleBps)
+	}
+}
+
+// Claims returns what each beneficiary claims for events, summed across their policies.
+// A policy claims floor(coverage * severity / FullBps) for the most severe event it covers.
+func Claims(policies []payout.Policy, events []string, moves []PriceMove) (map[common.Address]*big.Int, error) {
+	if err := payout.ValidatePolicies(policies); err != nil {
+		return nil, err
+	}
+	if err := ValidateMoves(events, moves); err != nil {
+		return nil, err
+	}
+	severity := make(map[string]int64, len(events))
+	for _, e := range events {
+		severity[e] = Severity(e, moves)
+	}
+
+	claims := make(map[common.Address]*big.Int)
+	for _, p := range policies {
+		worst := int64(0)
+		for e, s := range severity {
+			if s > worst && p.Covers(e) {
+				worst = s
+			}
+		}
+		if worst == 0 {
+			continue
+		}
+		claim := new(big.Int).Mul(p.Coverage, big.NewInt(worst))
+		claim.Quo(claim, big.NewInt(FullBps))
+		if c, ok := claims[p.Beneficiary]; ok {
+			c.Add(c, claim)
+		} else {
+			claims[p.Beneficiary] = claim
+		}
+	}
+	return claims, nil
+}
+
+// Schedule returns the payout vector splitting pot across the claims for events.
+func Schedule(pot *big.Int, policies []payout.Policy, events []string, moves []PriceMove) ([]payout.Payout, error) {
+	claims, err := Claims(policies, events, moves)
+	if err != nil {
+		return nil, err
+	}
+	return payout.Allocate(pot, claims)
+}
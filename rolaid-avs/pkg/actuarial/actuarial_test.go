@@ -0,0 +1,113 @@
+package actuarial
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/payout"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	alice = common.HexToAddress("0xa1")
+	bob   = common.HexToAddress("0xb2")
+)
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []PriceMove
+		want  int64
+	}{
+		{name: "no moves reported", want: FullBps},
+		{name: "moves for other events only", moves: []PriceMove{{Event: "exploit", MoveBps: -50}}, want: FullBps},
+		{name: "within the deductible", moves: []PriceMove{{Event: "depeg", MoveBps: -100}}, want: 0},
+		{name: "linear range", moves: []PriceMove{{Event: "depeg", MoveBps: -800}}, want: 3684},
+		{name: "rise counts like a fall", moves: []PriceMove{{Event: "depeg", MoveBps: 800}}, want: 3684},
+		{name: "worst move wins", moves: []PriceMove{{Event: "depeg", MoveBps: -300}, {Event: "depeg", MoveBps: -1050}}, want: 5000},
+		{name: "full loss", moves: []PriceMove{{Event: "depeg", MoveBps: -2000}}, want: FullBps},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Severity("depeg", tt.moves); got != tt.want {
+				t.Fatalf("Severity = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	policies := []payout.Policy{
+		{Id: "p-1", Beneficiary: alice, Coverage: big.NewInt(600), Events: []string{"depeg"}},
+		{Id: "p-2", Beneficiary: bob, Coverage: big.NewInt(600)},
+		{Id: "p-3", Beneficiary: bob, Coverage: big.NewInt(999), Events: []string{"exploit"}},
+	}
+	tests := []struct {
+		name   string
+		pot    int64
+		events []string
+		moves  []PriceMove
+		want   []payout.Payout
+	}{
+		{
+			name:   "unreported move pays full coverage",
+			pot:    1000,
+			events: []string{"depeg"},
+			want:   []payout.Payout{{Beneficiary: alice, Amount: big.NewInt(500)}, {Beneficiary: bob, Amount: big.NewInt(500)}},
+		},
+		{
+			// Severity 5000 halves both claims to 300 each, under the pot.
+			name:   "partial loss",
+			pot:    1000,
+			events: []string{"depeg"},
+			moves:  []PriceMove{{Event: "depeg", Asset: "USDC", MoveBps: -1050}},
+			want:   []payout.Payout{{Beneficiary: alice, Amount: big.NewInt(300)}, {Beneficiary: bob, Amount: big.NewInt(300)}},
+		},
+		{
+			// p-2 covers both events and claims for the worse one.
+			name:   "worst covered event",
+			pot:    10_000,
+			events: []string{"depeg", "exploit"},
+			moves:  []PriceMove{{Event: "depeg", MoveBps: -1050}},
+			want:   []payout.Payout{{Beneficiary: alice, Amount: big.NewInt(300)}, {Beneficiary: bob, Amount: big.NewInt(1599)}},
+		},
+		{
+			name:   "within the deductible",
+			pot:    1000,
+			events: []string{"depeg"},
+			moves:  []PriceMove{{Event: "depeg", MoveBps: -20}},
+			want:   []payout.Payout{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Schedule(big.NewInt(tt.pot), policies, tt.events, tt.moves)
+			if err != nil {
+				t.Fatalf("Schedule: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Schedule = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMoves(t *testing.T) {
+	events := []string{"depeg"}
+	if err := ValidateMoves(events, []PriceMove{{Event: "depeg", MoveBps: -10_000}}); err != nil {
+		t.Fatalf("ValidateMoves: %v", err)
+	}
+	for _, moves := range [][]PriceMove{
+		{{Event: "exploit", MoveBps: -500}},
+		{{Event: "depeg", MoveBps: -10_001}},
+	} {
+		if err := ValidateMoves(events, moves); !errors.Is(err, ErrInvalidMove) {
+			t.Errorf("ValidateMoves(%v) = %v, want ErrInvalidMove", moves, err)
+		}
+	}
+	if _, err := Claims([]payout.Policy{{Id: "p-1"}}, events, nil); !errors.Is(err, payout.ErrInvalidPolicy) {
+		t.Errorf("Claims without beneficiary = %v, want ErrInvalidPolicy", err)
+	}
+}
@@ -0,0 +1,224 @@
+// Package auction runs the sealed-bid auction for an AuctionService auction.
+//
+// Bidders first commit to a bid with SealBid during the commit phase and then reveal the
+// bid and its salt during the reveal phase. Both phases fall inside the auction's
+// [startTime, endTime] window. The highest valid revealed bid wins; equal amounts are
+// broken by the lower commitment, so every operator fed the same commits and reveals
+// reaches the same winner. The book never reads the wall clock: callers pass the time
+// each commit or reveal was made, which the bidder signs into it (see CommitDigest and
+// RevealDigest) so whoever relays the bids cannot move it.
+package auction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/commitment"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/wei"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DomainSealedBid separates sealed-bid commitments from the result commitments.
+var DomainSealedBid = commitment.Domain("ROLAID_SEALED_BID_V1")
+
+// MaxBidWei is the largest bid AuctionService can record (bidAmount is a uint96).
+var MaxBidWei = wei.MaxUint96
+
+var (
+	ErrInvalidWindow   = errors.New("invalid auction window")
+	ErrCommitPhase     = errors.New("outside commit phase")
+	ErrRevealPhase     = errors.New("outside reveal phase")
+	ErrDuplicateCommit = errors.New("bidder already committed")
+	ErrDuplicateReveal = errors.New("bid already revealed")
+	ErrNoCommit        = errors.New("no commitment for bidder")
+	ErrCommitMismatch  = errors.New("reveal does not match commitment")
+	ErrInvalidBid      = errors.New("invalid bid")
+	ErrNoValidBids     = errors.New("no valid bids")
+	ErrBadSignature    = errors.New("invalid bidder signature")
+)
+
+// Window splits an auction's [Start, End] window into a commit phase [Start, RevealStart)
+// and a reveal phase [RevealStart, End]. Times are unix seconds.
+type Window struct {
+	Start       uint64
+	RevealStart uint64
+	End         uint64
+}
+
+// NewWindow builds a window for an auction running from start to end whose last
+// revealPeriod seconds are the reveal phase. A zero revealPeriod splits the window in half.
+func NewWindow(start, end, revealPeriod uint64) (Window, error) {
+	if end <= start {
+		return Window{}, fmt.Errorf("%w: end %d not after start %d", ErrInvalidWindow, end, start)
+	}
+	if revealPeriod == 0 {
+		revealPeriod = (end - start + 1) / 2
+	}
+	if revealPeriod > end-start {
+		return Window{}, fmt.Errorf("%w: reveal period %d leaves no commit phase", ErrInvalidWindow, revealPeriod)
+	}
+	w := Window{Start: start, RevealStart: end - revealPeriod + 1, End: end}
+	return w, w.Validate()
+}
+
+// Validate checks both phases are non-empty.
+func (w Window) Validate() error {
+	if !(w.Start < w.RevealStart && w.RevealStart <= w.End) {
+		return fmt.Errorf("%w: need start < reveal_start <= end, got %d, %d, %d", ErrInvalidWindow, w.Start, w.RevealStart, w.End)
+	}
+	return nil
+}
+
+// InCommitPhase reports whether t falls in [Start, RevealStart).
+func (w Window) InCommitPhase(t uint64) bool { return t >= w.Start && t < w.RevealStart }
+
+// InRevealPhase reports whether t falls in [RevealStart, End].
+func (w Window) InRevealPhase(t uint64) bool { return t >= w.RevealStart && t <= w.End }
+
+// Bid is a revealed bid: what the bidder pays and the settlement payload submitted onchain
+// if it wins.
+type Bid struct {
+	Bidder         common.Address
+	Amount         *big.Int
+	SettlementData []byte
+}
+
+// Validate checks the bid could be submitted to AuctionService.
+func (b Bid) Validate() error {
+	switch {
+	case b.Bidder == (common.Address{}):
+		return fmt.Errorf("%w: zero bidder", ErrInvalidBid)
+	case b.Amount == nil || b.Amount.Sign() <= 0:
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidBid)
+	case b.Amount.Cmp(MaxBidWei) > 0:
+		return fmt.Errorf("%w: amount %s exceeds uint96", ErrInvalidBid, b.Amount)
+	}
+	return nil
+}
+
+// SealBid computes the commitment a bidder publishes during the commit phase. It binds
+// the auction, the bid and a secret salt, so bids cannot be read or moved between auctions.
+func SealBid(auctionId *big.Int, bid Bid, salt common.Hash) common.Hash {
+	id := common.BigToHash(auctionId)
+	amount := common.BigToHash(bid.Amount)
+	settlementHash := commitment.SettlementHash(bid.SettlementData)
+	return commitment.Hash(DomainSealedBid, id[:], bid.Bidder[:], amount[:], settlementHash[:], salt[:])
+}
+
+// Outcome is the result of a closed auction, handed to the settlement handler.
+type Outcome struct {
+	AuctionId      *big.Int
+	Winner         common.Address
+	Amount         *big.Int
+	SettlementData []byte
+	// Commitment is the winner's sealed-bid commitment.
+	Commitment common.Hash
+	// Revealed counts valid reveals; Unrevealed counts commits that were never revealed.
+	Revealed   int
+	Unrevealed int
+}
+
+type sealedBid struct {
+	commitment common.Hash
+	revealed   *Bid
+}
+
+// Book collects the commits and reveals for one auction. It is not safe for concurrent use.
+type Book struct {
+	auctionId *big.Int
+	window    Window
+	bids      map[common.Address]*sealedBid
+}
+
+// NewBook starts an empty book for auctionId.
+func NewBook(auctionId *big.Int, w Window) (*Book, error) {
+	if auctionId == nil || auctionId.Sign() < 0 {
+		return nil, fmt.Errorf("invalid auction id")
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return &Book{
+		auctionId: new(big.Int).Set(auctionId),
+		window:    w,
+		bids:      make(map[common.Address]*sealedBid),
+	}, nil
+}
+
+// Window returns the book's auction window.
+func (b *Book) Window() Window { return b.window }
+
+// Commit records bidder's sealed bid observed at time at. Each bidder commits once.
+func (b *Book) Commit(bidder common.Address, sealed common.Hash, at uint64) error {
+	if !b.window.InCommitPhase(at) {
+		return fmt.Errorf("%w: time %d not in [%d, %d)", ErrCommitPhase, at, b.window.Start, b.window.RevealStart)
+	}
+	if bidder == (common.Address{}) {
+		return fmt.Errorf("%w: zero bidder", ErrInvalidBid)
+	}
+	if _, ok := b.bids[bidder]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateCommit, bidder.Hex())
+	}
+	b.bids[bidder] = &sealedBid{commitment: sealed}
+	return nil
+}
+
+// Reveal opens bid's commitment with salt, observed at time at.
+func (b *Book) Reveal(bid Bid, salt common.Hash, at uint64) error {
+	if !b.window.InRevealPhase(at) {
+		return fmt.Errorf("%w: time %d not in [%d, %d]", ErrRevealPhase, at, b.window.RevealStart, b.window.End)
+	}
+	sb, ok := b.bids[bid.Bidder]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoCommit, bid.Bidder.Hex())
+	}
+	if sb.revealed != nil {
+		return fmt.Errorf("%w: %s", ErrDuplicateReveal, bid.Bidder.Hex())
+	}
+	if err := bid.Validate(); err != nil {
+		return err
+	}
+	if SealBid(b.auctionId, bid, salt) != sb.commitment {
+		return fmt.Errorf("%w: %s", ErrCommitMismatch, bid.Bidder.Hex())
+	}
+	sb.revealed = &Bid{
+		Bidder:         bid.Bidder,
+		Amount:         new(big.Int).Set(bid.Amount),
+		SettlementData: bytes.Clone(bid.SettlementData),
+	}
+	return nil
+}
+
+// Outcome picks the highest revealed bid. Equal amounts go to the lower commitment, which
+// every operator computes identically regardless of the order bids were observed in.
+func (b *Book) Outcome() (*Outcome, error) {
+	revealed := make([]*sealedBid, 0, len(b.bids))
+	for _, sb := range b.bids {
+		if sb.revealed != nil {
+			revealed = append(revealed, sb)
+		}
+	}
+	if len(revealed) == 0 {
+		return nil, fmt.Errorf("%w: auction %s had %d commits", ErrNoValidBids, b.auctionId, len(b.bids))
+	}
+	sort.Slice(revealed, func(i, j int) bool {
+		if c := revealed[i].revealed.Amount.Cmp(revealed[j].revealed.Amount); c != 0 {
+			return c > 0
+		}
+		return bytes.Compare(revealed[i].commitment[:], revealed[j].commitment[:]) < 0
+	})
+
+	win := revealed[0]
+	return &Outcome{
+		AuctionId:      new(big.Int).Set(b.auctionId),
+		Winner:         win.revealed.Bidder,
+		Amount:         new(big.Int).Set(win.revealed.Amount),
+		SettlementData: bytes.Clone(win.revealed.SettlementData),
+		Commitment:     win.commitment,
+		Revealed:       len(revealed),
+		Unrevealed:     len(b.bids) - len(revealed),
+	}, nil
+}
@@ -0,0 +1,273 @@
+package auction
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	auctionId = big.NewInt(7)
+	window    = Window{Start: 100, RevealStart: 200, End: 300}
+	alice     = common.HexToAddress("0xa1")
+	bob       = common.HexToAddress("0xb2")
+	carol     = common.HexToAddress("0xc3")
+)
+
+func bid(bidder common.Address, amount int64, data string) Bid {
+	return Bid{Bidder: bidder, Amount: big.NewInt(amount), SettlementData: []byte(data)}
+}
+
+func salt(b byte) common.Hash {
+	var s common.Hash
+	s[31] = b
+	return s
+}
+
+// run commits and reveals bids in the given order and returns the outcome.
+func run(t *testing.T, bids []Bid) *Outcome {
+	t.Helper()
+	book, err := NewBook(auctionId, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range bids {
+		if err := book.Commit(b.Bidder, SealBid(auctionId, b, salt(byte(i))), 150); err != nil {
+			t.Fatalf("commit %s: %v", b.Bidder.Hex(), err)
+		}
+	}
+	for i, b := range bids {
+		if err := book.Reveal(b, salt(byte(i)), 250); err != nil {
+			t.Fatalf("reveal %s: %v", b.Bidder.Hex(), err)
+		}
+	}
+	out, err := book.Outcome()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestHighestBidWins(t *testing.T) {
+	out := run(t, []Bid{bid(alice, 10, "a"), bid(bob, 30, "b"), bid(carol, 20, "c")})
+	if out.Winner != bob || out.Amount.Int64() != 30 || string(out.SettlementData) != "b" {
+		t.Fatalf("outcome = %s %s %q, want bob 30 \"b\"", out.Winner.Hex(), out.Amount, out.SettlementData)
+	}
+	if out.Revealed != 3 || out.Unrevealed != 0 {
+		t.Fatalf("revealed %d unrevealed %d", out.Revealed, out.Unrevealed)
+	}
+}
+
+func TestTieBreakIsOrderIndependent(t *testing.T) {
+	a, b := bid(alice, 50, "a"), bid(bob, 50, "b")
+	sa := SealBid(auctionId, a, salt(1))
+	sb := SealBid(auctionId, b, salt(2))
+	want := alice
+	if sb.Big().Cmp(sa.Big()) < 0 {
+		want = bob
+	}
+
+	for _, order := range [][]int{{0, 1}, {1, 0}} {
+		book, err := NewBook(auctionId, window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bids := []Bid{a, b}
+		salts := []common.Hash{salt(1), salt(2)}
+		for _, i := range order {
+			if err := book.Commit(bids[i].Bidder, SealBid(auctionId, bids[i], salts[i]), 150); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, i := range order {
+			if err := book.Reveal(bids[i], salts[i], 250); err != nil {
+				t.Fatal(err)
+			}
+		}
+		out, err := book.Outcome()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Winner != want {
+			t.Fatalf("order %v: winner %s, want %s", order, out.Winner.Hex(), want.Hex())
+		}
+	}
+}
+
+func TestBookRejects(t *testing.T) {
+	book, err := NewBook(auctionId, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := bid(alice, 10, "a")
+	sealed := SealBid(auctionId, a, salt(1))
+
+	if err := book.Commit(alice, sealed, 99); !errors.Is(err, ErrCommitPhase) {
+		t.Fatalf("commit before start = %v, want ErrCommitPhase", err)
+	}
+	if err := book.Commit(alice, sealed, 200); !errors.Is(err, ErrCommitPhase) {
+		t.Fatalf("commit in reveal phase = %v, want ErrCommitPhase", err)
+	}
+	if err := book.Commit(alice, sealed, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Commit(alice, sealed, 101); !errors.Is(err, ErrDuplicateCommit) {
+		t.Fatalf("second commit = %v, want ErrDuplicateCommit", err)
+	}
+	if err := book.Reveal(a, salt(1), 199); !errors.Is(err, ErrRevealPhase) {
+		t.Fatalf("reveal in commit phase = %v, want ErrRevealPhase", err)
+	}
+	if err := book.Reveal(a, salt(1), 301); !errors.Is(err, ErrRevealPhase) {
+		t.Fatalf("reveal after end = %v, want ErrRevealPhase", err)
+	}
+	if err := book.Reveal(bid(bob, 10, "b"), salt(1), 250); !errors.Is(err, ErrNoCommit) {
+		t.Fatalf("reveal without commit = %v, want ErrNoCommit", err)
+	}
+	if err := book.Reveal(bid(alice, 11, "a"), salt(1), 250); !errors.Is(err, ErrCommitMismatch) {
+		t.Fatalf("reveal with other amount = %v, want ErrCommitMismatch", err)
+	}
+	if err := book.Reveal(a, salt(2), 250); !errors.Is(err, ErrCommitMismatch) {
+		t.Fatalf("reveal with other salt = %v, want ErrCommitMismatch", err)
+	}
+	if _, err := book.Outcome(); !errors.Is(err, ErrNoValidBids) {
+		t.Fatalf("outcome with no reveals = %v, want ErrNoValidBids", err)
+	}
+	if err := book.Reveal(a, salt(1), 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Reveal(a, salt(1), 300); !errors.Is(err, ErrDuplicateReveal) {
+		t.Fatalf("second reveal = %v, want ErrDuplicateReveal", err)
+	}
+
+	// Bids AuctionService could not record are invalid even when the commitment matches.
+	tooBig := Bid{Bidder: bob, Amount: new(big.Int).Add(MaxBidWei, big.NewInt(1))}
+	if err := book.Commit(bob, SealBid(auctionId, tooBig, salt(3)), 150); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Reveal(tooBig, salt(3), 250); !errors.Is(err, ErrInvalidBid) {
+		t.Fatalf("uint96 overflow = %v, want ErrInvalidBid", err)
+	}
+
+	out, err := book.Outcome()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Winner != alice || out.Revealed != 1 || out.Unrevealed != 1 {
+		t.Fatalf("outcome = %+v", out)
+	}
+}
+
+func TestSealBidBindsAuction(t *testing.T) {
+	a := bid(alice, 10, "a")
+	if SealBid(big.NewInt(1), a, salt(1)) == SealBid(big.NewInt(2), a, salt(1)) {
+		t.Fatal("commitment does not depend on auction id")
+	}
+}
+
+func TestNewWindow(t *testing.T) {
+	w, err := NewWindow(100, 300, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.InCommitPhase(w.RevealStart) || !w.InCommitPhase(w.RevealStart-1) || !w.InRevealPhase(300) {
+		t.Fatalf("phases overlap or leave a gap: %+v", w)
+	}
+	if _, err := NewWindow(100, 300, 201); !errors.Is(err, ErrInvalidWindow) {
+		t.Fatalf("reveal period covering the window = %v, want ErrInvalidWindow", err)
+	}
+	if _, err := NewWindow(300, 300, 0); !errors.Is(err, ErrInvalidWindow) {
+		t.Fatalf("empty window = %v, want ErrInvalidWindow", err)
+	}
+}
+
+func TestSignedDigestsMatchEIP712(t *testing.T) {
+	d := SigningDomain{ChainId: big.NewInt(31337), AuctionService: common.HexToAddress("0xa0c7")}
+	b := bid(alice, 42, "settle")
+	domain := apitypes.TypedDataDomain{
+		Name:              "RolaidSealedBid",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(31337),
+		VerifyingContract: d.AuctionService.Hex(),
+	}
+	types := apitypes.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+		"SealedBidCommit": {
+			{Name: "auctionId", Type: "uint256"},
+			{Name: "bidder", Type: "address"},
+			{Name: "commitment", Type: "bytes32"},
+			{Name: "time", Type: "uint64"},
+		},
+		"SealedBidReveal": {
+			{Name: "auctionId", Type: "uint256"},
+			{Name: "bidder", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+			{Name: "settlementData", Type: "bytes"},
+			{Name: "salt", Type: "bytes32"},
+			{Name: "time", Type: "uint64"},
+		},
+	}
+	sealed := SealBid(auctionId, b, salt(1))
+	for _, c := range []struct {
+		primary string
+		message apitypes.TypedDataMessage
+		got     common.Hash
+	}{
+		{"SealedBidCommit", apitypes.TypedDataMessage{
+			"auctionId": "7", "bidder": alice.Hex(), "commitment": sealed.Hex(), "time": "150",
+		}, CommitDigest(d, auctionId, alice, sealed, 150)},
+		{"SealedBidReveal", apitypes.TypedDataMessage{
+			"auctionId": "7", "bidder": alice.Hex(), "amount": "42", "settlementData": hexutil.Encode(b.SettlementData),
+			"salt": salt(1).Hex(), "time": "250",
+		}, RevealDigest(d, auctionId, b, salt(1), 250)},
+	} {
+		want, _, err := apitypes.TypedDataAndHash(apitypes.TypedData{Types: types, PrimaryType: c.primary, Domain: domain, Message: c.message})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.got != common.BytesToHash(want) {
+			t.Errorf("%s digest = %s, want %s", c.primary, c.got.Hex(), common.BytesToHash(want).Hex())
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	d := SigningDomain{ChainId: big.NewInt(1), AuctionService: common.HexToAddress("0xa0c7")}
+	digest := CommitDigest(d, auctionId, signer, salt(9), 150)
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(digest, sig, signer); err != nil {
+		t.Fatalf("0/1 recovery id: %v", err)
+	}
+	wallet := common.CopyBytes(sig)
+	wallet[64] += 27
+	if err := VerifySignature(digest, wallet, signer); err != nil {
+		t.Fatalf("27/28 recovery id: %v", err)
+	}
+
+	// A moved time, another domain or another bidder all fail.
+	for name, err := range map[string]error{
+		"time":   VerifySignature(CommitDigest(d, auctionId, signer, salt(9), 149), sig, signer),
+		"domain": VerifySignature(CommitDigest(SigningDomain{ChainId: big.NewInt(2), AuctionService: d.AuctionService}, auctionId, signer, salt(9), 150), sig, signer),
+		"signer": VerifySignature(digest, sig, alice),
+		"short":  VerifySignature(digest, sig[:64], signer),
+	} {
+		if !errors.Is(err, ErrBadSignature) {
+			t.Errorf("%s: err = %v, want ErrBadSignature", name, err)
+		}
+	}
+}
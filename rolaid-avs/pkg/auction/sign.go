@@ -0,0 +1,78 @@
+package auction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Bidders sign each commit and reveal as EIP-712 typed data, so the relay that collects
+// them can neither forge an entry for another bidder nor change the time a bidder signed.
+// The relay can still withhold entries, and a bidder vouches for its own times.
+var (
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	commitTypeHash = crypto.Keccak256Hash([]byte("SealedBidCommit(uint256 auctionId,address bidder,bytes32 commitment,uint64 time)"))
+	revealTypeHash = crypto.Keccak256Hash([]byte("SealedBidReveal(uint256 auctionId,address bidder,uint256 amount,bytes settlementData,bytes32 salt,uint64 time)"))
+
+	domainName    = crypto.Keccak256Hash([]byte("RolaidSealedBid"))
+	domainVersion = crypto.Keccak256Hash([]byte("1"))
+)
+
+// SigningDomain is the EIP-712 domain bids are signed under: the chain and the
+// AuctionService running the auction.
+type SigningDomain struct {
+	ChainId        *big.Int
+	AuctionService common.Address
+}
+
+func (d SigningDomain) separator() common.Hash {
+	chainId := common.BigToHash(d.ChainId)
+	return crypto.Keccak256Hash(domainTypeHash[:], domainName[:], domainVersion[:], chainId[:], common.LeftPadBytes(d.AuctionService[:], 32))
+}
+
+func (d SigningDomain) digest(structHash common.Hash) common.Hash {
+	sep := d.separator()
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, sep[:], structHash[:])
+}
+
+// CommitDigest is the EIP-712 digest a bidder signs to commit sealed at time at.
+func CommitDigest(d SigningDomain, auctionId *big.Int, bidder common.Address, sealed common.Hash, at uint64) common.Hash {
+	id := common.BigToHash(auctionId)
+	t := common.BigToHash(new(big.Int).SetUint64(at))
+	return d.digest(crypto.Keccak256Hash(commitTypeHash[:], id[:], common.LeftPadBytes(bidder[:], 32), sealed[:], t[:]))
+}
+
+// RevealDigest is the EIP-712 digest a bidder signs to reveal bid with salt at time at.
+func RevealDigest(d SigningDomain, auctionId *big.Int, bid Bid, salt common.Hash, at uint64) common.Hash {
+	id := common.BigToHash(auctionId)
+	amount := common.BigToHash(bid.Amount)
+	data := crypto.Keccak256Hash(bid.SettlementData)
+	t := common.BigToHash(new(big.Int).SetUint64(at))
+	return d.digest(crypto.Keccak256Hash(revealTypeHash[:], id[:], common.LeftPadBytes(bid.Bidder[:], 32), amount[:], data[:], salt[:], t[:]))
+}
+
+// VerifySignature checks that sig, a 65-byte [R || S || V] signature with V 0/1 or 27/28,
+// was made over digest by signer.
+func VerifySignature(digest common.Hash, sig []byte, signer common.Address) error {
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: %d bytes, want %d", ErrBadSignature, len(sig), crypto.SignatureLength)
+	}
+	rsv := common.CopyBytes(sig)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	r, s := new(big.Int).SetBytes(rsv[:32]), new(big.Int).SetBytes(rsv[32:64])
+	if !crypto.ValidateSignatureValues(rsv[64], r, s, true) {
+		return fmt.Errorf("%w: malformed", ErrBadSignature)
+	}
+	pub, err := crypto.SigToPub(digest[:], rsv)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != signer {
+		return fmt.Errorf("%w: signed by %s, not %s", ErrBadSignature, got.Hex(), signer.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package commitment builds the keccak256 commitments carried in task results.
+//
+// A commitment is keccak256(domain || len(p1) || p1 || len(p2) || p2 || ...) where domain
+// is keccak256 of a versioned tag and every length is a 4-byte big-endian prefix. The
+// domain keeps auction and insurance commitments from colliding, and the length prefixes
+// keep ("ab","c") and ("a","bc") apart. Parts are raw decoded bytes, never hex text.
+package commitment
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// DomainAuctionSettlement mirrors TaskResults.AUCTION_SETTLEMENT_DOMAIN in Solidity.
+	DomainAuctionSettlement = Domain("ROLAID_AUCTION_SETTLEMENT_V1")
+	// DomainInsurancePayout mirrors TaskResults.INSURANCE_PAYOUT_DOMAIN in Solidity.
+	DomainInsurancePayout = Domain("ROLAID_INSURANCE_PAYOUT_V1")
+	// DomainPayoutVector mirrors TaskResults.PAYOUT_VECTOR_DOMAIN in Solidity.
+	DomainPayoutVector = Domain("ROLAID_PAYOUT_VECTOR_V1")
+)
+
+// Domain derives a domain separator from a versioned tag.
+func Domain(tag string) common.Hash {
+	return crypto.Keccak256Hash([]byte(tag))
+}
+
+// Hash computes the domain-separated, length-prefixed keccak256 of parts.
+func Hash(domain common.Hash, parts ...[]byte) common.Hash {
+	h := crypto.NewKeccakState()
+	h.Write(domain[:])
+	for _, p := range parts {
+		if uint64(len(p)) > math.MaxUint32 {
+			panic(fmt.Sprintf("commitment: part of %d bytes exceeds length prefix", len(p)))
+		}
+		h.Write(uint32Bytes(uint32(len(p))))
+		h.Write(p)
+	}
+	var out common.Hash
+	h.Read(out[:])
+	return out
+}
+
+// SettlementHash matches AuctionService.submitSettlement, which stores keccak256(settlementData).
+func SettlementHash(settlementData []byte) common.Hash {
+	return crypto.Keccak256Hash(settlementData)
+}
+
+// AuctionSettlement commits to an auction outcome. It only depends on values that can be
+// read back from chain, so a verifier can recompute it from the auction's settlementHash.
+func AuctionSettlement(auctionId *big.Int, poolId, oracleUpdateId, settlementHash common.Hash) common.Hash {
+	id := common.BigToHash(auctionId)
+	return Hash(DomainAuctionSettlement, id[:], poolId[:], oracleUpdateId[:], settlementHash[:])
+}
+
+// VerifyAuctionSettlement reports whether commitment matches an auction whose settlement
+// landed onchain with settlementHash.
+func VerifyAuctionSettlement(commitment common.Hash, auctionId *big.Int, poolId, oracleUpdateId, settlementHash common.Hash) bool {
+	return AuctionSettlement(auctionId, poolId, oracleUpdateId, settlementHash) == commitment
+}
+
+// InsurancePayout commits to the inputs of an insurance payout. Events are prefixed by
+// their count so the event list cannot be confused with neighbouring parts.
+func InsurancePayout(policyBatchId string, events []string, seed uint64, amountWei *big.Int) common.Hash {
+	parts := make([][]byte, 0, len(events)+4)
+	parts = append(parts, []byte(policyBatchId), uint32Bytes(uint32(len(events))))
+	for _, e := range events {
+		parts = append(parts, []byte(e))
+	}
+	var seedBytes [8]byte
+	binary.BigEndian.PutUint64(seedBytes[:], seed)
+	amount := common.BigToHash(amountWei)
+	parts = append(parts, seedBytes[:], amount[:])
+	return Hash(DomainInsurancePayout, parts...)
+}
+
+// PayoutVector commits to a payout vector: its length, then each beneficiary followed by
+// its 32-byte amount. TaskResults.payoutVectorCommitment recomputes it onchain.
+func PayoutVector(beneficiaries []common.Address, amounts []*big.Int) (common.Hash, error) {
+	if len(beneficiaries) != len(amounts) {
+		return common.Hash{}, fmt.Errorf("commitment: %d beneficiaries but %d amounts", len(beneficiaries), len(amounts))
+	}
+	parts := make([][]byte, 0, 1+2*len(amounts))
+	parts = append(parts, uint32Bytes(uint32(len(amounts))))
+	for i, b := range beneficiaries {
+		amount := common.BigToHash(amounts[i])
+		parts = append(parts, b.Bytes(), amount[:])
+	}
+	return Hash(DomainPayoutVector, parts...), nil
+}
+
+func uint32Bytes(v uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	return b[:]
+}
@@ -0,0 +1,64 @@
+package commitment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestHashIsLengthPrefixed(t *testing.T) {
+	d := Domain("TEST")
+	if Hash(d, []byte("ab"), []byte("c")) == Hash(d, []byte("a"), []byte("bc")) {
+		t.Fatalf("(ab, c) and (a, bc) must not collide")
+	}
+	if Hash(d, []byte("ab")) == Hash(Domain("OTHER"), []byte("ab")) {
+		t.Fatalf("different domains must not collide")
+	}
+}
+
+func TestInsurancePayoutEventsAreDelimited(t *testing.T) {
+	amount := big.NewInt(1000)
+	a := InsurancePayout("batch", []string{"a,b"}, 1, amount)
+	b := InsurancePayout("batch", []string{"a", "b"}, 1, amount)
+	if a == b {
+		t.Fatalf("event lists [a,b] and [a b] must not collide")
+	}
+}
+
+// TestAuctionSettlementGolden pins the commitment layout; TaskResults.auctionCommitment
+// in src/avs/TaskResults.sol must produce the same value (see test/avs/TaskResults.t.sol).
+func TestAuctionSettlementGolden(t *testing.T) {
+	settlementHash := SettlementHash([]byte{0xde, 0xad, 0xbe, 0xef})
+	if want := common.HexToHash("0xd4fd4e189132273036449fc9e11198c739161b4c0116a9a2dccdfa1c492006f1"); settlementHash != want {
+		t.Fatalf("settlement hash = %s, want %s", settlementHash.Hex(), want.Hex())
+	}
+
+	got := AuctionSettlement(big.NewInt(7), common.HexToHash("0x11"), common.HexToHash("0x22"), settlementHash)
+	want := common.HexToHash("0xdddfaf49d19a0ae0807b10c78d6b83166e1308c0e7d178ca6647820df22bf050")
+	if got != want {
+		t.Fatalf("commitment = %s, want %s", got.Hex(), want.Hex())
+	}
+	if !VerifyAuctionSettlement(got, big.NewInt(7), common.HexToHash("0x11"), common.HexToHash("0x22"), settlementHash) {
+		t.Fatalf("VerifyAuctionSettlement rejected a matching commitment")
+	}
+}
+
+// TestPayoutVectorGolden pins the vector layout; TaskResults.payoutVectorCommitment in
+// Solidity must produce the same hash.
+func TestPayoutVectorGolden(t *testing.T) {
+	got, err := PayoutVector(
+		[]common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xb2")},
+		[]*big.Int{big.NewInt(500), big.NewInt(500)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0x398e26ea5224690246d59e84f7e0c050cfc0032278c1335478c3f92cbc06becf"); got != want {
+		t.Fatalf("PayoutVector = %s, want %s", got.Hex(), want.Hex())
+	}
+
+	if _, err := PayoutVector([]common.Address{{}}, nil); err == nil {
+		t.Fatal("mismatched lengths accepted")
+	}
+}
@@ -0,0 +1,364 @@
+// Package eigenai calls EigenAI chat completions for the performer.
+//
+// Two auth modes are supported, matching apps/insurance: an EigenAI API key sent as
+// x-api-key to the EigenAI base URL, or a deTERMinal grant, where the wallet fetches a
+// grant message from the grant server, signs it (EIP-191) and sends the message, the
+// signature and the wallet address with each completion request. EigenAI is deterministic
+// for a fixed model, prompt and seed; VerifyDeterminism checks that by asking twice.
+package eigenai
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Endpoints and defaults from the EigenCloud and deTERMinal docs.
+const (
+	SepoliaBaseURL        = "https://eigenai-sepolia.eigencloud.xyz/v1"
+	MainnetBaseURL        = "https://eigenai.eigencloud.xyz/v1"
+	DefaultGrantServerURL = "https://determinal-api.eigenarcade.com"
+	DefaultModel          = "gpt-oss-120b-f16"
+	DefaultMaxTokens      = 200
+)
+
+// Defaults for zero Config durations.
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// Auth modes reported by Client.Mode.
+const (
+	ModeAPIKey = "api-key"
+	ModeGrant  = "grant"
+)
+
+var (
+	ErrNoAuth           = errors.New("eigenai: neither an API key nor a grant private key is set")
+	ErrEmptyResponse    = errors.New("eigenai: no content returned")
+	ErrNondeterministic = errors.New("eigenai: outputs differ for the same seed")
+)
+
+// StatusError is a non-2xx response from EigenAI or the grant server.
+type StatusError struct {
+	URL  string
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("eigenai: %s returned %d %s", e.URL, e.Code, strings.TrimSpace(e.Body))
+}
+
+// Temporary reports whether retrying may succeed: rate limits and server errors.
+func (e *StatusError) Temporary() bool {
+	return e.Code == http.StatusTooManyRequests || e.Code >= 500
+}
+
+// BaseURL returns the EigenAI base URL for env, "sepolia" (the default when empty) or
+// "mainnet".
+func BaseURL(env string) (string, error) {
+	switch strings.ToLower(env) {
+	case "", "sepolia":
+		return SepoliaBaseURL, nil
+	case "mainnet":
+		return MainnetBaseURL, nil
+	default:
+		return "", fmt.Errorf("eigenai: unknown env %q, want sepolia or mainnet", env)
+	}
+}
+
+// Config configures a Client. Zero values take the package defaults.
+type Config struct {
+	BaseURL        string // EigenAI API, used with APIKey; SepoliaBaseURL by default
+	GrantServerURL string // deTERMinal grant server, used with PrivateKey
+	Model          string // model used when a Request names none
+
+	// APIKey selects API-key auth. When it is empty, PrivateKey signs grant messages.
+	APIKey     string
+	PrivateKey *ecdsa.PrivateKey
+
+	MaxTokens int
+	// Timeout bounds each HTTP attempt; Retries is the number of extra attempts after a
+	// network error, rate limit or server error, spaced by RetryBackoff doubling each time.
+	Timeout      time.Duration
+	Retries      int
+	RetryBackoff time.Duration
+
+	HTTPClient *http.Client
+}
+
+// MaxComplete is the longest Complete can take under cfg: every attempt timing out, with
+// the backoff between them.
+func (cfg Config) MaxComplete() time.Duration {
+	timeout, backoff := cfg.Timeout, cfg.RetryBackoff
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	if backoff == 0 {
+		backoff = DefaultRetryBackoff
+	}
+	d := timeout
+	for i := 0; i < cfg.Retries; i++ {
+		d += backoff + timeout
+		backoff *= 2
+	}
+	return d
+}
+
+// Client is an EigenAI chat completions client. It is safe for concurrent use.
+type Client struct {
+	cfg    Config
+	wallet common.Address
+}
+
+// New checks cfg and fills in defaults.
+func New(cfg Config) (*Client, error) {
+	if cfg.APIKey == "" && cfg.PrivateKey == nil {
+		return nil, ErrNoAuth
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = SepoliaBaseURL
+	}
+	if cfg.GrantServerURL == "" {
+		cfg.GrantServerURL = DefaultGrantServerURL
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	cfg.GrantServerURL = strings.TrimRight(cfg.GrantServerURL, "/")
+	if cfg.Model == "" {
+		cfg.Model = DefaultModel
+	}
+	if cfg.MaxTokens == 0 {
+		cfg.MaxTokens = DefaultMaxTokens
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.RetryBackoff == 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.MaxTokens < 0 || cfg.Timeout < 0 || cfg.Retries < 0 || cfg.RetryBackoff < 0 {
+		return nil, fmt.Errorf("eigenai: max tokens, timeout, retries and backoff must not be negative")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	c := &Client{cfg: cfg}
+	if cfg.APIKey == "" {
+		c.wallet = crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey)
+	}
+	return c, nil
+}
+
+// Mode returns ModeAPIKey or ModeGrant.
+func (c *Client) Mode() string {
+	if c.cfg.APIKey != "" {
+		return ModeAPIKey
+	}
+	return ModeGrant
+}
+
+// Model returns the model used when a Request names none.
+func (c *Client) Model() string { return c.cfg.Model }
+
+// Request is one completion: a single user message answered by Model with Seed.
+type Request struct {
+	Model  string
+	Prompt string
+	Seed   uint64
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type completionRequest struct {
+	Model     string    `json:"model"`
+	MaxTokens int       `json:"max_tokens"`
+	Seed      uint64    `json:"seed"`
+	Messages  []message `json:"messages"`
+
+	// Grant auth only.
+	GrantMessage   string `json:"grantMessage,omitempty"`
+	GrantSignature string `json:"grantSignature,omitempty"`
+	WalletAddress  string `json:"walletAddress,omitempty"`
+}
+
+type completionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// Complete returns the model's answer to req.
+func (c *Client) Complete(ctx context.Context, req Request) (string, error) {
+	body := completionRequest{
+		Model:     req.Model,
+		MaxTokens: c.cfg.MaxTokens,
+		Seed:      req.Seed,
+		Messages:  []message{{Role: "user", Content: req.Prompt}},
+	}
+	if body.Model == "" {
+		body.Model = c.cfg.Model
+	}
+
+	var content string
+	err := c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		content, err = c.complete(ctx, body)
+		return err
+	})
+	return content, err
+}
+
+func (c *Client) complete(ctx context.Context, body completionRequest) (string, error) {
+	endpoint := c.cfg.BaseURL + "/chat/completions"
+	header := http.Header{}
+	if c.cfg.APIKey != "" {
+		header.Set("x-api-key", c.cfg.APIKey)
+	} else {
+		// Grants are fetched per request, as the grant server may rotate the message.
+		msg, err := c.grantMessage(ctx)
+		if err != nil {
+			return "", err
+		}
+		sig, err := crypto.Sign(accounts.TextHash([]byte(msg)), c.cfg.PrivateKey)
+		if err != nil {
+			return "", fmt.Errorf("eigenai: sign grant: %w", err)
+		}
+		sig[crypto.RecoveryIDOffset] += 27
+		body.GrantMessage, body.GrantSignature, body.WalletAddress = msg, hexutil.Encode(sig), c.wallet.Hex()
+		endpoint = c.cfg.GrantServerURL + "/api/chat/completions"
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	var resp completionResponse
+	if err := c.do(ctx, http.MethodPost, endpoint, header, payload, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		return "", ErrEmptyResponse
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+func (c *Client) grantMessage(ctx context.Context) (string, error) {
+	endpoint := c.cfg.GrantServerURL + "/message?address=" + url.QueryEscape(c.wallet.Hex())
+	var resp struct {
+		Message string `json:"message"`
+	}
+	if err := c.do(ctx, http.MethodGet, endpoint, nil, nil, &resp); err != nil {
+		return "", err
+	}
+	if resp.Message == "" {
+		return "", fmt.Errorf("eigenai: grant message missing")
+	}
+	return resp.Message, nil
+}
+
+// do sends one HTTP request bounded by the attempt timeout and decodes a JSON response.
+func (c *Client) do(ctx context.Context, method, endpoint string, header http.Header, body []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return &transportError{err}
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return &transportError{err}
+	}
+	if resp.StatusCode/100 != 2 {
+		// Strip the query, which carries the wallet address.
+		return &StatusError{URL: strings.SplitN(endpoint, "?", 2)[0], Code: resp.StatusCode, Body: string(data)}
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("eigenai: decode response: %w", err)
+	}
+	return nil
+}
+
+// retry runs fn until it succeeds, fails permanently or runs out of retries.
+func (c *Client) retry(ctx context.Context, fn func(context.Context) error) error {
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= c.cfg.Retries || !retryable(ctx, err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// transportError is a failed or timed-out HTTP exchange.
+type transportError struct{ err error }
+
+func (e *transportError) Error() string { return "eigenai: " + e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var status *StatusError
+	var transport *transportError
+	return errors.As(err, &status) && status.Temporary() || errors.As(err, &transport)
+}
+
+// VerifyDeterminism asks the same question twice and returns the answer only if both
+// agree.
+func (c *Client) VerifyDeterminism(ctx context.Context, req Request) (string, error) {
+	first, err := c.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	second, err := c.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if first != second {
+		return "", fmt.Errorf("%w (seed %d)", ErrNondeterministic, req.Seed)
+	}
+	return first, nil
+}
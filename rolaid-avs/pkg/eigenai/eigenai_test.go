@@ -0,0 +1,224 @@
+package eigenai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// stub is a local EigenAI and grant server. answer returns the completion for a request,
+// or a status code to fail with.
+type stub struct {
+	t      *testing.T
+	calls  atomic.Int32
+	answer func(call int32, req completionRequest) (string, int)
+	grant  string
+}
+
+func (s *stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/message":
+		json.NewEncoder(w).Encode(map[string]string{"message": s.grant + r.URL.Query().Get("address")})
+		return
+	case r.Method == http.MethodPost && (r.URL.Path == "/v1/chat/completions" || r.URL.Path == "/api/chat/completions"):
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	var req completionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.t.Errorf("decode request: %v", err)
+	}
+	if r.URL.Path == "/v1/chat/completions" && r.Header.Get("x-api-key") != "key-1" {
+		http.Error(w, "bad key", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path == "/api/chat/completions" {
+		if err := checkGrant(req); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	content, code := s.answer(s.calls.Add(1), req)
+	if code != 0 {
+		http.Error(w, "unavailable", code)
+		return
+	}
+	fmt.Fprintf(w, `{"choices": [{"message": {"role": "assistant", "content": %q}}]}`, content)
+}
+
+// checkGrant recovers the grant signer and checks it is the claimed wallet.
+func checkGrant(req completionRequest) error {
+	sig, err := hexutil.Decode(req.GrantSignature)
+	if err != nil || len(sig) != 65 {
+		return fmt.Errorf("bad signature")
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(req.GrantMessage)), sig)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != common.HexToAddress(req.WalletAddress) {
+		return fmt.Errorf("signer is not the wallet")
+	}
+	if !strings.HasSuffix(req.GrantMessage, req.WalletAddress) {
+		return fmt.Errorf("grant was issued to another wallet")
+	}
+	return nil
+}
+
+func echo(call int32, req completionRequest) (string, int) {
+	return fmt.Sprintf("%s/%d/%d: %s", req.Model, req.Seed, req.MaxTokens, req.Messages[0].Content), 0
+}
+
+func newStub(t *testing.T, answer func(int32, completionRequest) (string, int)) (*stub, *httptest.Server) {
+	s := &stub{t: t, answer: answer, grant: "grant for "}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func TestCompleteAPIKey(t *testing.T) {
+	_, srv := newStub(t, echo)
+	c, err := New(Config{BaseURL: srv.URL + "/v1/", APIKey: "key-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Mode() != ModeAPIKey || c.Model() != DefaultModel {
+		t.Fatalf("mode %s model %s", c.Mode(), c.Model())
+	}
+	got, err := c.Complete(context.Background(), Request{Prompt: "hello", Seed: 42})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if want := "gpt-oss-120b-f16/42/200: hello"; got != want {
+		t.Fatalf("Complete = %q, want %q", got, want)
+	}
+
+	bad, _ := New(Config{BaseURL: srv.URL + "/v1", APIKey: "key-2"})
+	var status *StatusError
+	if _, err := bad.Complete(context.Background(), Request{Prompt: "hello"}); !errors.As(err, &status) || status.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong key = %v, want 401", err)
+	}
+}
+
+func TestCompleteGrant(t *testing.T) {
+	_, srv := newStub(t, echo)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(Config{GrantServerURL: srv.URL, PrivateKey: key, Model: "other-model", MaxTokens: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Mode() != ModeGrant {
+		t.Fatalf("mode = %s, want grant", c.Mode())
+	}
+	got, err := c.Complete(context.Background(), Request{Prompt: "hi", Seed: 7})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if want := "other-model/7/50: hi"; got != want {
+		t.Fatalf("Complete = %q, want %q", got, want)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      int
+		failFirst int32
+		retries   int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "server error then success", code: http.StatusServiceUnavailable, failFirst: 2, retries: 2, wantCalls: 3},
+		{name: "rate limited then success", code: http.StatusTooManyRequests, failFirst: 1, retries: 1, wantCalls: 2},
+		{name: "retries exhausted", code: http.StatusBadGateway, failFirst: 5, retries: 2, wantCalls: 3, wantErr: true},
+		{name: "client error is not retried", code: http.StatusBadRequest, failFirst: 5, retries: 3, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, srv := newStub(t, func(call int32, req completionRequest) (string, int) {
+				if call <= tt.failFirst {
+					return "", tt.code
+				}
+				return "ok", 0
+			})
+			c, _ := New(Config{BaseURL: srv.URL + "/v1", APIKey: "key-1", Retries: tt.retries, RetryBackoff: time.Millisecond})
+			_, err := c.Complete(context.Background(), Request{Prompt: "p"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := s.calls.Load(); got != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c, _ := New(Config{BaseURL: srv.URL, APIKey: "key-1", Timeout: 20 * time.Millisecond, Retries: 1, RetryBackoff: time.Millisecond})
+	start := time.Now()
+	_, err := c.Complete(context.Background(), Request{Prompt: "p"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Fatalf("timed out after %s", time.Since(start))
+	}
+}
+
+func TestVerifyDeterminism(t *testing.T) {
+	_, srv := newStub(t, echo)
+	c, _ := New(Config{BaseURL: srv.URL + "/v1", APIKey: "key-1"})
+	if _, err := c.VerifyDeterminism(context.Background(), Request{Prompt: "p", Seed: 1}); err != nil {
+		t.Fatalf("VerifyDeterminism: %v", err)
+	}
+
+	_, srv = newStub(t, func(call int32, req completionRequest) (string, int) {
+		return fmt.Sprintf("answer %d", call), 0
+	})
+	c, _ = New(Config{BaseURL: srv.URL + "/v1", APIKey: "key-1"})
+	if _, err := c.VerifyDeterminism(context.Background(), Request{Prompt: "p", Seed: 1}); !errors.Is(err, ErrNondeterministic) {
+		t.Fatalf("VerifyDeterminism = %v, want ErrNondeterministic", err)
+	}
+}
+
+func TestNewAndBaseURL(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, ErrNoAuth) {
+		t.Fatalf("New without auth = %v", err)
+	}
+	if _, err := New(Config{APIKey: "k", Retries: -1}); err == nil {
+		t.Fatal("New accepted negative retries")
+	}
+	for env, want := range map[string]string{"": SepoliaBaseURL, "sepolia": SepoliaBaseURL, "MAINNET": MainnetBaseURL} {
+		if got, err := BaseURL(env); err != nil || got != want {
+			t.Errorf("BaseURL(%q) = %s, %v", env, got, err)
+		}
+	}
+	if _, err := BaseURL("holesky"); err == nil {
+		t.Error("BaseURL accepted an unknown env")
+	}
+}
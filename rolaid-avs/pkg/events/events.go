@@ -0,0 +1,445 @@
+// Package events follows contract logs on a chain, delivering them to a Handler in block
+// order and persisting a checkpoint after every delivered range.
+//
+// Logs are always read with eth_getLogs over block ranges, backfilling from the
+// checkpoint in chunks. A log subscription, when the endpoint supports one, only wakes
+// the listener early; polling every PollInterval keeps it going without one. Each range
+// is delivered whole before its checkpoint is saved, so a crash redelivers at most the
+// last range and handlers must be idempotent.
+//
+// The checkpoint keeps the hashes of recent blocks. When the checkpointed block is no
+// longer canonical, the listener walks those hashes back to the newest block still on the
+// chain, rolls the handler back to it and rescans from there.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Defaults for zero Config fields.
+const (
+	DefaultChunkSize    = 2000
+	DefaultPollInterval = 12 * time.Second
+	DefaultReorgDepth   = 64
+)
+
+// Client is the subset of an RPC client the listener reads. ethclient.Client implements it.
+type Client interface {
+	ethereum.LogFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Handler consumes logs. HandleLogs gets every log of a block range at once, in block
+// and log index order. Rollback undoes the effect of logs above block.
+type Handler interface {
+	HandleLogs(ctx context.Context, logs []types.Log) error
+	Rollback(ctx context.Context, block uint64) error
+}
+
+// Config configures a Listener.
+type Config struct {
+	Name      string // checkpoint key; listeners sharing a store need distinct names
+	Addresses []common.Address
+	Topics    []common.Hash // event ids matched in topic 0; empty matches every event
+	// StartBlock is the first block scanned when there is no checkpoint. Zero starts at
+	// the chain head, without backfill.
+	StartBlock uint64
+	// ChunkSize is the most blocks read per eth_getLogs call. Calls that fail are retried
+	// with half the range, so providers capping results still make progress.
+	ChunkSize    uint64
+	PollInterval time.Duration
+	// ReorgDepth is how many recent block hashes are kept. Reorgs deeper than this roll
+	// back to the oldest kept block.
+	ReorgDepth int
+	// OnError is told about failed syncs and subscription errors; the listener retries.
+	OnError func(error)
+}
+
+// BlockRef is a block number and hash.
+type BlockRef struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Checkpoint is the last block delivered and the hashes of recent blocks, oldest first.
+// A zero Hash skips the reorg check, as before the first range is read.
+type Checkpoint struct {
+	BlockRef
+	Recent []BlockRef `json:"recent"`
+}
+
+// CheckpointStore persists checkpoints by listener name.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under name, or nil if there is none.
+	Load(name string) (*Checkpoint, error)
+	Save(name string, cp *Checkpoint) error
+}
+
+// Listener delivers the logs matching Config to a Handler.
+type Listener struct {
+	client  Client
+	store   CheckpointStore
+	handler Handler
+	cfg     Config
+	cp      *Checkpoint
+}
+
+// New checks cfg and fills in defaults.
+func New(client Client, store CheckpointStore, handler Handler, cfg Config) (*Listener, error) {
+	if client == nil || store == nil || handler == nil {
+		return nil, fmt.Errorf("events: client, checkpoint store and handler are required")
+	}
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("events: listener name missing")
+	}
+	if len(cfg.Addresses) == 0 {
+		return nil, fmt.Errorf("events: no contract addresses")
+	}
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.ReorgDepth <= 0 {
+		cfg.ReorgDepth = DefaultReorgDepth
+	}
+	return &Listener{client: client, store: store, handler: handler, cfg: cfg}, nil
+}
+
+// Run syncs until ctx is done, on every poll tick and whenever the log subscription
+// reports activity.
+func (l *Listener) Run(ctx context.Context) error {
+	wake := make(chan struct{}, 1)
+	go l.subscribe(ctx, wake)
+
+	ticker := time.NewTicker(l.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := l.Sync(ctx); err != nil && ctx.Err() == nil {
+			l.report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-wake:
+		}
+	}
+}
+
+// Sync rolls back any reorg past the checkpoint and delivers the logs up to the head.
+func (l *Listener) Sync(ctx context.Context) error {
+	head, err := l.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("events: head: %w", err)
+	}
+	if err := l.load(head); err != nil {
+		return err
+	}
+	if err := l.reconcile(ctx); err != nil {
+		return err
+	}
+
+	chunk := l.cfg.ChunkSize
+	for from := l.cp.Number + 1; from <= head.Number.Uint64(); {
+		to := from + chunk - 1
+		if to > head.Number.Uint64() {
+			to = head.Number.Uint64()
+		}
+		// The range end's hash is read before its logs: if a reorg lands in between, the
+		// next sync finds the hash stale and rescans.
+		end, err := l.header(ctx, to)
+		if err != nil {
+			return err
+		}
+		logs, err := l.client.FilterLogs(ctx, l.query(from, to))
+		if err != nil {
+			if chunk > 1 && ctx.Err() == nil {
+				chunk /= 2
+				continue
+			}
+			return fmt.Errorf("events: logs %d-%d: %w", from, to, err)
+		}
+		sort.Slice(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+		if len(logs) > 0 {
+			if err := l.handler.HandleLogs(ctx, logs); err != nil {
+				return fmt.Errorf("events: handle logs %d-%d: %w", from, to, err)
+			}
+		}
+		l.advance(BlockRef{Number: to, Hash: end.Hash()}, logs)
+		if err := l.store.Save(l.cfg.Name, l.cp); err != nil {
+			return fmt.Errorf("events: save checkpoint: %w", err)
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// load reads the saved checkpoint, or starts one at StartBlock or the head.
+func (l *Listener) load(head *types.Header) error {
+	if l.cp != nil {
+		return nil
+	}
+	cp, err := l.store.Load(l.cfg.Name)
+	if err != nil {
+		return fmt.Errorf("events: load checkpoint: %w", err)
+	}
+	switch {
+	case cp != nil:
+	case l.cfg.StartBlock == 0:
+		cp = &Checkpoint{BlockRef: BlockRef{Number: head.Number.Uint64(), Hash: head.Hash()}}
+	default:
+		cp = &Checkpoint{BlockRef: BlockRef{Number: l.cfg.StartBlock - 1}}
+	}
+	l.cp = cp
+	return nil
+}
+
+// reconcile rolls the handler and checkpoint back to the newest recent block still on
+// the chain when the checkpointed block is not.
+func (l *Listener) reconcile(ctx context.Context) error {
+	if l.cp.Hash == (common.Hash{}) {
+		return nil
+	}
+	canonical, err := l.canonical(ctx, l.cp.BlockRef)
+	if err != nil || canonical {
+		return err
+	}
+
+	var ancestor BlockRef
+	found := false
+	for i := len(l.cp.Recent) - 1; i >= 0; i-- {
+		ref := l.cp.Recent[i]
+		if ref.Number >= l.cp.Number {
+			continue
+		}
+		ok, err := l.canonical(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if ok {
+			ancestor, found = ref, true
+			l.cp.Recent = l.cp.Recent[:i+1]
+			break
+		}
+	}
+	if !found {
+		// Deeper than the kept hashes: resume below the oldest one without a hash check.
+		oldest := l.cp.Number
+		if len(l.cp.Recent) > 0 {
+			oldest = l.cp.Recent[0].Number
+		}
+		if oldest > 0 {
+			oldest--
+		}
+		ancestor = BlockRef{Number: oldest}
+		l.cp.Recent = nil
+	}
+
+	if err := l.handler.Rollback(ctx, ancestor.Number); err != nil {
+		return fmt.Errorf("events: roll back to %d: %w", ancestor.Number, err)
+	}
+	l.cp.BlockRef = ancestor
+	if err := l.store.Save(l.cfg.Name, l.cp); err != nil {
+		return fmt.Errorf("events: save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// canonical reports whether ref is still on the chain.
+func (l *Listener) canonical(ctx context.Context, ref BlockRef) (bool, error) {
+	h, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(ref.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("events: header %d: %w", ref.Number, err)
+	}
+	return h.Hash() == ref.Hash, nil
+}
+
+func (l *Listener) header(ctx context.Context, number uint64) (*types.Header, error) {
+	h, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("events: header %d: %w", number, err)
+	}
+	return h, nil
+}
+
+// advance moves the checkpoint to end, keeping the hashes of the blocks with logs and of
+// end itself.
+func (l *Listener) advance(end BlockRef, logs []types.Log) {
+	for _, lg := range logs {
+		if lg.BlockNumber >= end.Number {
+			break
+		}
+		if n := len(l.cp.Recent); n == 0 || l.cp.Recent[n-1].Number < lg.BlockNumber {
+			l.cp.Recent = append(l.cp.Recent, BlockRef{Number: lg.BlockNumber, Hash: lg.BlockHash})
+		}
+	}
+	if n := len(l.cp.Recent); n == 0 || l.cp.Recent[n-1].Number < end.Number {
+		l.cp.Recent = append(l.cp.Recent, end)
+	}
+	if extra := len(l.cp.Recent) - l.cfg.ReorgDepth; extra > 0 {
+		l.cp.Recent = append([]BlockRef(nil), l.cp.Recent[extra:]...)
+	}
+	l.cp.BlockRef = end
+}
+
+func (l *Listener) query(from, to uint64) ethereum.FilterQuery {
+	q := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: l.cfg.Addresses,
+	}
+	if len(l.cfg.Topics) > 0 {
+		q.Topics = [][]common.Hash{l.cfg.Topics}
+	}
+	return q
+}
+
+// subscribe wakes Run whenever a matching log arrives, resubscribing after errors. It
+// gives up on endpoints without subscriptions, such as HTTP, leaving Run to poll.
+func (l *Listener) subscribe(ctx context.Context, wake chan<- struct{}) {
+	q := ethereum.FilterQuery{Addresses: l.cfg.Addresses}
+	if len(l.cfg.Topics) > 0 {
+		q.Topics = [][]common.Hash{l.cfg.Topics}
+	}
+	for {
+		logs := make(chan types.Log, 16)
+		sub, err := l.client.SubscribeFilterLogs(ctx, q, logs)
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			return
+		}
+		if err == nil {
+			err = l.forward(ctx, sub, logs, wake)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		l.report(fmt.Errorf("events: log subscription: %w", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.cfg.PollInterval):
+		}
+	}
+}
+
+func (l *Listener) forward(ctx context.Context, sub ethereum.Subscription, logs <-chan types.Log, wake chan<- struct{}) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case <-logs:
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (l *Listener) report(err error) {
+	if l.cfg.OnError != nil {
+		l.cfg.OnError(err)
+	}
+}
+
+var checkpointBucket = []byte("event_checkpoints")
+
+// BoltCheckpoints persists checkpoints in a bbolt database so they survive restarts.
+type BoltCheckpoints struct {
+	db *bolt.DB
+}
+
+// NewBoltCheckpoints creates the checkpoint bucket in db if needed.
+func NewBoltCheckpoints(db *bolt.DB) (*BoltCheckpoints, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(checkpointBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create checkpoint bucket: %w", err)
+	}
+	return &BoltCheckpoints{db: db}, nil
+}
+
+func (s *BoltCheckpoints) Load(name string) (*Checkpoint, error) {
+	var cp *Checkpoint
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(checkpointBucket).Get([]byte(name))
+		if v == nil {
+			return nil
+		}
+		cp = new(Checkpoint)
+		return json.Unmarshal(v, cp)
+	})
+	return cp, err
+}
+
+func (s *BoltCheckpoints) Save(name string, cp *Checkpoint) error {
+	v, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(checkpointBucket).Put([]byte(name), v)
+	})
+}
+
+// MemoryCheckpoints keeps checkpoints in memory only. Used when no data directory is
+// configured.
+type MemoryCheckpoints struct {
+	mu  sync.Mutex
+	cps map[string]Checkpoint
+}
+
+func NewMemoryCheckpoints() *MemoryCheckpoints {
+	return &MemoryCheckpoints{cps: make(map[string]Checkpoint)}
+}
+
+func (s *MemoryCheckpoints) Load(name string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp, ok := s.cps[name]
+	if !ok {
+		return nil, nil
+	}
+	cp.Recent = append([]BlockRef(nil), cp.Recent...)
+	return &cp, nil
+}
+
+func (s *MemoryCheckpoints) Save(name string, cp *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := *cp
+	saved.Recent = append([]BlockRef(nil), cp.Recent...)
+	s.cps[name] = saved
+	return nil
+}
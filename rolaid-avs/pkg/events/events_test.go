@@ -0,0 +1,284 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	contract = common.HexToAddress("0xc0")
+	topic    = common.HexToHash("0x01")
+)
+
+// chain is an in-memory chain. Each block may carry one log of topic from contract.
+type chain struct {
+	headers []*types.Header
+	logs    map[uint64]bool
+	// failRanges makes FilterLogs fail for ranges wider than this, when set.
+	failRanges uint64
+	calls      int
+}
+
+func newChain(n int, logs ...uint64) *chain {
+	c := &chain{logs: make(map[uint64]bool)}
+	for _, b := range logs {
+		c.logs[b] = true
+	}
+	c.extend(n, 0)
+	return c
+}
+
+// extend appends n blocks, tagged with fork so forks hash differently.
+func (c *chain) extend(n int, fork byte) {
+	for i := 0; i < n; i++ {
+		h := &types.Header{Number: big.NewInt(int64(len(c.headers))), Extra: []byte{fork}, Difficulty: big.NewInt(1)}
+		if len(c.headers) > 0 {
+			h.ParentHash = c.headers[len(c.headers)-1].Hash()
+		}
+		c.headers = append(c.headers, h)
+	}
+}
+
+// reorg replaces the blocks from number on with n new ones.
+func (c *chain) reorg(number uint64, n int, logs ...uint64) {
+	c.headers = c.headers[:number]
+	for b := range c.logs {
+		if b >= number {
+			delete(c.logs, b)
+		}
+	}
+	for _, b := range logs {
+		c.logs[b] = true
+	}
+	c.extend(n, 1)
+}
+
+func (c *chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.calls++
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if c.failRanges > 0 && to-from+1 > c.failRanges {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	var out []types.Log
+	// Newest first, to check the listener orders logs itself.
+	for b := to + 1; b > from; b-- {
+		if c.logs[b-1] {
+			h := c.headers[b-1]
+			out = append(out, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: b - 1, BlockHash: h.Hash()})
+		}
+	}
+	return out, nil
+}
+
+func (c *chain) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, rpc.ErrNotificationsUnsupported
+}
+
+// recorder keeps the blocks of the logs it was given, and rolls them back.
+type recorder struct {
+	blocks    []uint64
+	rollbacks []uint64
+}
+
+func (r *recorder) HandleLogs(ctx context.Context, logs []types.Log) error {
+	for _, l := range logs {
+		r.blocks = append(r.blocks, l.BlockNumber)
+	}
+	return nil
+}
+
+func (r *recorder) Rollback(ctx context.Context, block uint64) error {
+	r.rollbacks = append(r.rollbacks, block)
+	kept := r.blocks[:0]
+	for _, b := range r.blocks {
+		if b <= block {
+			kept = append(kept, b)
+		}
+	}
+	r.blocks = kept
+	return nil
+}
+
+func newListener(t *testing.T, c *chain, store CheckpointStore, h Handler, cfg Config) *Listener {
+	t.Helper()
+	cfg.Name = "test"
+	cfg.Addresses = []common.Address{contract}
+	cfg.Topics = []common.Hash{topic}
+	l, err := New(c, store, h, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestBackfillInChunks(t *testing.T) {
+	c := newChain(100, 3, 10, 11, 57, 99)
+	rec := &recorder{}
+	store := NewMemoryCheckpoints()
+	l := newListener(t, c, store, rec, Config{StartBlock: 5, ChunkSize: 10})
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []uint64{10, 11, 57, 99}; !reflect.DeepEqual(rec.blocks, want) {
+		t.Fatalf("delivered %v, want %v", rec.blocks, want)
+	}
+	if c.calls != 10 {
+		t.Errorf("FilterLogs calls = %d, want 10 chunks", c.calls)
+	}
+
+	// A new listener resumes from the saved checkpoint.
+	c.logs[102] = true
+	c.extend(5, 0)
+	l = newListener(t, c, store, rec, Config{StartBlock: 5, ChunkSize: 10})
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []uint64{10, 11, 57, 99, 102}; !reflect.DeepEqual(rec.blocks, want) {
+		t.Fatalf("delivered %v, want %v", rec.blocks, want)
+	}
+}
+
+func TestStartAtHead(t *testing.T) {
+	c := newChain(20, 5)
+	rec := &recorder{}
+	l := newListener(t, c, NewMemoryCheckpoints(), rec, Config{})
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	c.logs[21] = true
+	c.extend(3, 0)
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []uint64{21}; !reflect.DeepEqual(rec.blocks, want) {
+		t.Fatalf("delivered %v, want %v", rec.blocks, want)
+	}
+}
+
+func TestChunkHalvesOnError(t *testing.T) {
+	c := newChain(40, 1, 30)
+	c.failRanges = 8
+	rec := &recorder{}
+	l := newListener(t, c, NewMemoryCheckpoints(), rec, Config{StartBlock: 1, ChunkSize: 32})
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []uint64{1, 30}; !reflect.DeepEqual(rec.blocks, want) {
+		t.Fatalf("delivered %v, want %v", rec.blocks, want)
+	}
+}
+
+func TestReorg(t *testing.T) {
+	tests := []struct {
+		name         string
+		reorgAt      uint64
+		reorgDepth   int
+		newLogs      []uint64
+		wantRollback uint64
+		want         []uint64
+	}{
+		{
+			// Blocks 25 on are replaced; block 24 (a log block) is the common ancestor.
+			name:         "shallow",
+			reorgAt:      25,
+			newLogs:      []uint64{26},
+			wantRollback: 24,
+			want:         []uint64{10, 24, 26},
+		},
+		{
+			// Only the last 2 hashes are kept (blocks 27 and 29), both replaced: the
+			// listener resumes below the oldest.
+			name:         "deeper than kept hashes",
+			reorgAt:      26,
+			reorgDepth:   2,
+			newLogs:      []uint64{28},
+			wantRollback: 26,
+			want:         []uint64{10, 24, 28},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChain(30, 10, 24, 27)
+			rec := &recorder{}
+			l := newListener(t, c, NewMemoryCheckpoints(), rec, Config{StartBlock: 1, ChunkSize: 100, ReorgDepth: tt.reorgDepth})
+			if err := l.Sync(context.Background()); err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+
+			c.reorg(tt.reorgAt, 35-int(tt.reorgAt), tt.newLogs...)
+			if err := l.Sync(context.Background()); err != nil {
+				t.Fatalf("Sync after reorg: %v", err)
+			}
+			if len(rec.rollbacks) != 1 || rec.rollbacks[0] != tt.wantRollback {
+				t.Fatalf("rollbacks = %v, want [%d]", rec.rollbacks, tt.wantRollback)
+			}
+			if !reflect.DeepEqual(rec.blocks, tt.want) {
+				t.Fatalf("delivered %v, want %v", rec.blocks, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPollsWithoutSubscription(t *testing.T) {
+	c := newChain(10, 9)
+	rec := &recorder{}
+	l := newListener(t, c, NewMemoryCheckpoints(), rec, Config{StartBlock: 1, PollInterval: time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run = %v", err)
+	}
+	if want := []uint64{9}; !reflect.DeepEqual(rec.blocks, want) {
+		t.Fatalf("delivered %v, want %v", rec.blocks, want)
+	}
+}
+
+func TestBoltCheckpoints(t *testing.T) {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "state.db"), 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store, err := NewBoltCheckpoints(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp, err := store.Load("l1"); err != nil || cp != nil {
+		t.Fatalf("Load before Save = %v, %v", cp, err)
+	}
+	want := &Checkpoint{
+		BlockRef: BlockRef{Number: 9, Hash: common.HexToHash("0x09")},
+		Recent:   []BlockRef{{Number: 4, Hash: common.HexToHash("0x04")}, {Number: 9, Hash: common.HexToHash("0x09")}},
+	}
+	if err := store.Save("l1", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Load("l1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Load = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,621 @@
+// Package lifecycle follows each AuctionService auction through its states:
+//
+//	Created -> Authorized -> SettlementSubmitted -> Finalized
+//	Created or Authorized -> Expired
+//
+// The Tracker is fed the protocol events in chain order. Authorization by the hook is
+// optional; a settlement may arrive straight after creation. A settlement only counts as
+// Finalized once its block is final, by Confirmations or by the chain's finalized tag,
+// and its block hash is still canonical. An auction expires once a final block is past
+// its window and grace period without a settlement.
+//
+// A reorg rolls events back: a settlement that was reorged out returns the auction to
+// Authorized or Created and re-queues it for settlement.
+//
+// Performer tasks add what they saw to the same record: the sealed bids, the winner and
+// commitment they chose, and the task ids. An auction first seen by a task is tracked as
+// Created until its AuctionCreated event fills in the onchain window.
+package lifecycle
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Status is an auction's place in its lifecycle.
+type Status string
+
+const (
+	StatusCreated    Status = "created"
+	StatusAuthorized Status = "authorized"
+	StatusSubmitted  Status = "settlement_submitted"
+	StatusFinalized  Status = "finalized"
+	StatusExpired    Status = "expired"
+)
+
+// Valid reports whether s is one of the lifecycle statuses.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusCreated, StatusAuthorized, StatusSubmitted, StatusFinalized, StatusExpired:
+		return true
+	}
+	return false
+}
+
+// Settlement is a SettlementSubmitted event.
+type Settlement struct {
+	Bidder         common.Address `json:"bidder"`
+	BidAmount      *big.Int       `json:"bid_amount"`
+	SettlementHash common.Hash    `json:"settlement_hash"`
+	AppId          common.Hash    `json:"app_id"`
+	ImageDigest    common.Hash    `json:"image_digest"`
+	TxHash         common.Hash    `json:"tx_hash"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      common.Hash    `json:"block_hash"`
+}
+
+// Authorization is an LVRAuctionHook AuctionAuthorized event for the auction's oracle
+// update.
+type Authorization struct {
+	PoolId      common.Hash    `json:"pool_id"`
+	Winner      common.Address `json:"winner"`
+	Expiry      uint64         `json:"expiry"`
+	BlockNumber uint64         `json:"block_number"`
+}
+
+// Bid is a sealed bid seen in a task's bid book. Amount and RevealTime are set once the
+// bid is revealed.
+type Bid struct {
+	Bidder     common.Address `json:"bidder"`
+	Commitment common.Hash    `json:"commitment,omitempty"`
+	CommitTime uint64         `json:"commit_time,omitempty"`
+	Amount     *big.Int       `json:"amount,omitempty"`
+	RevealTime uint64         `json:"reveal_time,omitempty"`
+}
+
+// Result is the settlement a performer task chose.
+type Result struct {
+	TaskId string `json:"task_id"`
+	// Winner is zero when the task named the settlement directly, without a bid book.
+	Winner     common.Address `json:"winner"`
+	BidAmount  *big.Int       `json:"bid_amount"`
+	Commitment common.Hash    `json:"commitment"`
+	// LvrEstimate is the signed pool arbitrage estimate in token1 (pkg/lvr); zero when
+	// the task carried no oracle price.
+	LvrEstimate *big.Int `json:"lvr_estimate,omitempty"`
+}
+
+// TaskRef is a performer task that touched the auction, with its latest call.
+type TaskRef struct {
+	Id    string `json:"id"`
+	Op    string `json:"op"` // validate or handle
+	Time  uint64 `json:"time"`
+	Error string `json:"error,omitempty"`
+}
+
+// TaskReport is what a performer task learned about an auction. Zero fields are unknown.
+type TaskReport struct {
+	AuctionId      uint64
+	OracleUpdateId common.Hash
+	PoolId         common.Hash
+	Task           TaskRef
+	// Bids replaces the auction's bids when the task carried a bid book.
+	Bids   []Bid
+	Result *Result
+}
+
+// Auction is the tracked state of one auction.
+type Auction struct {
+	Id             uint64      `json:"id"`
+	OracleUpdateId common.Hash `json:"oracle_update_id"`
+	// PoolId comes from the hook's authorization or the auction's task.
+	PoolId    common.Hash `json:"pool_id"`
+	StartTime uint64      `json:"start_time"`
+	EndTime   uint64      `json:"end_time"`
+	// GracePeriod is AuctionService.submissionGracePeriod when the auction was seen.
+	GracePeriod uint64 `json:"grace_period"`
+	// CreatedBlock is the block of AuctionCreated; zero while the auction is only known
+	// from a task.
+	CreatedBlock uint64 `json:"created_block"`
+	Status       Status `json:"status"`
+
+	Bids   []Bid     `json:"bids,omitempty"`
+	Result *Result   `json:"result,omitempty"`
+	Tasks  []TaskRef `json:"tasks,omitempty"`
+
+	Authorization *Authorization `json:"authorization,omitempty"`
+	Settlement    *Settlement    `json:"settlement,omitempty"`
+	// FinalBlock is the final block that finalized or expired the auction.
+	FinalBlock uint64 `json:"final_block,omitempty"`
+	// Requeues counts settlements lost to reorgs.
+	Requeues int `json:"requeues,omitempty"`
+}
+
+// Deadline is the last block time at which AuctionService accepts a settlement.
+func (a *Auction) Deadline() uint64 {
+	return a.EndTime + a.GracePeriod
+}
+
+func (a *Auction) clone() Auction {
+	c := *a
+	c.Bids = make([]Bid, len(a.Bids))
+	for i, b := range a.Bids {
+		if b.Amount != nil {
+			b.Amount = new(big.Int).Set(b.Amount)
+		}
+		c.Bids[i] = b
+	}
+	if a.Result != nil {
+		r := *a.Result
+		if r.BidAmount != nil {
+			r.BidAmount = new(big.Int).Set(r.BidAmount)
+		}
+		if r.LvrEstimate != nil {
+			r.LvrEstimate = new(big.Int).Set(r.LvrEstimate)
+		}
+		c.Result = &r
+	}
+	c.Tasks = append([]TaskRef(nil), a.Tasks...)
+	if a.Authorization != nil {
+		auth := *a.Authorization
+		c.Authorization = &auth
+	}
+	if a.Settlement != nil {
+		s := *a.Settlement
+		if s.BidAmount != nil {
+			s.BidAmount = new(big.Int).Set(s.BidAmount)
+		}
+		c.Settlement = &s
+	}
+	return c
+}
+
+// unsettled returns the status an auction without a settlement has.
+func (a *Auction) unsettled() Status {
+	if a.Authorization != nil {
+		return StatusAuthorized
+	}
+	return StatusCreated
+}
+
+// Chain reads the headers finality is judged on. ethclient.Client implements it.
+type Chain interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Store persists auctions by id.
+type Store interface {
+	Put(a *Auction) error
+	Delete(id uint64) error
+	// All returns every saved auction.
+	All() ([]*Auction, error)
+}
+
+// Config configures a Tracker.
+type Config struct {
+	// Confirmations is how many blocks, counting its own, make a block final. Zero uses
+	// the chain's finalized block.
+	Confirmations uint64
+	// OnChange is told of every status change, with the previous status and a copy of the
+	// auction. The previous status is empty for a new auction, and the auction's status is
+	// empty when a rollback drops it.
+	OnChange func(prev Status, a Auction)
+}
+
+// Tracker holds the state of every auction.
+type Tracker struct {
+	store Store
+	cfg   Config
+
+	mu       sync.Mutex
+	auctions map[uint64]*Auction
+}
+
+// New loads the saved auctions.
+func New(store Store, cfg Config) (*Tracker, error) {
+	saved, err := store.All()
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: load: %w", err)
+	}
+	t := &Tracker{store: store, cfg: cfg, auctions: make(map[uint64]*Auction, len(saved))}
+	for _, a := range saved {
+		t.auctions[a.Id] = a
+	}
+	return t, nil
+}
+
+// Get returns a copy of an auction.
+func (t *Tracker) Get(id uint64) (Auction, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.auctions[id]
+	if !ok {
+		return Auction{}, false
+	}
+	return a.clone(), true
+}
+
+// List returns copies of every auction, by id.
+func (t *Tracker) List() []Auction {
+	return t.Query(Filter{})
+}
+
+// Filter selects auctions. Zero fields match any auction.
+type Filter struct {
+	PoolId common.Hash
+	Status Status
+	// From and To select auctions whose window overlaps [From, To], in unix seconds.
+	From, To uint64
+}
+
+func (f *Filter) match(a *Auction) bool {
+	if f.PoolId != (common.Hash{}) && a.PoolId != f.PoolId {
+		return false
+	}
+	if f.Status != "" && a.Status != f.Status {
+		return false
+	}
+	if f.From != 0 && a.EndTime < f.From {
+		return false
+	}
+	if f.To != 0 && a.StartTime > f.To {
+		return false
+	}
+	return true
+}
+
+// Query returns copies of the auctions matching f, by id.
+func (t *Tracker) Query(f Filter) []Auction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := []Auction{}
+	for _, a := range t.auctions {
+		if f.match(a) {
+			out = append(out, a.clone())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out
+}
+
+// Created records an AuctionCreated event, filling in an auction known from a task.
+// Redelivered events are ignored.
+func (t *Tracker) Created(a Auction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if known, ok := t.auctions[a.Id]; ok {
+		if known.CreatedBlock != 0 {
+			return nil
+		}
+		known.OracleUpdateId = a.OracleUpdateId
+		known.StartTime, known.EndTime, known.GracePeriod = a.StartTime, a.EndTime, a.GracePeriod
+		known.CreatedBlock = a.CreatedBlock
+		return t.save(known, known.Status)
+	}
+	a.Status = StatusCreated
+	a.Bids, a.Result, a.Tasks = nil, nil, nil
+	a.Authorization, a.Settlement, a.FinalBlock, a.Requeues = nil, nil, 0, 0
+	t.auctions[a.Id] = &a
+	return t.save(&a, "")
+}
+
+// Task records what a performer task saw of an auction, starting a record for an auction
+// not yet seen onchain.
+func (t *Tracker) Task(r TaskReport) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var prev Status
+	a, ok := t.auctions[r.AuctionId]
+	if ok {
+		prev = a.Status
+	} else {
+		a = &Auction{Id: r.AuctionId, OracleUpdateId: r.OracleUpdateId, Status: StatusCreated}
+		t.auctions[r.AuctionId] = a
+	}
+	if a.PoolId == (common.Hash{}) {
+		a.PoolId = r.PoolId
+	}
+	if len(r.Bids) > 0 {
+		a.Bids = r.Bids
+	}
+	if r.Result != nil {
+		a.Result = r.Result
+	}
+	if r.Task.Id != "" {
+		a.Tasks = addTask(a.Tasks, r.Task)
+	}
+	return t.save(a, prev)
+}
+
+// maxTasks bounds the task refs kept per auction; the oldest are dropped.
+const maxTasks = 32
+
+// addTask updates the task's ref, moving it to the end.
+func addTask(tasks []TaskRef, ref TaskRef) []TaskRef {
+	out := tasks[:0:0]
+	for _, t := range tasks {
+		if t.Id != ref.Id {
+			out = append(out, t)
+		}
+	}
+	out = append(out, ref)
+	if len(out) > maxTasks {
+		out = out[len(out)-maxTasks:]
+	}
+	return out
+}
+
+// Authorized records an AuctionAuthorized event against the auctions of its oracle
+// update. An auction already settled keeps its status.
+func (t *Tracker) Authorized(oracleUpdateId common.Hash, auth Authorization) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, a := range t.auctions {
+		if a.OracleUpdateId != oracleUpdateId || a.Authorization != nil {
+			continue
+		}
+		prev := a.Status
+		a.Authorization = &auth
+		if a.PoolId == (common.Hash{}) {
+			a.PoolId = auth.PoolId
+		}
+		if a.Status == StatusCreated {
+			a.Status = StatusAuthorized
+		}
+		if err := t.save(a, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Submitted records a SettlementSubmitted event. An auction expired by its deadline can
+// still take a settlement the listener delivers late.
+func (t *Tracker) Submitted(id uint64, s Settlement) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.auctions[id]
+	if !ok {
+		return fmt.Errorf("lifecycle: settlement for unknown auction %d", id)
+	}
+	if a.Settlement != nil && a.Settlement.TxHash == s.TxHash {
+		return nil
+	}
+	prev := a.Status
+	a.Settlement = &s
+	a.Status = StatusSubmitted
+	a.FinalBlock = 0
+	return t.save(a, prev)
+}
+
+// Rollback undoes the events above block. Auctions created above it are dropped, unless
+// a task touched them, and a settlement above it is removed and its auction re-queued.
+func (t *Tracker) Rollback(block uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, a := range t.auctions {
+		if a.CreatedBlock > block && len(a.Tasks) == 0 {
+			delete(t.auctions, id)
+			if err := t.store.Delete(id); err != nil {
+				return fmt.Errorf("lifecycle: delete auction %d: %w", id, err)
+			}
+			if t.cfg.OnChange != nil {
+				dropped := a.clone()
+				dropped.Status = ""
+				t.cfg.OnChange(a.Status, dropped)
+			}
+			continue
+		}
+		prev := a.Status
+		changed := false
+		if a.CreatedBlock > block {
+			a.CreatedBlock = 0
+			changed = true
+		}
+		if a.Settlement != nil && a.Settlement.BlockNumber > block {
+			a.Settlement = nil
+			a.FinalBlock = 0
+			a.Requeues++
+			changed = true
+		}
+		if a.Authorization != nil && a.Authorization.BlockNumber > block {
+			a.Authorization = nil
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if a.Settlement == nil && a.Status != StatusExpired {
+			a.Status = a.unsettled()
+		}
+		if err := t.save(a, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Advance finalizes settlements and expires auctions against the latest final block.
+// A settlement whose block is no longer canonical is rolled back and re-queued.
+func (t *Tracker) Advance(ctx context.Context, chain Chain) error {
+	final, err := t.finalHeader(ctx, chain)
+	if err != nil || final == nil {
+		return err
+	}
+	finalNumber := final.Number.Uint64()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, a := range t.auctions {
+		prev := a.Status
+		switch a.Status {
+		case StatusSubmitted:
+			s := a.Settlement
+			if s.BlockNumber > finalNumber {
+				continue
+			}
+			h, err := chain.HeaderByNumber(ctx, new(big.Int).SetUint64(s.BlockNumber))
+			if err != nil {
+				return fmt.Errorf("lifecycle: header %d: %w", s.BlockNumber, err)
+			}
+			if h.Hash() != s.BlockHash {
+				a.Settlement = nil
+				a.Requeues++
+				a.Status = a.unsettled()
+			} else {
+				a.Status = StatusFinalized
+				a.FinalBlock = finalNumber
+			}
+		case StatusCreated, StatusAuthorized:
+			// The window of an auction only known from a task is not known.
+			if a.CreatedBlock == 0 || final.Time <= a.Deadline() {
+				continue
+			}
+			a.Status = StatusExpired
+			a.FinalBlock = finalNumber
+		default:
+			continue
+		}
+		if err := t.save(a, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalHeader returns the latest final block, or nil while there is none.
+func (t *Tracker) finalHeader(ctx context.Context, chain Chain) (*types.Header, error) {
+	if t.cfg.Confirmations == 0 {
+		h, err := chain.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		if err != nil {
+			return nil, fmt.Errorf("lifecycle: finalized block: %w", err)
+		}
+		return h, nil
+	}
+	head, err := chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: head: %w", err)
+	}
+	if head.Number.Uint64()+1 < t.cfg.Confirmations {
+		return nil, nil
+	}
+	number := head.Number.Uint64() + 1 - t.cfg.Confirmations
+	if number == head.Number.Uint64() {
+		return head, nil
+	}
+	h, err := chain.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: header %d: %w", number, err)
+	}
+	return h, nil
+}
+
+// save persists a and reports a status change.
+func (t *Tracker) save(a *Auction, prev Status) error {
+	if err := t.store.Put(a); err != nil {
+		return fmt.Errorf("lifecycle: save auction %d: %w", a.Id, err)
+	}
+	if a.Status != prev && t.cfg.OnChange != nil {
+		t.cfg.OnChange(prev, a.clone())
+	}
+	return nil
+}
+
+var auctionBucket = []byte("auction_lifecycle")
+
+func auctionKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+// BoltStore persists auctions in a bbolt database, as JSON keyed by big-endian id.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore creates the auction bucket in db if needed.
+func NewBoltStore(db *bolt.DB) (*BoltStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(auctionBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create lifecycle bucket: %w", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Put(a *Auction) error {
+	v, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(auctionBucket).Put(auctionKey(a.Id), v)
+	})
+}
+
+func (s *BoltStore) Delete(id uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(auctionBucket).Delete(auctionKey(id))
+	})
+}
+
+func (s *BoltStore) All() ([]*Auction, error) {
+	var out []*Auction
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(auctionBucket).ForEach(func(k, v []byte) error {
+			var a Auction
+			if err := json.Unmarshal(v, &a); err != nil {
+				return fmt.Errorf("auction %x: %w", k, err)
+			}
+			out = append(out, &a)
+			return nil
+		})
+	})
+	return out, err
+}
+
+// MemoryStore keeps auctions in memory, for tests and for running without a data dir.
+type MemoryStore struct {
+	mu       sync.Mutex
+	auctions map[uint64]Auction
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{auctions: make(map[uint64]Auction)}
+}
+
+func (s *MemoryStore) Put(a *Auction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auctions[a.Id] = a.clone()
+	return nil
+}
+
+func (s *MemoryStore) Delete(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.auctions, id)
+	return nil
+}
+
+func (s *MemoryStore) All() ([]*Auction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*Auction, 0, len(s.auctions))
+	for _, a := range s.auctions {
+		c := a.clone()
+		out = append(out, &c)
+	}
+	return out, nil
+}
@@ -0,0 +1,360 @@
+package lifecycle
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+// chain serves headers by number. Each header's Extra holds its fork, so replacing a
+// block changes its hash.
+type chain struct {
+	headers   map[uint64]*types.Header
+	head      uint64
+	finalized uint64
+}
+
+func newChain() *chain {
+	return &chain{headers: make(map[uint64]*types.Header)}
+}
+
+// mine sets block n, at time n*12, on the given fork.
+func (c *chain) mine(n uint64, fork byte) common.Hash {
+	h := &types.Header{Number: new(big.Int).SetUint64(n), Time: n * 12, Extra: []byte{fork}, Difficulty: big.NewInt(0)}
+	c.headers[n] = h
+	if n > c.head {
+		c.head = n
+	}
+	return h.Hash()
+}
+
+func (c *chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	n := c.head
+	if number != nil {
+		if number.Int64() == int64(rpc.FinalizedBlockNumber) {
+			n = c.finalized
+		} else {
+			n = number.Uint64()
+		}
+	}
+	return c.headers[n], nil
+}
+
+type change struct {
+	prev, next Status
+}
+
+func hasChange(changes []change, c change) bool {
+	for _, got := range changes {
+		if got == c {
+			return true
+		}
+	}
+	return false
+}
+
+func newTracker(t *testing.T, store Store, confirmations uint64) (*Tracker, *[]change) {
+	t.Helper()
+	var changes []change
+	tr, err := New(store, Config{
+		Confirmations: confirmations,
+		OnChange:      func(prev Status, a Auction) { changes = append(changes, change{prev, a.Status}) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr, &changes
+}
+
+func status(t *testing.T, tr *Tracker, id uint64) Status {
+	t.Helper()
+	a, ok := tr.Get(id)
+	if !ok {
+		t.Fatalf("auction %d not tracked", id)
+	}
+	return a.Status
+}
+
+var oracleUpdate = common.HexToHash("0x0a")
+
+func created(t *testing.T, tr *Tracker, id, block uint64) {
+	t.Helper()
+	err := tr.Created(Auction{Id: id, OracleUpdateId: oracleUpdate, StartTime: 0, EndTime: 240, GracePeriod: 60, CreatedBlock: block})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Finalize(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 10; n++ {
+		c.mine(n, 0)
+	}
+	tr, changes := newTracker(t, NewMemoryStore(), 3)
+	created(t, tr, 1, 2)
+	if err := tr.Authorized(oracleUpdate, Authorization{Winner: common.HexToAddress("0xb1"), BlockNumber: 4}); err != nil {
+		t.Fatal(err)
+	}
+	settlement := Settlement{TxHash: common.HexToHash("0x01"), BlockNumber: 9, BlockHash: c.headers[9].Hash(), BidAmount: big.NewInt(5)}
+	if err := tr.Submitted(1, settlement); err != nil {
+		t.Fatal(err)
+	}
+
+	// Head 10 with 3 confirmations makes block 8 final; the settlement in 9 is not.
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusSubmitted {
+		t.Fatalf("status = %s before confirmations", got)
+	}
+	c.mine(11, 0)
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := tr.Get(1)
+	if a.Status != StatusFinalized || a.FinalBlock != 9 {
+		t.Fatalf("auction = %+v", a)
+	}
+	want := []change{{"", StatusCreated}, {StatusCreated, StatusAuthorized}, {StatusAuthorized, StatusSubmitted}, {StatusSubmitted, StatusFinalized}}
+	if len(*changes) != len(want) {
+		t.Fatalf("changes = %v, want %v", *changes, want)
+	}
+	for i := range want {
+		if (*changes)[i] != want[i] {
+			t.Fatalf("changes = %v, want %v", *changes, want)
+		}
+	}
+}
+
+func Test_FinalizedTag(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 40; n++ {
+		c.mine(n, 0)
+	}
+	c.finalized = 8
+	tr, _ := newTracker(t, NewMemoryStore(), 0)
+	created(t, tr, 1, 2)
+	if err := tr.Submitted(1, Settlement{BlockNumber: 9, BlockHash: c.headers[9].Hash()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusSubmitted {
+		t.Fatalf("status = %s before the finalized tag reached the settlement", got)
+	}
+	c.finalized = 9
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusFinalized {
+		t.Fatalf("status = %s", got)
+	}
+}
+
+func Test_Expire(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 30; n++ {
+		c.mine(n, 0)
+	}
+	tr, _ := newTracker(t, NewMemoryStore(), 1)
+	created(t, tr, 1, 2)
+
+	// Deadline 300: block 25 (time 300) still accepts a settlement.
+	c.head = 25
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusCreated {
+		t.Fatalf("status = %s at the deadline", got)
+	}
+	c.head = 26
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusExpired {
+		t.Fatalf("status = %s past the deadline", got)
+	}
+
+	// A late delivery of a settlement mined in time still counts.
+	if err := tr.Submitted(1, Settlement{BlockNumber: 24, BlockHash: c.headers[24].Hash()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusFinalized {
+		t.Fatalf("status = %s", got)
+	}
+}
+
+func Test_Rollback(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 10; n++ {
+		c.mine(n, 0)
+	}
+	tr, changes := newTracker(t, NewMemoryStore(), 5)
+	created(t, tr, 1, 2)
+	created(t, tr, 2, 8)
+	if err := tr.Authorized(oracleUpdate, Authorization{BlockNumber: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Submitted(1, Settlement{BlockNumber: 7, BlockHash: c.headers[7].Hash()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tr.Rollback(6); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tr.Get(2); ok {
+		t.Fatal("auction created above the rollback block is still tracked")
+	}
+	a, _ := tr.Get(1)
+	if a.Status != StatusAuthorized || a.Settlement != nil || a.Requeues != 1 {
+		t.Fatalf("auction after rollback = %+v", a)
+	}
+	if !hasChange(*changes, change{StatusSubmitted, StatusAuthorized}) || !hasChange(*changes, change{StatusAuthorized, ""}) {
+		t.Fatalf("changes = %v", *changes)
+	}
+
+	if err := tr.Rollback(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(t, tr, 1); got != StatusCreated {
+		t.Fatalf("status = %s after the authorization was rolled back", got)
+	}
+}
+
+func Test_ReorgedSettlement(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 10; n++ {
+		c.mine(n, 0)
+	}
+	tr, _ := newTracker(t, NewMemoryStore(), 2)
+	created(t, tr, 1, 2)
+	if err := tr.Submitted(1, Settlement{BlockNumber: 8, BlockHash: c.headers[8].Hash()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Block 8 is replaced before the listener noticed.
+	c.mine(8, 1)
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := tr.Get(1)
+	if a.Status != StatusCreated || a.Settlement != nil || a.Requeues != 1 {
+		t.Fatalf("auction = %+v", a)
+	}
+}
+
+func Test_BoltStore(t *testing.T) {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "state.db"), 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, _ := newTracker(t, store, 1)
+	created(t, tr, 1, 2)
+	created(t, tr, 2, 3)
+	if err := tr.Submitted(1, Settlement{BidAmount: big.NewInt(7), BlockNumber: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Rollback(2); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, _ := newTracker(t, store, 1)
+	list := reloaded.List()
+	if len(list) != 1 || list[0].Id != 1 || list[0].Status != StatusCreated || list[0].Requeues != 1 {
+		t.Fatalf("reloaded = %+v", list)
+	}
+}
+
+func Test_Task(t *testing.T) {
+	c := newChain()
+	for n := uint64(1); n <= 40; n++ {
+		c.mine(n, 0)
+	}
+	tr, _ := newTracker(t, NewMemoryStore(), 1)
+	pool := common.HexToHash("0x01")
+	bob := common.HexToAddress("0xb2")
+	report := TaskReport{
+		AuctionId:      1,
+		OracleUpdateId: oracleUpdate,
+		PoolId:         pool,
+		Task:           TaskRef{Id: "0x01", Op: "validate"},
+		Bids:           []Bid{{Bidder: bob, Amount: big.NewInt(9)}},
+	}
+	if err := tr.Task(report); err != nil {
+		t.Fatal(err)
+	}
+	report.Task = TaskRef{Id: "0x02", Op: "handle"}
+	report.Bids = nil
+	report.Result = &Result{TaskId: "0x02", Winner: bob, BidAmount: big.NewInt(9), LvrEstimate: big.NewInt(4)}
+	if err := tr.Task(report); err != nil {
+		t.Fatal(err)
+	}
+	report.Task = TaskRef{Id: "0x01", Op: "handle", Error: "boom"}
+	report.Result = nil
+	if err := tr.Task(report); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only known from tasks: no window, so it never expires.
+	if err := tr.Advance(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := tr.Get(1)
+	if a.Status != StatusCreated || a.PoolId != pool || len(a.Bids) != 1 || a.Result == nil || a.Result.Winner != bob {
+		t.Fatalf("auction = %+v", a)
+	}
+	if len(a.Tasks) != 2 || a.Tasks[0].Id != "0x02" || a.Tasks[1].Error != "boom" {
+		t.Fatalf("tasks = %+v", a.Tasks)
+	}
+	a.Result.LvrEstimate.SetInt64(0)
+	if a, _ := tr.Get(1); a.Result.LvrEstimate.Int64() != 4 {
+		t.Fatalf("Get shares the result's estimate: %s", a.Result.LvrEstimate)
+	}
+
+	created(t, tr, 1, 5)
+	created(t, tr, 1, 6) // redelivered
+	created(t, tr, 2, 6)
+	if a, _ := tr.Get(1); a.CreatedBlock != 5 || a.Deadline() != 300 || len(a.Tasks) != 2 {
+		t.Fatalf("auction after AuctionCreated = %+v", a)
+	}
+	for _, tt := range []struct {
+		f    Filter
+		want int
+	}{
+		{Filter{}, 2},
+		{Filter{PoolId: pool}, 1},
+		{Filter{Status: StatusExpired}, 0},
+		{Filter{From: 241}, 0},
+		{Filter{From: 100, To: 200}, 2},
+	} {
+		if got := tr.Query(tt.f); len(got) != tt.want {
+			t.Errorf("Query(%+v) = %d auctions, want %d", tt.f, len(got), tt.want)
+		}
+	}
+
+	// A reorg drops the AuctionCreated but keeps the task history.
+	if err := tr.Rollback(4); err != nil {
+		t.Fatal(err)
+	}
+	if a, ok := tr.Get(1); !ok || a.CreatedBlock != 0 || len(a.Tasks) != 2 {
+		t.Fatalf("auction after rollback = %+v", a)
+	}
+	if _, ok := tr.Get(2); ok {
+		t.Fatal("auction without tasks kept after rollback")
+	}
+}
@@ -0,0 +1,405 @@
+// Package lvr estimates the loss-versus-rebalancing (LVR) an auction winner can capture:
+// the profit of the arbitrage that moves a Uniswap v4 pool's price to the oracle price.
+//
+// The estimate uses Uniswap's integer tick and sqrt-price math with the pool's rounding,
+// and charges the pool's swap fee, so the arbitrage stops where the fee eats the edge.
+// The swap crosses the pool's initialized ticks on the way, adding or removing each
+// tick's liquidityNet as Uniswap's swap loop does, so liquidity that ends between the
+// pool price and the oracle price is followed rather than assumed.
+//
+// Prices are token1 per token0 in raw units, as sqrtPriceX96 = sqrt(price) * 2^96.
+package lvr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxSwapFee is a swap fee of 100%, in pips (hundredths of a basis point).
+const MaxSwapFee uint32 = 1_000_000
+
+// Tick spacing bounds, from Uniswap v4 TickMath.
+const (
+	MinTickSpacing int32 = 1
+	MaxTickSpacing int32 = 32767
+)
+
+var (
+	ErrPoolNotInitialized = errors.New("pool not initialized")
+	// ErrTickSpacing reports a tick spacing that does not fit the pool's tick bitmap.
+	ErrTickSpacing = errors.New("tick spacing does not match pool")
+	// ErrLiquidity reports crossed ticks that would take the pool's liquidity below zero.
+	ErrLiquidity = errors.New("liquidity below zero")
+)
+
+// Pool is the swap state of a Uniswap v4 pool, as PoolManager stores it in slot0 and
+// liquidity.
+type Pool struct {
+	SqrtPriceX96 *big.Int
+	Tick         int32
+	// ProtocolFee packs the zeroForOne fee in its low 12 bits and the oneForZero fee in
+	// the next 12, in pips.
+	ProtocolFee uint32
+	LPFee       uint32 // pips
+	Liquidity   *big.Int
+}
+
+// Tick is an initialized tick and the liquidity that starts at it: liquidityNet is added
+// when the price crosses the tick upward and removed when it crosses downward.
+type Tick struct {
+	Index        int32
+	LiquidityNet *big.Int
+}
+
+// Estimate is the arbitrage that moves a pool to the oracle price.
+type Estimate struct {
+	// ZeroForOne is true when the arbitrage sells token0 into the pool (the pool prices
+	// token0 above the oracle).
+	ZeroForOne         bool
+	SqrtPriceX96       *big.Int // pool price before the arbitrage
+	OracleSqrtPriceX96 *big.Int
+	// TargetSqrtPriceX96 is where the arbitrage stops: the oracle price, less the swap fee.
+	TargetSqrtPriceX96 *big.Int
+	TargetTick         int32
+	Fee                uint32   // swap fee charged, pips
+	TicksCrossed       int      // initialized ticks the arbitrage crosses
+	AmountIn           *big.Int // including the fee
+	AmountOut          *big.Int
+	// Profit is the arbitrage profit valued at the oracle price, in token1; zero when the
+	// pool is within the fee of the oracle. Profit0 is the same in token0.
+	Profit  *big.Int
+	Profit0 *big.Int
+}
+
+// SwapFee returns the fee a swap in the given direction pays, in pips, as Uniswap v4
+// ProtocolFeeLibrary.calculateSwapFee: the protocol fee is taken first and the LP fee
+// from the rest.
+func SwapFee(protocolFee, lpFee uint32, zeroForOne bool) uint32 {
+	p := protocolFee >> 12 & 0xfff
+	if zeroForOne {
+		p = protocolFee & 0xfff
+	}
+	return p + lpFee - uint32(uint64(p)*uint64(lpFee)/uint64(MaxSwapFee))
+}
+
+// Compute estimates the arbitrage that moves pool to oracleSqrtPriceX96 and its profit.
+// ticks must hold every initialized tick between the pool price and the oracle price, in
+// ascending order (ReadTicks loads them); the swap crosses those it reaches.
+func Compute(pool Pool, ticks []Tick, oracleSqrtPriceX96 *big.Int) (*Estimate, error) {
+	if pool.SqrtPriceX96 == nil || pool.SqrtPriceX96.Sign() == 0 {
+		return nil, ErrPoolNotInitialized
+	}
+	if _, err := TickAtSqrtPrice(pool.SqrtPriceX96); err != nil {
+		return nil, fmt.Errorf("pool: %w", err)
+	}
+	if _, err := TickAtSqrtPrice(oracleSqrtPriceX96); err != nil {
+		return nil, fmt.Errorf("oracle: %w", err)
+	}
+	liquidity := new(big.Int)
+	if pool.Liquidity != nil {
+		liquidity.Set(pool.Liquidity)
+	}
+	sqrtP, sqrtQ := pool.SqrtPriceX96, oracleSqrtPriceX96
+	zeroForOne := sqrtQ.Cmp(sqrtP) < 0
+	fee := SwapFee(pool.ProtocolFee, pool.LPFee, zeroForOne)
+	e := &Estimate{
+		ZeroForOne:         zeroForOne,
+		SqrtPriceX96:       new(big.Int).Set(sqrtP),
+		OracleSqrtPriceX96: new(big.Int).Set(sqrtQ),
+		TargetSqrtPriceX96: new(big.Int).Set(sqrtP),
+		TargetTick:         pool.Tick,
+		Fee:                fee,
+		AmountIn:           new(big.Int),
+		AmountOut:          new(big.Int),
+		Profit:             new(big.Int),
+		Profit0:            new(big.Int),
+	}
+	if fee >= MaxSwapFee {
+		return e, nil
+	}
+
+	// Selling token0 pays out price * (1 - fee) per unit, so it pays until the pool price
+	// falls to oracle / (1 - fee); buying token0 costs price / (1 - fee), so it pays until
+	// the pool price rises to oracle * (1 - fee).
+	net, full := big.NewInt(int64(MaxSwapFee-fee)), big.NewInt(int64(MaxSwapFee))
+	target := new(big.Int).Mul(sqrtQ, sqrtQ)
+	if zeroForOne {
+		target.Mul(target, full).Quo(target, net)
+	} else {
+		target.Mul(target, net).Quo(target, full)
+	}
+	target.Sqrt(target)
+	if zeroForOne && target.Cmp(sqrtP) >= 0 || !zeroForOne && target.Cmp(sqrtP) <= 0 {
+		return e, nil
+	}
+	tick, err := TickAtSqrtPrice(target)
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+	e.TargetSqrtPriceX96, e.TargetTick = target, tick
+
+	// The swap loop of PoolManager: a step per initialized tick, each with amounts and fee
+	// as SwapMath.computeSwapStep for an exact-input swap that reaches the step's end.
+	// Going down the swap crosses ticks at or below the current one; going up, above it.
+	var crossing []Tick
+	if zeroForOne {
+		for i := len(ticks) - 1; i >= 0; i-- {
+			if ticks[i].Index <= pool.Tick {
+				crossing = append(crossing, ticks[i])
+			}
+		}
+	} else {
+		for _, t := range ticks {
+			if t.Index > pool.Tick {
+				crossing = append(crossing, t)
+			}
+		}
+	}
+	in, out := new(big.Int), new(big.Int)
+	cur := sqrtP
+	for i := 0; ; i++ {
+		end, cross := target, i < len(crossing)
+		if cross {
+			at, err := SqrtPriceAtTick(crossing[i].Index)
+			if err != nil {
+				return nil, err
+			}
+			if zeroForOne && at.Cmp(target) > 0 || !zeroForOne && at.Cmp(target) < 0 {
+				end = at
+			} else {
+				cross = false
+			}
+		}
+		var stepIn, stepOut *big.Int
+		if zeroForOne {
+			stepIn = Amount0Delta(end, cur, liquidity, true)
+			stepOut = Amount1Delta(end, cur, liquidity, false)
+		} else {
+			stepIn = Amount1Delta(cur, end, liquidity, true)
+			stepOut = Amount0Delta(cur, end, liquidity, false)
+		}
+		in.Add(in, stepIn).Add(in, divUp(new(big.Int).Mul(stepIn, big.NewInt(int64(fee))), net))
+		out.Add(out, stepOut)
+		if !cross {
+			break
+		}
+		if zeroForOne {
+			liquidity.Sub(liquidity, crossing[i].LiquidityNet)
+		} else {
+			liquidity.Add(liquidity, crossing[i].LiquidityNet)
+		}
+		if liquidity.Sign() < 0 {
+			return nil, fmt.Errorf("%w: crossing tick %d", ErrLiquidity, crossing[i].Index)
+		}
+		e.TicksCrossed++
+		cur = end
+	}
+	e.AmountIn, e.AmountOut = in, out
+
+	// Value both legs at the oracle price, rounding against the arbitrageur.
+	priceX192 := new(big.Int).Mul(sqrtQ, sqrtQ)
+	q192 := new(big.Int).Lsh(big.NewInt(1), 192)
+	if zeroForOne {
+		e.Profit.Sub(out, divUp(new(big.Int).Mul(in, priceX192), q192))
+	} else {
+		value := new(big.Int).Mul(out, priceX192)
+		e.Profit.Sub(value.Quo(value, q192), in)
+	}
+	if e.Profit.Sign() < 0 {
+		e.Profit.SetInt64(0)
+	}
+	e.Profit0.Mul(e.Profit, q192).Quo(e.Profit0, priceX192)
+	return e, nil
+}
+
+// PoolManager storage layout, from Uniswap v4 StateLibrary.
+var (
+	poolsSlot        = common.BigToHash(big.NewInt(6))
+	liquidityOffset  = big.NewInt(3)
+	ticksOffset      = big.NewInt(4)
+	tickBitmapOffset = big.NewInt(5)
+	extsload         = crypto.Keccak256([]byte("extsload(bytes32)"))[:4]
+	extsloadMany     = crypto.Keccak256([]byte("extsload(bytes32[])"))[:4]
+)
+
+// slotsPerCall bounds the slots ReadTicks reads in one extsload call.
+const slotsPerCall = 256
+
+// ReadPool reads poolId's slot0 and liquidity from a Uniswap v4 PoolManager through
+// extsload, at block (nil reads at the head).
+func ReadPool(ctx context.Context, caller bind.ContractCaller, poolManager common.Address, poolId common.Hash, block *big.Int) (*Pool, error) {
+	stateSlot := poolStateSlot(poolId)
+	slot0, err := readSlot(ctx, caller, poolManager, stateSlot, block)
+	if err != nil {
+		return nil, fmt.Errorf("read slot0: %w", err)
+	}
+	liquiditySlot := offsetSlot(stateSlot, liquidityOffset)
+	liquidity, err := readSlot(ctx, caller, poolManager, liquiditySlot, block)
+	if err != nil {
+		return nil, fmt.Errorf("read liquidity: %w", err)
+	}
+
+	// slot0: lpFee (24 bits) | protocolFee (24 bits) | tick (int24) | sqrtPriceX96 (160 bits)
+	word := slot0.Big()
+	field := func(shift, bits uint) uint64 {
+		v := new(big.Int).Rsh(word, shift)
+		return v.And(v, big.NewInt(1<<bits-1)).Uint64()
+	}
+	pool := &Pool{
+		SqrtPriceX96: new(big.Int).SetBytes(slot0[12:]),
+		Tick:         int32(field(160, 24)<<8) >> 8,
+		ProtocolFee:  uint32(field(184, 24)),
+		LPFee:        uint32(field(208, 24)),
+		Liquidity:    new(big.Int).SetBytes(liquidity[16:]),
+	}
+	if pool.SqrtPriceX96.Sign() == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPoolNotInitialized, poolId)
+	}
+	return pool, nil
+}
+
+// ReadTicks reads poolId's initialized ticks in [lower, upper] from a Uniswap v4
+// PoolManager through extsload, in ascending order, at block (nil reads at the head).
+// PoolManager does not store the tick spacing, so the caller supplies the pool key's; a
+// tick the bitmap then marks without liquidity reports ErrTickSpacing.
+func ReadTicks(ctx context.Context, caller bind.ContractCaller, poolManager common.Address, poolId common.Hash, tickSpacing, lower, upper int32, block *big.Int) ([]Tick, error) {
+	if tickSpacing < MinTickSpacing || tickSpacing > MaxTickSpacing {
+		return nil, fmt.Errorf("%w: %d is out of range", ErrTickSpacing, tickSpacing)
+	}
+	stateSlot := poolStateSlot(poolId)
+	ticksSlot, bitmapSlot := offsetSlot(stateSlot, ticksOffset), offsetSlot(stateSlot, tickBitmapOffset)
+
+	// TickBitmap: bit (tick / tickSpacing) & 0xff of word (tick / tickSpacing) >> 8, both
+	// rounded toward negative infinity.
+	compress := func(tick int32) int32 {
+		c := tick / tickSpacing
+		if tick < 0 && tick%tickSpacing != 0 {
+			c--
+		}
+		return c
+	}
+	first, last := compress(lower)>>8, compress(upper)>>8
+	var wordSlots []common.Hash
+	for pos := first; pos <= last; pos++ {
+		wordSlots = append(wordSlots, mappingSlot(int64(pos), bitmapSlot))
+	}
+	words, err := readSlots(ctx, caller, poolManager, wordSlots, block)
+	if err != nil {
+		return nil, fmt.Errorf("read tick bitmap: %w", err)
+	}
+	var ticks []Tick
+	var infoSlots []common.Hash
+	for i, word := range words {
+		bits := word.Big()
+		for bit := 0; bit < 256; bit++ {
+			if bits.Bit(bit) == 0 {
+				continue
+			}
+			tick := ((first+int32(i))<<8 + int32(bit)) * tickSpacing
+			if tick < lower || tick > upper {
+				continue
+			}
+			ticks = append(ticks, Tick{Index: tick})
+			infoSlots = append(infoSlots, mappingSlot(int64(tick), ticksSlot))
+		}
+	}
+	infos, err := readSlots(ctx, caller, poolManager, infoSlots, block)
+	if err != nil {
+		return nil, fmt.Errorf("read ticks: %w", err)
+	}
+	// Tick info word: liquidityNet (int128) | liquidityGross (uint128)
+	for i, info := range infos {
+		if new(big.Int).SetBytes(info[16:]).Sign() == 0 {
+			return nil, fmt.Errorf("%w: tick %d is marked initialized but has no liquidity at spacing %d", ErrTickSpacing, ticks[i].Index, tickSpacing)
+		}
+		liquidityNet := new(big.Int).SetBytes(info[:16])
+		if liquidityNet.Bit(127) == 1 {
+			liquidityNet.Sub(liquidityNet, q128)
+		}
+		ticks[i].LiquidityNet = liquidityNet
+	}
+	return ticks, nil
+}
+
+// EstimateAt reads poolId and the initialized ticks up to the oracle price from
+// poolManager at block, and estimates the arbitrage that moves it to
+// oracleSqrtPriceX96. tickSpacing is the pool key's, as ReadTicks.
+func EstimateAt(ctx context.Context, caller bind.ContractCaller, poolManager common.Address, poolId common.Hash, tickSpacing int32, oracleSqrtPriceX96, block *big.Int) (*Estimate, error) {
+	pool, err := ReadPool(ctx, caller, poolManager, poolId, block)
+	if err != nil {
+		return nil, err
+	}
+	oracleTick, err := TickAtSqrtPrice(oracleSqrtPriceX96)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: %w", err)
+	}
+	lower, upper := pool.Tick, oracleTick
+	if lower > upper {
+		lower, upper = upper, lower
+	}
+	ticks, err := ReadTicks(ctx, caller, poolManager, poolId, tickSpacing, lower, upper, block)
+	if err != nil {
+		return nil, err
+	}
+	return Compute(*pool, ticks, oracleSqrtPriceX96)
+}
+
+func poolStateSlot(poolId common.Hash) common.Hash {
+	return crypto.Keccak256Hash(poolId.Bytes(), poolsSlot.Bytes())
+}
+
+func offsetSlot(slot common.Hash, offset *big.Int) common.Hash {
+	return common.BigToHash(new(big.Int).Add(slot.Big(), offset))
+}
+
+// mappingSlot is the slot of key in a Solidity mapping from a signed integer type.
+func mappingSlot(key int64, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(math.U256Bytes(big.NewInt(key)), slot.Bytes())
+}
+
+func readSlot(ctx context.Context, caller bind.ContractCaller, to common.Address, slot common.Hash, block *big.Int) (common.Hash, error) {
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{
+		To:   &to,
+		Data: append(append([]byte{}, extsload...), slot.Bytes()...),
+	}, block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(out) != 32 {
+		return common.Hash{}, fmt.Errorf("extsload returned %d bytes", len(out))
+	}
+	return common.BytesToHash(out), nil
+}
+
+// readSlots reads slots through extsload(bytes32[]), slotsPerCall at a time.
+func readSlots(ctx context.Context, caller bind.ContractCaller, to common.Address, slots []common.Hash, block *big.Int) ([]common.Hash, error) {
+	var words []common.Hash
+	for start := 0; start < len(slots); start += slotsPerCall {
+		batch := slots[start:min(start+slotsPerCall, len(slots))]
+		data := append([]byte{}, extsloadMany...)
+		data = append(data, common.BigToHash(big.NewInt(32)).Bytes()...)
+		data = append(data, common.BigToHash(big.NewInt(int64(len(batch)))).Bytes()...)
+		for _, slot := range batch {
+			data = append(data, slot.Bytes()...)
+		}
+		out, err := caller.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, block)
+		if err != nil {
+			return nil, err
+		}
+		if len(out) != 64+32*len(batch) {
+			return nil, fmt.Errorf("extsload returned %d bytes for %d slots", len(out), len(batch))
+		}
+		for i := range batch {
+			words = append(words, common.BytesToHash(out[64+32*i:96+32*i]))
+		}
+	}
+	return words, nil
+}
@@ -0,0 +1,294 @@
+package lvr
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSqrtPriceAtTick(t *testing.T) {
+	for tick, want := range map[int32]string{
+		MinTick: "4295128739",
+		MaxTick: "1461446703485210103287273052203988822378723970342",
+		0:       "79228162514264337593543950336",
+		50:      "79426470787362580746886972461",
+		-50:     "79030349367926598376800521322",
+	} {
+		got, err := SqrtPriceAtTick(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want {
+			t.Errorf("SqrtPriceAtTick(%d) = %s, want %s", tick, got, want)
+		}
+	}
+	// Every ratio, checked against floating point.
+	for bit := 0; bit < 20; bit++ {
+		for _, tick := range []int32{1 << bit, -(1 << bit)} {
+			if tick > MaxTick {
+				continue
+			}
+			got, _ := SqrtPriceAtTick(tick)
+			f, _ := new(big.Float).Quo(new(big.Float).SetInt(got), new(big.Float).SetInt(Q96)).Float64()
+			if want := math.Pow(1.0001, float64(tick)/2); math.Abs(f-want)/want > 1e-9 {
+				t.Errorf("SqrtPriceAtTick(%d) = %g, want %g", tick, f, want)
+			}
+		}
+	}
+	if _, err := SqrtPriceAtTick(MaxTick + 1); !errors.Is(err, ErrTickOutOfRange) {
+		t.Fatalf("err = %v, want ErrTickOutOfRange", err)
+	}
+}
+
+func TestTickAtSqrtPrice(t *testing.T) {
+	for _, tick := range []int32{MinTick, -200000, -50, -1, 0, 1, 50, 887271} {
+		p, _ := SqrtPriceAtTick(tick)
+		if got, err := TickAtSqrtPrice(p); err != nil || got != tick {
+			t.Errorf("TickAtSqrtPrice(at %d) = %d, %v", tick, got, err)
+		}
+		next := new(big.Int).Add(p, big.NewInt(1))
+		if tick != MinTick {
+			// Just below a tick's price is the tick before.
+			below := new(big.Int).Sub(p, big.NewInt(1))
+			if got, _ := TickAtSqrtPrice(below); got != tick-1 {
+				t.Errorf("TickAtSqrtPrice(below %d) = %d", tick, got)
+			}
+		}
+		if got, _ := TickAtSqrtPrice(next); got != tick {
+			t.Errorf("TickAtSqrtPrice(above %d) = %d", tick, got)
+		}
+	}
+	if _, err := TickAtSqrtPrice(MaxSqrtPrice); !errors.Is(err, ErrPriceOutOfRange) {
+		t.Fatalf("err = %v, want ErrPriceOutOfRange", err)
+	}
+}
+
+func TestAmountDeltas(t *testing.T) {
+	// 1:1 to 1.21:1 (sqrt 1.1) at 1e18 liquidity, as in Uniswap's SqrtPriceMath tests.
+	a := Q96
+	b := mustInt("87150978765690771352898345369")
+	l := mustInt("1000000000000000000")
+	if got := Amount0Delta(a, b, l, true); got.String() != "90909090909090910" {
+		t.Errorf("Amount0Delta up = %s", got)
+	}
+	if got := Amount0Delta(b, a, l, false); got.String() != "90909090909090909" {
+		t.Errorf("Amount0Delta down = %s", got)
+	}
+	if got := Amount1Delta(a, b, l, true); got.String() != "100000000000000000" {
+		t.Errorf("Amount1Delta up = %s", got)
+	}
+	if got := Amount1Delta(b, a, l, false); got.String() != "99999999999999999" {
+		t.Errorf("Amount1Delta down = %s", got)
+	}
+}
+
+func sqrtPrice(price float64) *big.Int {
+	f := new(big.Float).SetFloat64(math.Sqrt(price))
+	n, _ := f.Mul(f, new(big.Float).SetInt(Q96)).Int(nil)
+	return n
+}
+
+func TestCompute(t *testing.T) {
+	l := mustInt("1000000000000000000000")
+	pool := Pool{SqrtPriceX96: sqrtPrice(2000), Liquidity: l}
+	pool.Tick, _ = TickAtSqrtPrice(pool.SqrtPriceX96)
+
+	for _, oracle := range []float64{2010, 1990} {
+		e, err := Compute(pool, nil, sqrtPrice(oracle))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.ZeroForOne != (oracle < 2000) {
+			t.Fatalf("oracle %v: zeroForOne = %v", oracle, e.ZeroForOne)
+		}
+		if e.TargetSqrtPriceX96.Cmp(sqrtPrice(oracle)) != 0 {
+			t.Fatalf("oracle %v: fee-free target %s", oracle, e.TargetSqrtPriceX96)
+		}
+		// Fee-free LVR: L * (sqrtQ - sqrtP)^2 / sqrtP.
+		lf, _ := new(big.Float).SetInt(l).Float64()
+		d := math.Sqrt(oracle) - math.Sqrt(2000)
+		want := lf * d * d / math.Sqrt(2000)
+		got, _ := new(big.Float).SetInt(e.Profit).Float64()
+		if math.Abs(got-want)/want > 1e-6 {
+			t.Errorf("oracle %v: profit = %g, want %g", oracle, got, want)
+		}
+		got0, _ := new(big.Float).SetInt(e.Profit0).Float64()
+		if math.Abs(got0*oracle-got)/got > 1e-6 {
+			t.Errorf("oracle %v: profit0 = %g", oracle, got0)
+		}
+	}
+
+	// A 0.3% fee leaves a 0.2% move unprofitable and shrinks a 1% one.
+	pool.LPFee = 3000
+	if e, _ := Compute(pool, nil, sqrtPrice(2004)); e.Profit.Sign() != 0 || e.AmountIn.Sign() != 0 {
+		t.Fatalf("inside the fee: %+v", e)
+	}
+	free, _ := Compute(Pool{SqrtPriceX96: pool.SqrtPriceX96, Liquidity: l}, nil, sqrtPrice(2020))
+	e, _ := Compute(pool, nil, sqrtPrice(2020))
+	if e.Fee != 3000 || e.Profit.Sign() <= 0 || e.Profit.Cmp(free.Profit) >= 0 {
+		t.Fatalf("with fee %s, without %s", e.Profit, free.Profit)
+	}
+	if e.TargetSqrtPriceX96.Cmp(sqrtPrice(2020)) >= 0 || e.TargetTick < pool.Tick {
+		t.Fatalf("target %s tick %d", e.TargetSqrtPriceX96, e.TargetTick)
+	}
+
+	if _, err := Compute(Pool{}, nil, sqrtPrice(2000)); !errors.Is(err, ErrPoolNotInitialized) {
+		t.Fatalf("err = %v, want ErrPoolNotInitialized", err)
+	}
+	if _, err := Compute(pool, nil, big.NewInt(1)); !errors.Is(err, ErrPriceOutOfRange) {
+		t.Fatalf("err = %v, want ErrPriceOutOfRange", err)
+	}
+}
+
+func TestComputeCrossesTicks(t *testing.T) {
+	l := mustInt("1000000000000000000000")
+	half := new(big.Int).Rsh(l, 1)
+	pool := Pool{SqrtPriceX96: sqrtPrice(2000), Liquidity: l}
+	pool.Tick, _ = TickAtSqrtPrice(pool.SqrtPriceX96)
+	above, below := pool.Tick+50, pool.Tick-50
+	atAbove, _ := SqrtPriceAtTick(above)
+	atBelow, _ := SqrtPriceAtTick(below)
+	// Half the liquidity ends at each tick, and a tick past the oracle is not crossed.
+	ticks := []Tick{
+		{Index: below - 1000, LiquidityNet: big.NewInt(1)},
+		{Index: below, LiquidityNet: half},
+		{Index: above, LiquidityNet: new(big.Int).Neg(half)},
+		{Index: above + 1000, LiquidityNet: big.NewInt(-1)},
+	}
+
+	up := sqrtPrice(2020)
+	e, err := Compute(pool, ticks, up)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := new(big.Int).Add(Amount1Delta(pool.SqrtPriceX96, atAbove, l, true), Amount1Delta(atAbove, up, half, true))
+	out := new(big.Int).Add(Amount0Delta(pool.SqrtPriceX96, atAbove, l, false), Amount0Delta(atAbove, up, half, false))
+	if e.TicksCrossed != 1 || e.AmountIn.Cmp(in) != 0 || e.AmountOut.Cmp(out) != 0 {
+		t.Fatalf("up: crossed %d, in %s out %s, want in %s out %s", e.TicksCrossed, e.AmountIn, e.AmountOut, in, out)
+	}
+	if flat, _ := Compute(pool, nil, up); e.Profit.Cmp(flat.Profit) >= 0 {
+		t.Fatalf("up: profit %s not below constant-liquidity %s", e.Profit, flat.Profit)
+	}
+
+	down := sqrtPrice(1980)
+	e, err = Compute(pool, ticks, down)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in = new(big.Int).Add(Amount0Delta(atBelow, pool.SqrtPriceX96, l, true), Amount0Delta(down, atBelow, half, true))
+	out = new(big.Int).Add(Amount1Delta(atBelow, pool.SqrtPriceX96, l, false), Amount1Delta(down, atBelow, half, false))
+	if e.TicksCrossed != 1 || e.AmountIn.Cmp(in) != 0 || e.AmountOut.Cmp(out) != 0 {
+		t.Fatalf("down: crossed %d, in %s out %s, want in %s out %s", e.TicksCrossed, e.AmountIn, e.AmountOut, in, out)
+	}
+
+	// A pool sitting on an initialized tick crosses it first on the way down.
+	onTick := Pool{SqrtPriceX96: atBelow, Tick: below, Liquidity: l}
+	if e, err := Compute(onTick, ticks, sqrtPrice(1950)); err != nil || e.TicksCrossed != 1 {
+		t.Fatalf("on tick: %+v, %v", e, err)
+	}
+
+	overdrawn := []Tick{{Index: above, LiquidityNet: new(big.Int).Neg(new(big.Int).Add(l, big.NewInt(1)))}}
+	if _, err := Compute(pool, overdrawn, up); !errors.Is(err, ErrLiquidity) {
+		t.Fatalf("err = %v, want ErrLiquidity", err)
+	}
+}
+
+func TestSwapFee(t *testing.T) {
+	// 0.1% protocol fee one way, 0.05% the other, on a 0.3% LP fee.
+	protocol := uint32(500)<<12 | 1000
+	if got := SwapFee(protocol, 3000, true); got != 3997 {
+		t.Errorf("zeroForOne fee = %d", got)
+	}
+	if got := SwapFee(protocol, 3000, false); got != 3499 {
+		t.Errorf("oneForZero fee = %d", got)
+	}
+}
+
+// storage is a PoolManager answering extsload from a slot map.
+type storage map[common.Hash]common.Hash
+
+func (s storage) CodeAt(ctx context.Context, contract common.Address, block *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (s storage) CallContract(ctx context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	switch {
+	case len(call.Data) == 36 && string(call.Data[:4]) == string(extsload):
+		v := s[common.BytesToHash(call.Data[4:])]
+		return v.Bytes(), nil
+	case len(call.Data) >= 68 && string(call.Data[:4]) == string(extsloadMany):
+		out := append([]byte{}, call.Data[4:68]...)
+		for slot := call.Data[68:]; len(slot) >= 32; slot = slot[32:] {
+			v := s[common.BytesToHash(slot[:32])]
+			out = append(out, v.Bytes()...)
+		}
+		return out, nil
+	}
+	return nil, errors.New("unexpected call")
+}
+
+func TestReadPool(t *testing.T) {
+	poolId := common.HexToHash("0x01")
+	stateSlot := crypto.Keccak256Hash(poolId.Bytes(), common.BigToHash(big.NewInt(6)).Bytes())
+	sqrtP, _ := SqrtPriceAtTick(-887)
+	word := new(big.Int).Lsh(big.NewInt(3000), 208)
+	word.Or(word, new(big.Int).Lsh(big.NewInt(1000), 184))
+	word.Or(word, new(big.Int).Lsh(big.NewInt(1<<24-887), 160))
+	word.Or(word, sqrtP)
+	s := storage{
+		stateSlot: common.BigToHash(word),
+		common.BigToHash(new(big.Int).Add(stateSlot.Big(), big.NewInt(3))): common.BigToHash(big.NewInt(5e18)),
+	}
+
+	pool, err := ReadPool(context.Background(), s, common.Address{}, poolId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.SqrtPriceX96.Cmp(sqrtP) != 0 || pool.Tick != -887 || pool.ProtocolFee != 1000 || pool.LPFee != 3000 || pool.Liquidity.Int64() != 5e18 {
+		t.Fatalf("pool = %+v", pool)
+	}
+	if _, err := ReadPool(context.Background(), s, common.Address{}, common.HexToHash("0x02"), nil); !errors.Is(err, ErrPoolNotInitialized) {
+		t.Fatalf("err = %v, want ErrPoolNotInitialized", err)
+	}
+}
+
+func TestReadTicks(t *testing.T) {
+	poolId := common.HexToHash("0x01")
+	stateSlot := crypto.Keccak256Hash(poolId.Bytes(), common.BigToHash(big.NewInt(6)).Bytes())
+	ticksSlot := common.BigToHash(new(big.Int).Add(stateSlot.Big(), big.NewInt(4)))
+	bitmapSlot := common.BigToHash(new(big.Int).Add(stateSlot.Big(), big.NewInt(5)))
+	key := func(v int64) []byte { return common.BigToHash(new(big.Int).And(big.NewInt(v), maxUint256)).Bytes() }
+	info := func(gross, net int64) common.Hash {
+		word := new(big.Int).Lsh(new(big.Int).And(big.NewInt(net), new(big.Int).Sub(q128, big.NewInt(1))), 128)
+		return common.BigToHash(word.Or(word, big.NewInt(gross)))
+	}
+	// Spacing 10: tick -20 is bit 254 of word -1, tick 30 bit 3 of word 0, and tick
+	// 2000, bit 200, is out of range.
+	s := storage{
+		crypto.Keccak256Hash(key(-1), bitmapSlot.Bytes()): common.BigToHash(new(big.Int).Lsh(big.NewInt(1), 254)),
+		crypto.Keccak256Hash(key(0), bitmapSlot.Bytes()):  common.BigToHash(new(big.Int).SetBit(big.NewInt(1<<3), 200, 1)),
+		crypto.Keccak256Hash(key(-20), ticksSlot.Bytes()): info(7, 7),
+		crypto.Keccak256Hash(key(30), ticksSlot.Bytes()):  info(7, -7),
+	}
+
+	ticks, err := ReadTicks(context.Background(), s, common.Address{}, poolId, 10, -30, 40, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticks) != 2 || ticks[0].Index != -20 || ticks[0].LiquidityNet.Int64() != 7 || ticks[1].Index != 30 || ticks[1].LiquidityNet.Int64() != -7 {
+		t.Fatalf("ticks = %+v", ticks)
+	}
+	// At spacing 5 the same bits name ticks -10 and 15, which hold no liquidity.
+	if _, err := ReadTicks(context.Background(), s, common.Address{}, poolId, 5, -30, 40, nil); !errors.Is(err, ErrTickSpacing) {
+		t.Fatalf("err = %v, want ErrTickSpacing", err)
+	}
+	if _, err := ReadTicks(context.Background(), s, common.Address{}, poolId, 0, -30, 40, nil); !errors.Is(err, ErrTickSpacing) {
+		t.Fatalf("err = %v, want ErrTickSpacing", err)
+	}
+}
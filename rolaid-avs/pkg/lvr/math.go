@@ -0,0 +1,150 @@
+package lvr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Tick and sqrt price bounds, from Uniswap v4 TickMath.
+const (
+	MinTick int32 = -887272
+	MaxTick int32 = 887272
+)
+
+var (
+	// MinSqrtPrice is SqrtPriceAtTick(MinTick).
+	MinSqrtPrice = big.NewInt(4295128739)
+	// MaxSqrtPrice is SqrtPriceAtTick(MaxTick).
+	MaxSqrtPrice = mustInt("1461446703485210103287273052203988822378723970342")
+
+	// Q96 is the fixed-point scale of sqrtPriceX96 values.
+	Q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+
+	ErrTickOutOfRange  = errors.New("tick out of range")
+	ErrPriceOutOfRange = errors.New("sqrt price out of range")
+
+	q128       = new(big.Int).Lsh(big.NewInt(1), 128)
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// tickRatios[i] is 2^128 / sqrt(1.0001^(2^i)), the factors TickMath multiplies in for
+	// each set bit of the absolute tick.
+	tickRatios = []*big.Int{
+		mustInt("0xfffcb933bd6fad37aa2d162d1a594001"),
+		mustInt("0xfff97272373d413259a46990580e213a"),
+		mustInt("0xfff2e50f5f656932ef12357cf3c7fdcc"),
+		mustInt("0xffe5caca7e10e4e61c3624eaa0941cd0"),
+		mustInt("0xffcb9843d60f6159c9db58835c926644"),
+		mustInt("0xff973b41fa98c081472e6896dfb254c0"),
+		mustInt("0xff2ea16466c96a3843ec78b326b52861"),
+		mustInt("0xfe5dee046a99a2a811c461f1969c3053"),
+		mustInt("0xfcbe86c7900a88aedcffc83b479aa3a4"),
+		mustInt("0xf987a7253ac413176f2b074cf7815e54"),
+		mustInt("0xf3392b0822b70005940c7a398e4b70f3"),
+		mustInt("0xe7159475a2c29b7443b29c7fa6e889d9"),
+		mustInt("0xd097f3bdfd2022b8845ad8f792aa5825"),
+		mustInt("0xa9f746462d870fdf8a65dc1f90e061e5"),
+		mustInt("0x70d869a156d2a1b890bb3df62baf32f7"),
+		mustInt("0x31be135f97d08fd981231505542fcfa6"),
+		mustInt("0x9aa508b5b7a84e1c677de54f3e99bc9"),
+		mustInt("0x5d6af8dedb81196699c329225ee604"),
+		mustInt("0x2216e584f5fa1ea926041bedfe98"),
+		mustInt("0x48a170391f7dc42444e8fa2"),
+	}
+)
+
+func mustInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		panic(fmt.Sprintf("lvr: bad constant %s", s))
+	}
+	return n
+}
+
+// SqrtPriceAtTick returns sqrt(1.0001^tick) * 2^96, bit-exact with Uniswap v4
+// TickMath.getSqrtPriceAtTick.
+func SqrtPriceAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, fmt.Errorf("%w: %d", ErrTickOutOfRange, tick)
+	}
+	abs := tick
+	if abs < 0 {
+		abs = -abs
+	}
+	price := new(big.Int).Set(q128)
+	if abs&1 != 0 {
+		price.Set(tickRatios[0])
+	}
+	for i := 1; i < len(tickRatios); i++ {
+		if abs&(1<<i) != 0 {
+			price.Mul(price, tickRatios[i]).Rsh(price, 128)
+		}
+	}
+	if tick > 0 {
+		price.Quo(maxUint256, price)
+	}
+	// Q128.128 to Q64.96, rounding up so TickAtSqrtPrice inverts it.
+	rem := new(big.Int).And(price, big.NewInt(1<<32-1))
+	price.Rsh(price, 32)
+	if rem.Sign() != 0 {
+		price.Add(price, big.NewInt(1))
+	}
+	return price, nil
+}
+
+// TickAtSqrtPrice returns the greatest tick whose sqrt price is at most sqrtPriceX96, as
+// Uniswap v4 TickMath.getTickAtSqrtPrice does. sqrtPriceX96 must lie in
+// [MinSqrtPrice, MaxSqrtPrice).
+func TickAtSqrtPrice(sqrtPriceX96 *big.Int) (int32, error) {
+	if sqrtPriceX96.Cmp(MinSqrtPrice) < 0 || sqrtPriceX96.Cmp(MaxSqrtPrice) >= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrPriceOutOfRange, sqrtPriceX96)
+	}
+	// SqrtPriceAtTick is strictly increasing, so search it rather than approximate a log.
+	lo, hi := MinTick, MaxTick-1
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		p, _ := SqrtPriceAtTick(mid)
+		if p.Cmp(sqrtPriceX96) <= 0 {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+// Amount0Delta returns the token0 amount between two sqrt prices at liquidity, as
+// Uniswap v4 SqrtPriceMath.getAmount0Delta:
+// liquidity * 2^96 * (sqrtB - sqrtA) / (sqrtA * sqrtB).
+func Amount0Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtA.Cmp(sqrtB) > 0 {
+		sqrtA, sqrtB = sqrtB, sqrtA
+	}
+	num := new(big.Int).Lsh(liquidity, 96)
+	num.Mul(num, new(big.Int).Sub(sqrtB, sqrtA))
+	if !roundUp {
+		return num.Quo(num.Quo(num, sqrtB), sqrtA)
+	}
+	return divUp(divUp(num, sqrtB), sqrtA)
+}
+
+// Amount1Delta returns the token1 amount between two sqrt prices at liquidity, as
+// Uniswap v4 SqrtPriceMath.getAmount1Delta: liquidity * (sqrtB - sqrtA) / 2^96.
+func Amount1Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	diff := new(big.Int).Sub(sqrtB, sqrtA)
+	diff.Abs(diff)
+	num := diff.Mul(diff, liquidity)
+	if !roundUp {
+		return num.Quo(num, Q96)
+	}
+	return divUp(num, Q96)
+}
+
+// divUp returns ceil(a / b) for non-negative a and positive b.
+func divUp(a, b *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
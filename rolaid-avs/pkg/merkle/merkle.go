@@ -0,0 +1,230 @@
+// Package merkle builds Merkle distributions over (account, amount) leaves.
+//
+// Trees match OpenZeppelin's StandardMerkleTree with leaf encoding ["address", "uint256"]:
+// a leaf is keccak256(keccak256(abi.encode(account, amount))), leaves are sorted by hash,
+// pairs are hashed in sorted order, and the tree is laid out as a flat array with the
+// root at index 0. Proofs verify with OpenZeppelin's MerkleProof.verify, and Dump output
+// loads with StandardMerkleTree.load in @openzeppelin/merkle-tree.
+//
+// Amounts are cumulative per account: MerkleDistributor pays each claim the difference
+// between its leaf amount and what the account already claimed, so a new root can extend
+// an earlier distribution without invalidating it.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrEmpty            = errors.New("distribution has no leaves")
+	ErrDuplicateAccount = errors.New("duplicate account")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrUnknownAccount   = errors.New("account not in distribution")
+)
+
+// Leaf is one account's entry in a distribution.
+type Leaf struct {
+	Account common.Address
+	Amount  *big.Int
+}
+
+// LeafHash hashes a leaf the way StandardMerkleTree does for ["address", "uint256"].
+func LeafHash(l Leaf) common.Hash {
+	amount := common.BigToHash(l.Amount)
+	encoded := append(common.LeftPadBytes(l.Account[:], 32), amount[:]...)
+	return crypto.Keccak256Hash(crypto.Keccak256(encoded))
+}
+
+// hashPair hashes two nodes in sorted order, as MerkleProof does.
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a[:], b[:])
+}
+
+// Tree is a built distribution. It is immutable and safe for concurrent use.
+type Tree struct {
+	leaves []Leaf
+	nodes  []common.Hash
+	// index maps an account to its leaf's position in nodes.
+	index map[common.Address]int
+}
+
+// New builds the tree for leaves. Every account appears once and amounts fit a uint256;
+// zero amounts are allowed so a cumulative distribution can keep an account listed.
+func New(leaves []Leaf) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmpty
+	}
+	t := &Tree{
+		leaves: make([]Leaf, len(leaves)),
+		nodes:  make([]common.Hash, 2*len(leaves)-1),
+		index:  make(map[common.Address]int, len(leaves)),
+	}
+	type hashed struct {
+		hash common.Hash
+		i    int
+	}
+	sorted := make([]hashed, len(leaves))
+	for i, l := range leaves {
+		if l.Amount == nil || l.Amount.Sign() < 0 || l.Amount.BitLen() > 256 {
+			return nil, fmt.Errorf("%w for %s", ErrInvalidAmount, l.Account.Hex())
+		}
+		if _, ok := t.index[l.Account]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateAccount, l.Account.Hex())
+		}
+		t.index[l.Account] = -1
+		t.leaves[i] = Leaf{Account: l.Account, Amount: new(big.Int).Set(l.Amount)}
+		sorted[i] = hashed{hash: LeafHash(l), i: i}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return bytes.Compare(sorted[a].hash[:], sorted[b].hash[:]) < 0
+	})
+
+	// Leaves fill the array from the end, so the first sorted leaf is the last node.
+	for k, h := range sorted {
+		pos := len(t.nodes) - 1 - k
+		t.nodes[pos] = h.hash
+		t.index[t.leaves[h.i].Account] = pos
+	}
+	for i := len(t.nodes) - 1 - len(leaves); i >= 0; i-- {
+		t.nodes[i] = hashPair(t.nodes[2*i+1], t.nodes[2*i+2])
+	}
+	return t, nil
+}
+
+// Root returns the tree's root, the value committed to in task results and set on
+// MerkleDistributor.
+func (t *Tree) Root() common.Hash { return t.nodes[0] }
+
+// Leaves returns the leaves in the order they were given.
+func (t *Tree) Leaves() []Leaf {
+	out := make([]Leaf, len(t.leaves))
+	for i, l := range t.leaves {
+		out[i] = Leaf{Account: l.Account, Amount: new(big.Int).Set(l.Amount)}
+	}
+	return out
+}
+
+// Total sums the leaf amounts.
+func (t *Tree) Total() *big.Int {
+	sum := new(big.Int)
+	for _, l := range t.leaves {
+		sum.Add(sum, l.Amount)
+	}
+	return sum
+}
+
+// Proof returns the sibling path from account's leaf to the root.
+func (t *Tree) Proof(account common.Address) ([]common.Hash, error) {
+	i, ok := t.index[account]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, account.Hex())
+	}
+	var proof []common.Hash
+	for i > 0 {
+		sibling := i + 1
+		if i%2 == 0 {
+			sibling = i - 1
+		}
+		proof = append(proof, t.nodes[sibling])
+		i = (i - 1) / 2
+	}
+	return proof, nil
+}
+
+// Verify checks a proof for leaf against root, like MerkleProof.verify.
+func Verify(root common.Hash, leaf Leaf, proof []common.Hash) bool {
+	h := LeafHash(leaf)
+	for _, p := range proof {
+		h = hashPair(h, p)
+	}
+	return h == root
+}
+
+// Root builds the tree for leaves and returns its root, or the zero hash when there are
+// no leaves.
+func Root(leaves []Leaf) (common.Hash, error) {
+	if len(leaves) == 0 {
+		return common.Hash{}, nil
+	}
+	t, err := New(leaves)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return t.Root(), nil
+}
+
+// Claim is one account's entry in a claims file.
+type Claim struct {
+	Amount string        `json:"amount"` // decimal wei
+	Proof  []common.Hash `json:"proof"`
+}
+
+// ClaimsFile is what claimants and frontends read: the root, the total to fund the
+// distributor with and every account's amount and proof.
+type ClaimsFile struct {
+	Root   common.Hash              `json:"root"`
+	Total  string                   `json:"total"`
+	Claims map[common.Address]Claim `json:"claims"`
+}
+
+// Claims returns the tree's claims file.
+func (t *Tree) Claims() *ClaimsFile {
+	f := &ClaimsFile{
+		Root:   t.Root(),
+		Total:  t.Total().String(),
+		Claims: make(map[common.Address]Claim, len(t.leaves)),
+	}
+	for _, l := range t.leaves {
+		proof, _ := t.Proof(l.Account)
+		if proof == nil {
+			proof = []common.Hash{}
+		}
+		f.Claims[l.Account] = Claim{Amount: l.Amount.String(), Proof: proof}
+	}
+	return f
+}
+
+// StandardTree is the StandardMerkleTree.dump() format.
+type StandardTree struct {
+	Format       string          `json:"format"`
+	LeafEncoding []string        `json:"leafEncoding"`
+	Tree         []hexutil.Bytes `json:"tree"`
+	Values       []StandardValue `json:"values"`
+}
+
+// StandardValue is one leaf of a StandardTree: [account, amount] and its node index.
+type StandardValue struct {
+	Value     [2]string `json:"value"`
+	TreeIndex int       `json:"treeIndex"`
+}
+
+// Dump returns the tree in the format StandardMerkleTree.load reads.
+func (t *Tree) Dump() *StandardTree {
+	d := &StandardTree{
+		Format:       "standard-v1",
+		LeafEncoding: []string{"address", "uint256"},
+		Tree:         make([]hexutil.Bytes, len(t.nodes)),
+		Values:       make([]StandardValue, len(t.leaves)),
+	}
+	for i, n := range t.nodes {
+		d.Tree[i] = n.Bytes()
+	}
+	for i, l := range t.leaves {
+		d.Values[i] = StandardValue{
+			Value:     [2]string{l.Account.Hex(), l.Amount.String()},
+			TreeIndex: t.index[l.Account],
+		}
+	}
+	return d
+}
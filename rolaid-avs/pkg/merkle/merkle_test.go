@@ -0,0 +1,152 @@
+package merkle
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func leaves(kv ...interface{}) []Leaf {
+	var out []Leaf
+	for i := 0; i < len(kv); i += 2 {
+		out = append(out, Leaf{Account: common.HexToAddress(kv[i].(string)), Amount: big.NewInt(int64(kv[i+1].(int)))})
+	}
+	return out
+}
+
+// TestStandardTreeCompatible checks the example from the @openzeppelin/merkle-tree README.
+func TestStandardTreeCompatible(t *testing.T) {
+	a, _ := new(big.Int).SetString("5000000000000000000", 10)
+	b, _ := new(big.Int).SetString("2500000000000000000", 10)
+	tree, err := New([]Leaf{
+		{Account: common.HexToAddress("0x1111111111111111111111111111111111111111"), Amount: a},
+		{Account: common.HexToAddress("0x2222222222222222222222222222222222222222"), Amount: b},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Root().Hex(), "0xd4dee0beab2d53f2cc83e567171bd2820e49898130a22622b10ead383e90bd77"; got != want {
+		t.Fatalf("root = %s, want %s", got, want)
+	}
+}
+
+// TestTreeGolden pins the vector claimed in test/avs/MerkleDistributor.t.sol.
+func TestTreeGolden(t *testing.T) {
+	tree, err := New(leaves("0xa1", 100, "0xb2", 200, "0xc3", 300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Root().Hex(), "0x265e8ffdb13c337235907025a451c66d8fbc5eb898d8ce2aab834a67b5c939e0"; got != want {
+		t.Fatalf("root = %s, want %s", got, want)
+	}
+	proof, err := tree.Proof(common.HexToAddress("0xc3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof) != 1 || proof[0].Hex() != "0x7af74014198677f464d44df7c5cd9e245ba4e4f5e275d7170765d5f8d655e3d2" {
+		t.Fatalf("proof for 0xc3 = %v", proof)
+	}
+}
+
+func TestProofs(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		var ls []Leaf
+		for i := 0; i < n; i++ {
+			ls = append(ls, Leaf{Account: common.BigToAddress(big.NewInt(int64(i + 1))), Amount: big.NewInt(int64(i * 10))})
+		}
+		tree, err := New(ls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range ls {
+			proof, err := tree.Proof(l.Account)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Verify(tree.Root(), l, proof) {
+				t.Fatalf("%d leaves: proof for %s does not verify", n, l.Account.Hex())
+			}
+			wrong := Leaf{Account: l.Account, Amount: new(big.Int).Add(l.Amount, big.NewInt(1))}
+			if Verify(tree.Root(), wrong, proof) {
+				t.Fatalf("%d leaves: proof verified a wrong amount", n)
+			}
+		}
+
+		// The root does not depend on leaf order.
+		reversed := make([]Leaf, n)
+		for i, l := range ls {
+			reversed[n-1-i] = l
+		}
+		other, _ := New(reversed)
+		if other.Root() != tree.Root() {
+			t.Fatalf("%d leaves: root depends on order", n)
+		}
+	}
+}
+
+func TestNewRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		leaves  []Leaf
+		wantErr error
+	}{
+		{name: "empty", wantErr: ErrEmpty},
+		{name: "duplicate account", leaves: leaves("0xa1", 1, "0xa1", 2), wantErr: ErrDuplicateAccount},
+		{name: "negative amount", leaves: leaves("0xa1", -1), wantErr: ErrInvalidAmount},
+		{name: "nil amount", leaves: []Leaf{{Account: common.HexToAddress("0xa1")}}, wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.leaves); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tree, _ := New(leaves("0xa1", 1))
+	if _, err := tree.Proof(common.HexToAddress("0xb2")); !errors.Is(err, ErrUnknownAccount) {
+		t.Fatalf("Proof(unknown) = %v", err)
+	}
+	if root, err := Root(nil); err != nil || root != (common.Hash{}) {
+		t.Fatalf("Root(nil) = %s, %v, want zero", root.Hex(), err)
+	}
+}
+
+func TestClaimsAndDump(t *testing.T) {
+	tree, err := New(leaves("0xa1", 100, "0xb2", 200, "0xc3", 300))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(tree.Claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims ClaimsFile
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Root != tree.Root() || claims.Total != "600" || len(claims.Claims) != 3 {
+		t.Fatalf("claims = %+v", claims)
+	}
+	for account, c := range claims.Claims {
+		amount, _ := new(big.Int).SetString(c.Amount, 10)
+		if !Verify(claims.Root, Leaf{Account: account, Amount: amount}, c.Proof) {
+			t.Fatalf("claim for %s does not verify", account.Hex())
+		}
+	}
+
+	dump := tree.Dump()
+	if dump.Format != "standard-v1" || len(dump.Tree) != 5 || len(dump.Values) != 3 {
+		t.Fatalf("dump = %+v", dump)
+	}
+	for i, v := range dump.Values {
+		l := tree.Leaves()[i]
+		if v.Value[0] != l.Account.Hex() || common.BytesToHash(dump.Tree[v.TreeIndex]) != LeafHash(l) {
+			t.Fatalf("dump value %d = %+v does not point at its leaf", i, v)
+		}
+	}
+}
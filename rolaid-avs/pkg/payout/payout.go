@@ -0,0 +1,179 @@
+// Package payout allocates an insurance pot across the policies hit by a batch of events.
+//
+// Every covered policy claims its coverage. Claims are summed per beneficiary and, when
+// they exceed the pot, scaled down pro rata with the largest-remainder method: each
+// beneficiary gets floor(pot * claim / total) and the wei left over from rounding go one
+// each to the largest remainders. The vector therefore sums to exactly the pot when it is
+// oversubscribed and to the total claims otherwise, never more, and the result depends
+// only on the inputs, not on the order policies are listed in.
+package payout
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+// Policy is one insurance policy in a batch.
+type Policy struct {
+	Id          string
+	Beneficiary common.Address
+	Coverage    *big.Int
+	// Events lists the event ids the policy covers; an empty list covers every event.
+	Events []string
+}
+
+// Payout is one entry of the payout vector.
+type Payout struct {
+	Beneficiary common.Address
+	Amount      *big.Int
+}
+
+// Claims returns the coverage each beneficiary claims for events, summed across their
+// policies. A policy claims its full coverage once if it covers any of the events.
+func Claims(policies []Policy, events []string) (map[common.Address]*big.Int, error) {
+	hit := make(map[string]bool, len(events))
+	for _, e := range events {
+		hit[e] = true
+	}
+	if err := ValidatePolicies(policies); err != nil {
+		return nil, err
+	}
+	claims := make(map[common.Address]*big.Int)
+	for _, p := range policies {
+		if !covers(p, hit) {
+			continue
+		}
+		if c, ok := claims[p.Beneficiary]; ok {
+			c.Add(c, p.Coverage)
+		} else {
+			claims[p.Beneficiary] = new(big.Int).Set(p.Coverage)
+		}
+	}
+	return claims, nil
+}
+
+// ValidatePolicies checks every policy has a unique id, a beneficiary and a non-negative
+// coverage.
+func ValidatePolicies(policies []Policy) error {
+	seen := make(map[string]bool, len(policies))
+	for _, p := range policies {
+		switch {
+		case p.Id == "":
+			return fmt.Errorf("%w: empty id", ErrInvalidPolicy)
+		case seen[p.Id]:
+			return fmt.Errorf("%w: duplicate id %q", ErrInvalidPolicy, p.Id)
+		case p.Beneficiary == (common.Address{}):
+			return fmt.Errorf("%w: %q has no beneficiary", ErrInvalidPolicy, p.Id)
+		case p.Coverage == nil || p.Coverage.Sign() < 0:
+			return fmt.Errorf("%w: %q coverage must not be negative", ErrInvalidPolicy, p.Id)
+		}
+		seen[p.Id] = true
+	}
+	return nil
+}
+
+func covers(p Policy, hit map[string]bool) bool {
+	for e := range hit {
+		if p.Covers(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether the policy covers event.
+func (p Policy) Covers(event string) bool {
+	if len(p.Events) == 0 {
+		return true
+	}
+	for _, e := range p.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Allocate splits pot across claims and returns the non-zero payouts sorted by
+// beneficiary address.
+func Allocate(pot *big.Int, claims map[common.Address]*big.Int) ([]Payout, error) {
+	if pot == nil || pot.Sign() < 0 {
+		return nil, fmt.Errorf("pot must not be negative")
+	}
+	payouts := make([]Payout, 0, len(claims))
+	total := new(big.Int)
+	for addr, c := range claims {
+		if c.Sign() < 0 {
+			return nil, fmt.Errorf("negative claim for %s", addr.Hex())
+		}
+		if c.Sign() == 0 {
+			continue
+		}
+		payouts = append(payouts, Payout{Beneficiary: addr, Amount: new(big.Int).Set(c)})
+		total.Add(total, c)
+	}
+	sort.Slice(payouts, func(i, j int) bool {
+		return bytes.Compare(payouts[i].Beneficiary[:], payouts[j].Beneficiary[:]) < 0
+	})
+	if total.Cmp(pot) <= 0 {
+		return payouts, nil
+	}
+
+	remainders := make([]*big.Int, len(payouts))
+	left := new(big.Int).Set(pot)
+	for i, p := range payouts {
+		share := new(big.Int).Mul(pot, p.Amount)
+		remainders[i] = new(big.Int)
+		share.QuoRem(share, total, remainders[i])
+		payouts[i].Amount = share
+		left.Sub(left, share)
+	}
+	// Fewer wei are left than there are payouts; hand them out by largest remainder,
+	// breaking ties by address order.
+	order := make([]int, len(payouts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for _, i := range order[:left.Int64()] {
+		payouts[i].Amount.Add(payouts[i].Amount, big.NewInt(1))
+	}
+
+	out := payouts[:0]
+	for _, p := range payouts {
+		if p.Amount.Sign() > 0 {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// Total sums a payout vector.
+func Total(payouts []Payout) *big.Int {
+	sum := new(big.Int)
+	for _, p := range payouts {
+		sum.Add(sum, p.Amount)
+	}
+	return sum
+}
+
+// Split returns the vector as parallel beneficiary and amount slices, the form used in
+// task results.
+func Split(payouts []Payout) ([]common.Address, []*big.Int) {
+	beneficiaries := make([]common.Address, len(payouts))
+	amounts := make([]*big.Int, len(payouts))
+	for i, p := range payouts {
+		beneficiaries[i] = p.Beneficiary
+		amounts[i] = new(big.Int).Set(p.Amount)
+	}
+	return beneficiaries, amounts
+}
@@ -0,0 +1,174 @@
+package payout
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	alice = common.HexToAddress("0xa1")
+	bob   = common.HexToAddress("0xb2")
+	carol = common.HexToAddress("0xc3")
+)
+
+func claimsOf(kv ...interface{}) map[common.Address]*big.Int {
+	m := make(map[common.Address]*big.Int)
+	for i := 0; i < len(kv); i += 2 {
+		m[kv[i].(common.Address)] = big.NewInt(int64(kv[i+1].(int)))
+	}
+	return m
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		pot    int64
+		claims map[common.Address]*big.Int
+		want   []Payout
+	}{
+		{
+			name:   "claims under the pot are paid in full",
+			pot:    1000,
+			claims: claimsOf(bob, 300, alice, 200),
+			want:   []Payout{{alice, big.NewInt(200)}, {bob, big.NewInt(300)}},
+		},
+		{
+			name:   "oversubscribed pot is split pro rata",
+			pot:    1000,
+			claims: claimsOf(alice, 600, bob, 600),
+			want:   []Payout{{alice, big.NewInt(500)}, {bob, big.NewInt(500)}},
+		},
+		{
+			name:   "leftover wei go to the largest remainders",
+			pot:    10,
+			claims: claimsOf(alice, 10, bob, 20, carol, 40),
+			// Exact shares are 1.43, 2.86 and 5.71.
+			want: []Payout{{alice, big.NewInt(1)}, {bob, big.NewInt(3)}, {carol, big.NewInt(6)}},
+		},
+		{
+			name:   "equal remainders go in address order",
+			pot:    10,
+			claims: claimsOf(carol, 5, bob, 5, alice, 5),
+			want:   []Payout{{alice, big.NewInt(4)}, {bob, big.NewInt(3)}, {carol, big.NewInt(3)}},
+		},
+		{
+			name:   "zero shares are dropped",
+			pot:    1,
+			claims: claimsOf(alice, 1, bob, 1000),
+			want:   []Payout{{bob, big.NewInt(1)}},
+		},
+		{
+			name:   "zero claims are dropped",
+			pot:    5,
+			claims: claimsOf(alice, 0, bob, 3),
+			want:   []Payout{{bob, big.NewInt(3)}},
+		},
+		{
+			name:   "empty pot",
+			pot:    0,
+			claims: claimsOf(alice, 1),
+			want:   []Payout{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Allocate(big.NewInt(tt.pot), tt.claims)
+			if err != nil {
+				t.Fatalf("Allocate: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Beneficiary != tt.want[i].Beneficiary || got[i].Amount.Cmp(tt.want[i].Amount) != 0 {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+			if Total(got).Cmp(big.NewInt(tt.pot)) > 0 {
+				t.Fatalf("total %s exceeds pot %d", Total(got), tt.pot)
+			}
+		})
+	}
+
+	if _, err := Allocate(big.NewInt(-1), nil); err == nil {
+		t.Fatal("Allocate accepted a negative pot")
+	}
+}
+
+// An oversubscribed pot is always paid out to the last wei.
+func TestAllocateNoDrift(t *testing.T) {
+	pot, _ := new(big.Int).SetString("1000000000000000000", 10)
+	claims := make(map[common.Address]*big.Int)
+	for i := 1; i <= 7; i++ {
+		claims[common.BigToAddress(big.NewInt(int64(i)))] = big.NewInt(int64(i) * 1e17)
+	}
+	got, err := Allocate(pot, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Total(got).Cmp(pot) != 0 {
+		t.Fatalf("total %s, want pot %s", Total(got), pot)
+	}
+}
+
+func TestClaims(t *testing.T) {
+	policies := []Policy{
+		{Id: "p-1", Beneficiary: alice, Coverage: big.NewInt(100), Events: []string{"depeg"}},
+		{Id: "p-2", Beneficiary: alice, Coverage: big.NewInt(50)},
+		{Id: "p-3", Beneficiary: bob, Coverage: big.NewInt(70), Events: []string{"exploit"}},
+		// Covering two hit events still claims once.
+		{Id: "p-4", Beneficiary: carol, Coverage: big.NewInt(10), Events: []string{"depeg", "halt"}},
+	}
+	got, err := Claims(policies, []string{"depeg", "halt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := claimsOf(alice, 150, carol, 10)
+	if len(got) != len(want) {
+		t.Fatalf("claims = %v, want %v", got, want)
+	}
+	for addr, c := range want {
+		if got[addr] == nil || got[addr].Cmp(c) != 0 {
+			t.Fatalf("claims = %v, want %v", got, want)
+		}
+	}
+
+	// Listing order does not change the vector.
+	reversed := make([]Policy, len(policies))
+	for i, p := range policies {
+		reversed[len(policies)-1-i] = p
+	}
+	a, _ := Claims(policies, []string{"depeg"})
+	b, _ := Claims(reversed, []string{"depeg"})
+	pa, _ := Allocate(big.NewInt(100), a)
+	pb, _ := Allocate(big.NewInt(100), b)
+	if len(pa) != len(pb) {
+		t.Fatalf("order changed the vector: %v vs %v", pa, pb)
+	}
+	for i := range pa {
+		if pa[i].Beneficiary != pb[i].Beneficiary || pa[i].Amount.Cmp(pb[i].Amount) != 0 {
+			t.Fatalf("order changed the vector: %v vs %v", pa, pb)
+		}
+	}
+
+	if got, _ := Claims(policies, nil); len(got) != 0 {
+		t.Fatalf("no events claimed %v", got)
+	}
+}
+
+func TestClaimsInvalidPolicy(t *testing.T) {
+	for name, p := range map[string][]Policy{
+		"empty id":       {{Beneficiary: alice, Coverage: big.NewInt(1)}},
+		"duplicate id":   {{Id: "p", Beneficiary: alice, Coverage: big.NewInt(1)}, {Id: "p", Beneficiary: bob, Coverage: big.NewInt(1)}},
+		"no beneficiary": {{Id: "p", Coverage: big.NewInt(1)}},
+		"nil coverage":   {{Id: "p", Beneficiary: alice}},
+		"negative":       {{Id: "p", Beneficiary: alice, Coverage: big.NewInt(-1)}},
+	} {
+		if _, err := Claims(p, []string{"depeg"}); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("%s: err = %v, want ErrInvalidPolicy", name, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package preflight checks an auction task against AuctionService state before operators
+// sign it, so results that would revert in submitSettlement are rejected up front.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/attestationregistry"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Errors mirroring the require checks in AuctionService.submitSettlement.
+var (
+	ErrUnknownAuction   = errors.New("unknown auction")
+	ErrAuctionSettled   = errors.New("auction already settled")
+	ErrOutsideWindow    = errors.New("outside submission window")
+	ErrOracleMismatch   = errors.New("oracle update id mismatch")
+	ErrAttestationFails = errors.New("attestation registry rejected app")
+)
+
+// Backend is the subset of an L1 client needed for preflight reads.
+type Backend interface {
+	bind.ContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// AuctionCheck is the task data checked against chain state.
+type AuctionCheck struct {
+	// BlockNumber pins the reads to a block every operator agrees on; nil reads at the head.
+	BlockNumber    *big.Int
+	AuctionService common.Address
+	AuctionId      *big.Int
+	OracleUpdateId common.Hash
+	AppId          common.Hash
+	ImageDigest    common.Hash
+}
+
+// AuctionState is the onchain view the checks were evaluated against.
+type AuctionState struct {
+	BlockNumber    uint64
+	BlockTime      uint64
+	OracleUpdateId common.Hash
+	StartTime      uint64
+	EndTime        uint64
+	GracePeriod    uint64
+	Settled        bool
+}
+
+// CheckAuction reads AuctionService.auctions(id) and the attestation registry at
+// c.BlockNumber (or the latest block) and verifies the task could still be settled. All
+// reads are pinned to the same block. The returned state is populated whenever the auction could be read.
+func CheckAuction(ctx context.Context, backend Backend, c AuctionCheck) (*AuctionState, error) {
+	head, err := backend.HeaderByNumber(ctx, c.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block header: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+
+	service, err := auctionservice.NewAuctionServiceCaller(c.AuctionService, backend)
+	if err != nil {
+		return nil, fmt.Errorf("bind AuctionService: %w", err)
+	}
+	auction, err := service.Auctions(opts, c.AuctionId)
+	if err != nil {
+		return nil, fmt.Errorf("read auction %s: %w", c.AuctionId, err)
+	}
+	grace, err := service.SubmissionGracePeriod(opts)
+	if err != nil {
+		return nil, fmt.Errorf("read submissionGracePeriod: %w", err)
+	}
+
+	state := &AuctionState{
+		BlockNumber:    head.Number.Uint64(),
+		BlockTime:      head.Time,
+		OracleUpdateId: auction.OracleUpdateId,
+		StartTime:      auction.StartTime,
+		EndTime:        auction.EndTime,
+		GracePeriod:    grace,
+		Settled:        auction.Settled,
+	}
+
+	if auction.EndTime == 0 {
+		return state, fmt.Errorf("%w: id %s", ErrUnknownAuction, c.AuctionId)
+	}
+	if auction.Settled {
+		return state, fmt.Errorf("%w: id %s", ErrAuctionSettled, c.AuctionId)
+	}
+	if head.Time < auction.StartTime || head.Time > auction.EndTime+grace {
+		return state, fmt.Errorf("%w: block time %d not in [%d, %d]", ErrOutsideWindow, head.Time, auction.StartTime, auction.EndTime+grace)
+	}
+	if auction.OracleUpdateId != c.OracleUpdateId {
+		return state, fmt.Errorf("%w: onchain %s, task %s", ErrOracleMismatch, common.Hash(auction.OracleUpdateId).Hex(), c.OracleUpdateId.Hex())
+	}
+
+	registryAddr, err := service.AttestationRegistry(opts)
+	if err != nil {
+		return state, fmt.Errorf("read attestationRegistry: %w", err)
+	}
+	registry, err := attestationregistry.NewAttestationRegistryCaller(registryAddr, backend)
+	if err != nil {
+		return state, fmt.Errorf("bind AttestationRegistry: %w", err)
+	}
+	ok, err := registry.Verify(opts, c.AppId, c.ImageDigest)
+	if err != nil {
+		return state, fmt.Errorf("verify attestation: %w", err)
+	}
+	if !ok {
+		return state, fmt.Errorf("%w: app %s digest %s", ErrAttestationFails, c.AppId.Hex(), c.ImageDigest.Hex())
+	}
+	return state, nil
+}
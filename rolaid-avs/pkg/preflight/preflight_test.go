@@ -0,0 +1,147 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/attestationregistry"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/auctionservice"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	serviceAddr  = common.HexToAddress("0xa1")
+	registryAddr = common.HexToAddress("0xa2")
+	oracleId     = common.HexToHash("0x22")
+	appId        = common.HexToHash("0x33")
+	digest       = common.HexToHash("0x44")
+)
+
+// fakeChain answers eth_call for AuctionService and AttestationRegistry from in-memory state.
+type fakeChain struct {
+	head     *types.Header
+	blocks   map[uint64]*types.Header // historical headers, by number
+	read     *types.Header            // header the reads must be pinned to
+	auction  [7]interface{}
+	grace    uint64
+	verified bool
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		head:     &types.Header{Number: big.NewInt(100), Time: 1_000},
+		auction:  [7]interface{}{[32]byte(oracleId), uint64(900), uint64(1_100), common.Address{}, big.NewInt(0), [32]byte{}, false},
+		grace:    600,
+		verified: true,
+	}
+}
+
+func (f *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	f.read = f.head
+	if number != nil {
+		h, ok := f.blocks[number.Uint64()]
+		if !ok {
+			return nil, fmt.Errorf("block %s not found", number)
+		}
+		f.read = h
+	}
+	return f.read, nil
+}
+
+func (f *fakeChain) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil || f.read == nil || blockNumber.Cmp(f.read.Number) != 0 {
+		return nil, fmt.Errorf("call not pinned to the checked block: %v", blockNumber)
+	}
+	var metaABI *abi.ABI
+	var err error
+	switch *call.To {
+	case serviceAddr:
+		metaABI, err = auctionservice.AuctionServiceMetaData.GetAbi()
+	case registryAddr:
+		metaABI, err = attestationregistry.AttestationRegistryMetaData.GetAbi()
+	default:
+		return nil, fmt.Errorf("unexpected call to %s", call.To)
+	}
+	if err != nil {
+		return nil, err
+	}
+	method, err := metaABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "auctions":
+		return method.Outputs.Pack(f.auction[:]...)
+	case "submissionGracePeriod":
+		return method.Outputs.Pack(f.grace)
+	case "attestationRegistry":
+		return method.Outputs.Pack(registryAddr)
+	case "verify":
+		return method.Outputs.Pack(f.verified)
+	}
+	return nil, fmt.Errorf("unexpected method %s", method.Name)
+}
+
+func validCheck() AuctionCheck {
+	return AuctionCheck{
+		AuctionService: serviceAddr,
+		AuctionId:      big.NewInt(1),
+		OracleUpdateId: oracleId,
+		AppId:          appId,
+		ImageDigest:    digest,
+	}
+}
+
+func TestCheckAuction(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(f *fakeChain, c *AuctionCheck)
+		wantErr error
+	}{
+		{name: "valid", mutate: func(*fakeChain, *AuctionCheck) {}},
+		{name: "inside grace period", mutate: func(f *fakeChain, _ *AuctionCheck) { f.head.Time = 1_700 }},
+		{name: "unknown auction", mutate: func(f *fakeChain, _ *AuctionCheck) { f.auction[2] = uint64(0) }, wantErr: ErrUnknownAuction},
+		{name: "settled", mutate: func(f *fakeChain, _ *AuctionCheck) { f.auction[6] = true }, wantErr: ErrAuctionSettled},
+		{name: "too early", mutate: func(f *fakeChain, _ *AuctionCheck) { f.head.Time = 899 }, wantErr: ErrOutsideWindow},
+		{name: "expired", mutate: func(f *fakeChain, _ *AuctionCheck) { f.head.Time = 1_701 }, wantErr: ErrOutsideWindow},
+		{name: "oracle mismatch", mutate: func(_ *fakeChain, c *AuctionCheck) { c.OracleUpdateId = common.HexToHash("0x99") }, wantErr: ErrOracleMismatch},
+		{name: "pinned block", mutate: func(f *fakeChain, c *AuctionCheck) {
+			// Expired at the head, but the task pins a block inside the window.
+			f.head.Time = 5_000
+			f.blocks = map[uint64]*types.Header{90: {Number: big.NewInt(90), Time: 950}}
+			c.BlockNumber = big.NewInt(90)
+		}},
+		{name: "attestation", mutate: func(f *fakeChain, _ *AuctionCheck) { f.verified = false }, wantErr: ErrAttestationFails},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeChain()
+			c := validCheck()
+			tt.mutate(f, &c)
+
+			state, err := CheckAuction(context.Background(), f, c)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if state.BlockNumber != f.read.Number.Uint64() || state.GracePeriod != 600 {
+					t.Fatalf("unexpected state %+v", state)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
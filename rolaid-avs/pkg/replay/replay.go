@@ -0,0 +1,128 @@
+// Package replay guards against task envelopes being signed more than once by tracking
+// the highest submission nonce accepted per (auction service, auction id).
+package replay
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+var nonceBucket = []byte("replay_nonces")
+
+// ErrStaleNonce is returned when a nonce is not above the highest accepted nonce.
+var ErrStaleNonce = errors.New("stale or reused submission nonce")
+
+// Guard checks and records submission nonces.
+type Guard interface {
+	// Check reports whether nonce would be accepted, without recording it.
+	Check(service common.Address, auctionId uint64, nonce uint64) error
+	// Accept atomically checks nonce and records it as the new highest.
+	Accept(service common.Address, auctionId uint64, nonce uint64) error
+	// Highest returns the highest accepted nonce, or 0 if none.
+	Highest(service common.Address, auctionId uint64) (uint64, error)
+}
+
+func key(service common.Address, auctionId uint64) []byte {
+	k := make([]byte, common.AddressLength+8)
+	copy(k, service[:])
+	binary.BigEndian.PutUint64(k[common.AddressLength:], auctionId)
+	return k
+}
+
+func checkNonce(highest, nonce uint64) error {
+	if nonce <= highest {
+		return fmt.Errorf("%w: nonce %d, highest accepted %d", ErrStaleNonce, nonce, highest)
+	}
+	return nil
+}
+
+// BoltGuard persists nonces in a bbolt database so they survive restarts.
+type BoltGuard struct {
+	db *bolt.DB
+}
+
+// NewBoltGuard creates the nonce bucket in db if needed.
+func NewBoltGuard(db *bolt.DB) (*BoltGuard, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(nonceBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create replay bucket: %w", err)
+	}
+	return &BoltGuard{db: db}, nil
+}
+
+func (g *BoltGuard) Check(service common.Address, auctionId uint64, nonce uint64) error {
+	highest, err := g.Highest(service, auctionId)
+	if err != nil {
+		return err
+	}
+	return checkNonce(highest, nonce)
+}
+
+func (g *BoltGuard) Accept(service common.Address, auctionId uint64, nonce uint64) error {
+	return g.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(nonceBucket)
+		k := key(service, auctionId)
+		if err := checkNonce(readNonce(b.Get(k)), nonce); err != nil {
+			return err
+		}
+		v := make([]byte, 8)
+		binary.BigEndian.PutUint64(v, nonce)
+		return b.Put(k, v)
+	})
+}
+
+func (g *BoltGuard) Highest(service common.Address, auctionId uint64) (uint64, error) {
+	var highest uint64
+	err := g.db.View(func(tx *bolt.Tx) error {
+		highest = readNonce(tx.Bucket(nonceBucket).Get(key(service, auctionId)))
+		return nil
+	})
+	return highest, err
+}
+
+func readNonce(v []byte) uint64 {
+	if len(v) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(v)
+}
+
+// MemoryGuard keeps nonces in memory only. Used when no data directory is configured.
+type MemoryGuard struct {
+	mu      sync.Mutex
+	highest map[string]uint64
+}
+
+func NewMemoryGuard() *MemoryGuard {
+	return &MemoryGuard{highest: make(map[string]uint64)}
+}
+
+func (g *MemoryGuard) Check(service common.Address, auctionId uint64, nonce uint64) error {
+	highest, _ := g.Highest(service, auctionId)
+	return checkNonce(highest, nonce)
+}
+
+func (g *MemoryGuard) Accept(service common.Address, auctionId uint64, nonce uint64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	k := string(key(service, auctionId))
+	if err := checkNonce(g.highest[k], nonce); err != nil {
+		return err
+	}
+	g.highest[k] = nonce
+	return nil
+}
+
+func (g *MemoryGuard) Highest(service common.Address, auctionId uint64) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.highest[string(key(service, auctionId))], nil
+}
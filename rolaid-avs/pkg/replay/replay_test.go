@@ -0,0 +1,84 @@
+package replay
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openDB(t *testing.T, path string) *bolt.DB {
+	t.Helper()
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	return db
+}
+
+func exerciseGuard(t *testing.T, g Guard) {
+	t.Helper()
+	svc := common.HexToAddress("0xa1")
+	other := common.HexToAddress("0xa2")
+
+	if err := g.Accept(svc, 1, 5); err != nil {
+		t.Fatalf("first accept: %v", err)
+	}
+	for _, nonce := range []uint64{0, 4, 5} {
+		if err := g.Check(svc, 1, nonce); !errors.Is(err, ErrStaleNonce) {
+			t.Fatalf("Check(nonce=%d) = %v, want ErrStaleNonce", nonce, err)
+		}
+		if err := g.Accept(svc, 1, nonce); !errors.Is(err, ErrStaleNonce) {
+			t.Fatalf("Accept(nonce=%d) = %v, want ErrStaleNonce", nonce, err)
+		}
+	}
+	if err := g.Check(svc, 1, 6); err != nil {
+		t.Fatalf("Check(6): %v", err)
+	}
+
+	// Nonces are tracked per (service, auction id).
+	if err := g.Accept(svc, 2, 1); err != nil {
+		t.Fatalf("accept other auction: %v", err)
+	}
+	if err := g.Accept(other, 1, 1); err != nil {
+		t.Fatalf("accept other service: %v", err)
+	}
+}
+
+func TestMemoryGuard(t *testing.T) {
+	exerciseGuard(t, NewMemoryGuard())
+}
+
+func TestBoltGuardSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	db := openDB(t, path)
+	g, err := NewBoltGuard(db)
+	if err != nil {
+		t.Fatalf("NewBoltGuard: %v", err)
+	}
+	exerciseGuard(t, g)
+	if err := db.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	db = openDB(t, path)
+	defer db.Close()
+	g, err = NewBoltGuard(db)
+	if err != nil {
+		t.Fatalf("NewBoltGuard after restart: %v", err)
+	}
+	highest, err := g.Highest(common.HexToAddress("0xa1"), 1)
+	if err != nil {
+		t.Fatalf("Highest: %v", err)
+	}
+	if highest != 5 {
+		t.Fatalf("highest after restart = %d, want 5", highest)
+	}
+	if err := g.Accept(common.HexToAddress("0xa1"), 1, 5); !errors.Is(err, ErrStaleNonce) {
+		t.Fatalf("replayed nonce accepted after restart: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Package results ABI-encodes the task results that operators sign and submit to the
+// TaskMailbox, so onchain consumers (AuctionService, AVSTaskHook) can decode them.
+//
+// Every result is wrapped as abi.encode(uint8 kind, uint8 version, bytes body) where
+// body is abi.encode of the kind's result struct. The matching Solidity definitions
+// live in src/avs/TaskResults.sol at the repository root; keep both in sync.
+package results
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Result kinds, mirrored by TaskResults.KIND_* in Solidity.
+const (
+	KindAuctionSettlement uint8 = 1
+	KindInsurancePayout   uint8 = 2
+)
+
+// Claim models recorded in InsurancePayoutResult.ModelSource, mirrored by
+// TaskResults.MODEL_* in Solidity.
+const (
+	ModelRules   uint8 = 1 // pkg/actuarial; ModelVersion is its Version
+	ModelEigenAI uint8 = 2 // EigenAI; ModelVersion is the model name
+)
+
+// Version is the result encoding version, mirrored by TaskResults.VERSION in Solidity.
+const Version uint8 = 1
+
+// AuctionSettlementResult mirrors TaskResults.AuctionSettlementResult.
+type AuctionSettlementResult struct {
+	AuctionId      *big.Int
+	PoolId         [32]byte
+	OracleUpdateId [32]byte
+	Commitment     [32]byte
+	AuctionService common.Address
+	BidAmount      *big.Int // uint96, the value submitSettlement pays
+	// Winner is the bidder the settlement pays for: the sealed-bid winner, or the task's
+	// named winner; zero when the task names none.
+	Winner common.Address
+	// DistributionRoot is the Merkle root (pkg/merkle) of the LP split of the proceeds,
+	// or zero when the task carries none.
+	DistributionRoot [32]byte
+	// LvrEstimate is the pool arbitrage profit at the oracle price (pkg/lvr), in the
+	// pool's token1; zero when the task carries no oracle price. Nil encodes as zero.
+	LvrEstimate *big.Int
+}
+
+// InsurancePayoutResult mirrors TaskResults.InsurancePayoutResult.
+type InsurancePayoutResult struct {
+	PolicyBatchId    string
+	PayoutCommitment [32]byte
+	Seed             uint64
+	SettlementVault  common.Address
+	// VectorCommitment commits to the payout vector (commitment.PayoutVector), which
+	// follows as parallel Beneficiaries and Amounts slices.
+	VectorCommitment [32]byte
+	Beneficiaries    []common.Address
+	Amounts          []*big.Int
+	// DistributionRoot is the Merkle root (pkg/merkle) over the payout vector, which
+	// beneficiaries claim against; zero when the vector is empty.
+	DistributionRoot [32]byte
+	// ModelSource (ModelRules or ModelEigenAI) and ModelVersion record which model
+	// decided the claims.
+	ModelSource  uint8
+	ModelVersion string
+}
+
+var (
+	envelopeArgs = abi.Arguments{
+		{Name: "kind", Type: mustType("uint8", nil)},
+		{Name: "version", Type: mustType("uint8", nil)},
+		{Name: "body", Type: mustType("bytes", nil)},
+	}
+
+	auctionSettlementArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+		{Name: "auctionId", Type: "uint256"},
+		{Name: "poolId", Type: "bytes32"},
+		{Name: "oracleUpdateId", Type: "bytes32"},
+		{Name: "commitment", Type: "bytes32"},
+		{Name: "auctionService", Type: "address"},
+		{Name: "bidAmount", Type: "uint96"},
+		{Name: "winner", Type: "address"},
+		{Name: "distributionRoot", Type: "bytes32"},
+		{Name: "lvrEstimate", Type: "uint256"},
+	})}}
+
+	insurancePayoutArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+		{Name: "policyBatchId", Type: "string"},
+		{Name: "payoutCommitment", Type: "bytes32"},
+		{Name: "seed", Type: "uint64"},
+		{Name: "settlementVault", Type: "address"},
+		{Name: "vectorCommitment", Type: "bytes32"},
+		{Name: "beneficiaries", Type: "address[]"},
+		{Name: "amounts", Type: "uint256[]"},
+		{Name: "distributionRoot", Type: "bytes32"},
+		{Name: "modelSource", Type: "uint8"},
+		{Name: "modelVersion", Type: "string"},
+	})}}
+)
+
+func mustType(t string, components []abi.ArgumentMarshaling) abi.Type {
+	typ, err := abi.NewType(t, "", components)
+	if err != nil {
+		panic(fmt.Sprintf("results: bad abi type %s: %v", t, err))
+	}
+	return typ
+}
+
+// EncodeAuctionSettlement ABI-encodes an auction settlement result.
+func EncodeAuctionSettlement(r *AuctionSettlementResult) ([]byte, error) {
+	if r.AuctionId == nil {
+		return nil, fmt.Errorf("auction id missing")
+	}
+	if r.BidAmount == nil {
+		return nil, fmt.Errorf("bid amount missing")
+	}
+	if r.LvrEstimate == nil {
+		withZero := *r
+		withZero.LvrEstimate = new(big.Int)
+		r = &withZero
+	}
+	return encode(KindAuctionSettlement, auctionSettlementArgs, r)
+}
+
+// DecodeAuctionSettlement decodes a result produced by EncodeAuctionSettlement.
+func DecodeAuctionSettlement(data []byte) (*AuctionSettlementResult, error) {
+	var r AuctionSettlementResult
+	if err := decode(data, KindAuctionSettlement, auctionSettlementArgs, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// EncodeInsurancePayout ABI-encodes an insurance payout result.
+func EncodeInsurancePayout(r *InsurancePayoutResult) ([]byte, error) {
+	if len(r.Beneficiaries) != len(r.Amounts) {
+		return nil, fmt.Errorf("payout vector has %d beneficiaries but %d amounts", len(r.Beneficiaries), len(r.Amounts))
+	}
+	return encode(KindInsurancePayout, insurancePayoutArgs, r)
+}
+
+// DecodeInsurancePayout decodes a result produced by EncodeInsurancePayout.
+func DecodeInsurancePayout(data []byte) (*InsurancePayoutResult, error) {
+	var r InsurancePayoutResult
+	if err := decode(data, KindInsurancePayout, insurancePayoutArgs, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// DecodeHeader returns the kind, version and kind-specific body of an encoded result.
+func DecodeHeader(data []byte) (kind uint8, version uint8, body []byte, err error) {
+	vals, err := envelopeArgs.Unpack(data)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("decode result header: %w", err)
+	}
+	return vals[0].(uint8), vals[1].(uint8), vals[2].([]byte), nil
+}
+
+// Canonical checks data is the canonical encoding of a known result kind: decoding and
+// re-encoding it must reproduce the same bytes. Operators only reach a signing quorum on
+// identical bytes, so trailing data or non-standard offsets are rejected.
+func Canonical(data []byte) error {
+	kind, _, _, err := DecodeHeader(data)
+	if err != nil {
+		return err
+	}
+	var reencoded []byte
+	switch kind {
+	case KindAuctionSettlement:
+		r, err := DecodeAuctionSettlement(data)
+		if err != nil {
+			return err
+		}
+		reencoded, err = EncodeAuctionSettlement(r)
+		if err != nil {
+			return err
+		}
+	case KindInsurancePayout:
+		r, err := DecodeInsurancePayout(data)
+		if err != nil {
+			return err
+		}
+		reencoded, err = EncodeInsurancePayout(r)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown result kind %d", kind)
+	}
+	if !bytes.Equal(reencoded, data) {
+		return fmt.Errorf("result kind %d is not canonically encoded", kind)
+	}
+	return nil
+}
+
+func encode(kind uint8, args abi.Arguments, r interface{}) ([]byte, error) {
+	body, err := args.Pack(r)
+	if err != nil {
+		return nil, fmt.Errorf("encode result body: %w", err)
+	}
+	return envelopeArgs.Pack(kind, Version, body)
+}
+
+func decode(data []byte, wantKind uint8, args abi.Arguments, out interface{}) error {
+	kind, version, body, err := DecodeHeader(data)
+	if err != nil {
+		return err
+	}
+	if kind != wantKind {
+		return fmt.Errorf("result kind %d, want %d", kind, wantKind)
+	}
+	if version != Version {
+		return fmt.Errorf("unsupported result version %d", version)
+	}
+	vals, err := args.Unpack(body)
+	if err != nil {
+		return fmt.Errorf("decode result body: %w", err)
+	}
+	abi.ConvertType(vals[0], out)
+	return nil
+}
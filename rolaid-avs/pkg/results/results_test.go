@@ -0,0 +1,135 @@
+package results
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAuctionSettlementRoundTrip(t *testing.T) {
+	want := &AuctionSettlementResult{
+		AuctionId:        big.NewInt(7),
+		PoolId:           common.HexToHash("0x11"),
+		OracleUpdateId:   common.HexToHash("0x22"),
+		Commitment:       common.HexToHash("0x33"),
+		AuctionService:   common.HexToAddress("0xa1"),
+		BidAmount:        big.NewInt(1000),
+		Winner:           common.HexToAddress("0xb2"),
+		DistributionRoot: common.HexToHash("0x44"),
+		LvrEstimate:      big.NewInt(5000),
+	}
+	data, err := EncodeAuctionSettlement(want)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := DecodeAuctionSettlement(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+
+	if _, err := DecodeInsurancePayout(data); err == nil {
+		t.Fatalf("expected kind mismatch error")
+	}
+}
+
+func TestInsurancePayoutRoundTrip(t *testing.T) {
+	want := &InsurancePayoutResult{
+		PolicyBatchId:    "batch-1",
+		PayoutCommitment: common.HexToHash("0x44"),
+		Seed:             42,
+		SettlementVault:  common.HexToAddress("0xb2"),
+		VectorCommitment: common.HexToHash("0x55"),
+		Beneficiaries:    []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xb2")},
+		Amounts:          []*big.Int{big.NewInt(500), big.NewInt(500)},
+		DistributionRoot: common.HexToHash("0x66"),
+		ModelSource:      ModelRules,
+		ModelVersion:     "rolaid-actuarial-v1",
+	}
+	data, err := EncodeInsurancePayout(want)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	kind, version, _, err := DecodeHeader(data)
+	if err != nil {
+		t.Fatalf("decode header: %v", err)
+	}
+	if kind != KindInsurancePayout || version != Version {
+		t.Fatalf("header = (%d, %d), want (%d, %d)", kind, version, KindInsurancePayout, Version)
+	}
+	got, err := DecodeInsurancePayout(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+
+	want.Amounts = want.Amounts[:1]
+	if _, err := EncodeInsurancePayout(want); err == nil {
+		t.Fatal("expected mismatched payout vector error")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	data, err := EncodeInsurancePayout(&InsurancePayoutResult{PolicyBatchId: "batch-1", Seed: 42})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if err := Canonical(data); err != nil {
+		t.Fatalf("Canonical(encoded) = %v", err)
+	}
+	if err := Canonical(append(data, make([]byte, 32)...)); err == nil {
+		t.Fatal("trailing word accepted as canonical")
+	}
+	unknown, err := envelopeArgs.Pack(uint8(99), Version, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Canonical(unknown); err == nil {
+		t.Fatal("unknown kind accepted as canonical")
+	}
+}
+
+// TestAuctionSettlementGolden pins the wire layout; the same vector is decoded by
+// test/avs/TaskResults.t.sol at the repository root.
+func TestAuctionSettlementGolden(t *testing.T) {
+	data, err := EncodeAuctionSettlement(&AuctionSettlementResult{
+		AuctionId:        big.NewInt(7),
+		PoolId:           common.HexToHash("0x11"),
+		OracleUpdateId:   common.HexToHash("0x22"),
+		Commitment:       common.HexToHash("0x33"),
+		AuctionService:   common.HexToAddress("0xa1"),
+		BidAmount:        big.NewInt(1000),
+		Winner:           common.HexToAddress("0xb2"),
+		DistributionRoot: common.HexToHash("0x44"),
+		LvrEstimate:      big.NewInt(5000),
+	})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	want := strings.Join([]string{
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"0000000000000000000000000000000000000000000000000000000000000120",
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000011",
+		"0000000000000000000000000000000000000000000000000000000000000022",
+		"0000000000000000000000000000000000000000000000000000000000000033",
+		"00000000000000000000000000000000000000000000000000000000000000a1",
+		"00000000000000000000000000000000000000000000000000000000000003e8",
+		"00000000000000000000000000000000000000000000000000000000000000b2",
+		"0000000000000000000000000000000000000000000000000000000000000044",
+		"0000000000000000000000000000000000000000000000000000000000001388",
+	}, "")
+	if got := hex.EncodeToString(data); got != want {
+		t.Fatalf("encoding mismatch:\n got %s\nwant %s", got, want)
+	}
+}
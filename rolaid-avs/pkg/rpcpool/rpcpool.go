@@ -0,0 +1,504 @@
+// Package rpcpool spreads Ethereum RPC calls over several endpoints of one chain.
+//
+// Endpoints are ranked by health: those in a failure cooldown go last, then fewer
+// consecutive failures, then lower average latency. A read goes to the best endpoint
+// and fails over to the next when the endpoint, rather than the chain, fails: a
+// transport error, a rate limit or a server error. A revert or a missing object is the
+// chain's answer and is returned as is. With HedgeAfter set, a read that has not been
+// answered in time is also sent to the next endpoint, and the first answer wins.
+// Transactions are sent to every endpoint.
+//
+// Quorum returns a reader for critical reads, which need several endpoints to return the
+// same answer at the same block.
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Defaults for zero Config fields.
+const (
+	DefaultMaxFailures = 3
+	DefaultCooldown    = 30 * time.Second
+)
+
+// latencyWeight is the weight of the newest sample in an endpoint's average latency.
+const latencyWeight = 0.2
+
+// ErrNoQuorum is returned by quorum reads when too few endpoints agree.
+var ErrNoQuorum = errors.New("rpc endpoints disagree")
+
+// Client is one endpoint. ethclient.Client implements it.
+type Client interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	Close()
+}
+
+// Endpoint names a client. The name shows in errors and Status, so it should not carry
+// credentials.
+type Endpoint struct {
+	Name   string
+	Client Client
+}
+
+// Config configures a Pool.
+type Config struct {
+	// HedgeAfter is how long a read waits for an endpoint before it is also sent to the
+	// next one; zero disables hedging.
+	HedgeAfter time.Duration
+	// MaxFailures consecutive failures put an endpoint in cooldown.
+	MaxFailures int
+	// Cooldown is how long an endpoint is ranked last after MaxFailures.
+	Cooldown time.Duration
+}
+
+// EndpointStatus reports an endpoint's health.
+type EndpointStatus struct {
+	Name      string  `json:"name"`
+	Healthy   bool    `json:"healthy"`
+	Failures  int     `json:"consecutive_failures"`
+	LatencyMs float64 `json:"latency_ms"`
+	Calls     uint64  `json:"calls"`
+	Errors    uint64  `json:"errors"`
+}
+
+type endpoint struct {
+	Endpoint
+	index int
+
+	// Guarded by Pool.mu.
+	latency   time.Duration // moving average of answered calls
+	failures  int           // consecutive
+	downUntil time.Time
+	calls     uint64
+	errors    uint64
+}
+
+// Pool is a Client over several endpoints.
+type Pool struct {
+	cfg       Config
+	endpoints []*endpoint
+	now       func() time.Time
+
+	mu sync.Mutex
+}
+
+// New builds a pool over endpoints, in order of preference until they have been scored.
+func New(endpoints []Endpoint, cfg Config) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("rpcpool: no endpoints")
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = DefaultMaxFailures
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultCooldown
+	}
+	p := &Pool{cfg: cfg, now: time.Now}
+	for i, e := range endpoints {
+		if e.Client == nil {
+			return nil, fmt.Errorf("rpcpool: endpoint %s has no client", e.Name)
+		}
+		p.endpoints = append(p.endpoints, &endpoint{Endpoint: e, index: i})
+	}
+	return p, nil
+}
+
+// Len returns the number of endpoints.
+func (p *Pool) Len() int { return len(p.endpoints) }
+
+// Status reports every endpoint's health, in configured order.
+func (p *Pool) Status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	out := make([]EndpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		out[i] = EndpointStatus{
+			Name:      e.Name,
+			Healthy:   !now.Before(e.downUntil),
+			Failures:  e.failures,
+			LatencyMs: float64(e.latency) / float64(time.Millisecond),
+			Calls:     e.calls,
+			Errors:    e.errors,
+		}
+	}
+	return out
+}
+
+// Close closes every endpoint.
+func (p *Pool) Close() {
+	for _, e := range p.endpoints {
+		e.Client.Close()
+	}
+}
+
+// ranked returns the endpoints, best first.
+func (p *Pool) ranked() []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	out := append([]*endpoint(nil), p.endpoints...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if downA, downB := now.Before(a.downUntil), now.Before(b.downUntil); downA != downB {
+			return downB
+		}
+		if a.failures != b.failures {
+			return a.failures < b.failures
+		}
+		if a.latency != b.latency {
+			return a.latency < b.latency
+		}
+		return a.index < b.index
+	})
+	return out
+}
+
+// observe scores an endpoint on the outcome of a call.
+func (p *Pool) observe(e *endpoint, took time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.calls++
+	if err != nil && !isAnswer(err) {
+		e.errors++
+		e.failures++
+		if e.failures >= p.cfg.MaxFailures {
+			e.downUntil = p.now().Add(p.cfg.Cooldown)
+		}
+		return
+	}
+	e.failures = 0
+	e.downUntil = time.Time{}
+	if e.latency == 0 {
+		e.latency = took
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(took) + (1-latencyWeight)*float64(e.latency))
+	}
+}
+
+// isAnswer reports whether err came from the chain rather than from the endpoint.
+func isAnswer(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	var de rpc.DataError
+	if errors.As(err, &de) {
+		return true
+	}
+	return strings.Contains(err.Error(), "execution reverted")
+}
+
+type result[T any] struct {
+	v   T
+	err error
+	e   *endpoint
+}
+
+// call runs fn on the best endpoint, failing over and, when hedge is set, hedging.
+func call[T any](ctx context.Context, p *Pool, hedge bool, fn func(context.Context, Client) (T, error)) (T, error) {
+	var zero T
+	order := p.ranked()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan result[T], len(order))
+	started, running := 0, 0
+	launch := func() {
+		e := order[started]
+		started++
+		running++
+		go func() {
+			start := time.Now()
+			v, err := fn(ctx, e.Client)
+			if ctx.Err() == nil {
+				p.observe(e, time.Since(start), err)
+			}
+			results <- result[T]{v, err, e}
+		}()
+	}
+	launch()
+	var errs []error
+	for {
+		var hedgeC <-chan time.Time
+		var timer *time.Timer
+		if hedge && p.cfg.HedgeAfter > 0 && started < len(order) {
+			timer = time.NewTimer(p.cfg.HedgeAfter)
+			hedgeC = timer.C
+		}
+		select {
+		case r := <-results:
+			running--
+			if r.err == nil || isAnswer(r.err) {
+				return r.v, r.err
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", r.e.Name, r.err))
+			if started < len(order) {
+				launch()
+			} else if running == 0 {
+				return zero, errors.Join(errs...)
+			}
+		case <-hedgeC:
+			launch()
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (*big.Int, error) { return c.ChainID(ctx) })
+}
+
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (p *Pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+func (p *Pool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) ([]byte, error) { return c.CallContract(ctx, msg, blockNumber) })
+}
+
+func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) ([]byte, error) { return c.PendingCodeAt(ctx, account) })
+}
+
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (uint64, error) { return c.PendingNonceAt(ctx, account) })
+}
+
+func (p *Pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (uint64, error) { return c.NonceAt(ctx, account, blockNumber) })
+}
+
+func (p *Pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (*big.Int, error) { return c.SuggestGasPrice(ctx) })
+}
+
+func (p *Pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (*big.Int, error) { return c.SuggestGasTipCap(ctx) })
+}
+
+func (p *Pool) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (uint64, error) { return c.EstimateGas(ctx, msg) })
+}
+
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) (*types.Receipt, error) { return c.TransactionReceipt(ctx, txHash) })
+}
+
+func (p *Pool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return call(ctx, p, true, func(ctx context.Context, c Client) ([]types.Log, error) { return c.FilterLogs(ctx, q) })
+}
+
+// SubscribeFilterLogs subscribes on the best endpoint that supports subscriptions. It
+// is not hedged, so no subscription is left behind.
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return call(ctx, p, false, func(ctx context.Context, c Client) (ethereum.Subscription, error) {
+		return c.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+// SendTransaction sends tx to every endpoint, so one that drops it does not stall it.
+// It succeeds when any endpoint accepts tx.
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	errs := make([]error, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := e.Client.SendTransaction(ctx, tx)
+			p.observe(e, time.Since(start), err)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", e.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Quorum returns a reader whose answers n endpoints agree on. Its reads fail with
+// ErrNoQuorum when the pool has fewer than n endpoints.
+func (p *Pool) Quorum(n int) *QuorumReader {
+	if n < 1 {
+		n = 1
+	}
+	return &QuorumReader{p: p, n: n}
+}
+
+// QuorumReader reads from every endpoint of a pool and returns an answer once n of them
+// agree on it. Reads at the latest block are pinned first to the highest block n
+// endpoints have, so every endpoint answers for the same block.
+type QuorumReader struct {
+	p *Pool
+	n int
+}
+
+// HeaderByNumber returns the header n endpoints agree on. A nil number picks the highest
+// block n endpoints have reached.
+func (q *QuorumReader) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		var err error
+		if number, err = q.head(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return quorum(ctx, q, func(h *types.Header) string { return h.Hash().Hex() },
+		func(ctx context.Context, c Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (q *QuorumReader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	blockNumber, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return quorum(ctx, q, func(b []byte) string { return string(b) },
+		func(ctx context.Context, c Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+func (q *QuorumReader) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	blockNumber, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return quorum(ctx, q, func(b []byte) string { return string(b) },
+		func(ctx context.Context, c Client) ([]byte, error) { return c.CallContract(ctx, msg, blockNumber) })
+}
+
+// pin returns number, or the quorum head when it is nil.
+func (q *QuorumReader) pin(ctx context.Context, number *big.Int) (*big.Int, error) {
+	if number != nil {
+		return number, nil
+	}
+	return q.head(ctx)
+}
+
+// head returns a block number at least n endpoints have reached: the lowest head of the
+// first n endpoints to answer, so a slow endpoint does not hold the read up.
+func (q *QuorumReader) head(ctx context.Context) (*big.Int, error) {
+	if err := q.check(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan result[*types.Header], len(q.p.endpoints))
+	spreadAll(ctx, q.p, results, func(ctx context.Context, c Client) (*types.Header, error) { return c.HeaderByNumber(ctx, nil) })
+	var low uint64
+	heads := 0
+	var errs []error
+	for range q.p.endpoints {
+		var r result[*types.Header]
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.e.Name, r.err))
+			continue
+		}
+		if n := r.v.Number.Uint64(); heads == 0 || n < low {
+			low = n
+		}
+		if heads++; heads == q.n {
+			return new(big.Int).SetUint64(low), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d of %d endpoints returned a head: %w", ErrNoQuorum, heads, q.n, errors.Join(errs...))
+}
+
+// check fails when the pool has too few endpoints for the quorum.
+func (q *QuorumReader) check() error {
+	if len(q.p.endpoints) < q.n {
+		return fmt.Errorf("%w: %d endpoints for a quorum of %d", ErrNoQuorum, len(q.p.endpoints), q.n)
+	}
+	return nil
+}
+
+// spreadAll runs fn on every endpoint, sending each result to results.
+func spreadAll[T any](ctx context.Context, p *Pool, results chan<- result[T], fn func(context.Context, Client) (T, error)) {
+	for _, e := range p.endpoints {
+		go func() {
+			start := time.Now()
+			v, err := fn(ctx, e.Client)
+			if ctx.Err() == nil {
+				p.observe(e, time.Since(start), err)
+			}
+			results <- result[T]{v, err, e}
+		}()
+	}
+}
+
+// quorum runs fn on every endpoint and returns the first answer, keyed by key, that n
+// endpoints gave. Chain errors such as reverts count as answers, keyed by message.
+func quorum[T any](ctx context.Context, q *QuorumReader, key func(T) string, fn func(context.Context, Client) (T, error)) (T, error) {
+	var zero T
+	if err := q.check(); err != nil {
+		return zero, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan result[T], len(q.p.endpoints))
+	spreadAll(ctx, q.p, results, fn)
+	votes := make(map[string]int)
+	var errs []error
+	for range q.p.endpoints {
+		var r result[T]
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+		var k string
+		switch {
+		case r.err == nil:
+			k = "ok:" + key(r.v)
+		case isAnswer(r.err):
+			k = "err:" + r.err.Error()
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", r.e.Name, r.err))
+			continue
+		}
+		votes[k]++
+		if votes[k] >= q.n {
+			return r.v, r.err
+		}
+	}
+	most := 0
+	for _, v := range votes {
+		most = max(most, v)
+	}
+	if len(errs) == 0 {
+		return zero, fmt.Errorf("%w: at most %d of %d agree", ErrNoQuorum, most, q.n)
+	}
+	return zero, fmt.Errorf("%w: at most %d of %d agree: %w", ErrNoQuorum, most, q.n, errors.Join(errs...))
+}
@@ -0,0 +1,244 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var errDown = errors.New("connection refused")
+
+// fakeClient answers the calls the tests make; the embedded Client panics on others.
+type fakeClient struct {
+	Client
+	head   uint64
+	result []byte
+	err    error
+	delay  time.Duration
+
+	mu    sync.Mutex
+	calls int
+	sent  int
+	// callBlocks records the blocks CallContract was asked about.
+	callBlocks []uint64
+}
+
+func (c *fakeClient) wait(ctx context.Context) error {
+	c.mu.Lock()
+	c.calls++
+	err, delay := c.err, c.delay
+	c.mu.Unlock()
+	select {
+	case <-time.After(delay):
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// set changes what the client answers, racing safely with calls still in flight.
+func (c *fakeClient) set(result []byte, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.result, c.err = result, err
+}
+
+func (c *fakeClient) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *fakeClient) ChainID(ctx context.Context) (*big.Int, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return big.NewInt(1), nil
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	n := c.head
+	if number != nil {
+		if number.Uint64() > c.head {
+			return nil, ethereum.NotFound
+		}
+		n = number.Uint64()
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(n), Difficulty: big.NewInt(1)}, nil
+}
+
+func (c *fakeClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callBlocks = append(c.callBlocks, blockNumber.Uint64())
+	return c.result, nil
+}
+
+func (c *fakeClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent++
+	return c.err
+}
+
+func (c *fakeClient) Close() {}
+
+func newTestPool(t *testing.T, cfg Config, clients ...*fakeClient) *Pool {
+	t.Helper()
+	var eps []Endpoint
+	for i, c := range clients {
+		eps = append(eps, Endpoint{Name: string(rune('a' + i)), Client: c})
+	}
+	p, err := New(eps, cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return p
+}
+
+func Test_Failover(t *testing.T) {
+	down := &fakeClient{err: errDown}
+	up := &fakeClient{head: 10}
+	now := time.Unix(0, 0)
+	p := newTestPool(t, Config{MaxFailures: 1, Cooldown: time.Minute}, down, up)
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := p.ChainID(ctx); err != nil {
+		t.Fatalf("ChainID: %v", err)
+	}
+	st := p.Status()
+	if st[0].Healthy || st[0].Failures != 1 || !st[1].Healthy || st[1].Calls != 1 {
+		t.Fatalf("status = %+v", st)
+	}
+	// The failed endpoint is ranked last and no longer tried first.
+	if _, err := p.ChainID(ctx); err != nil {
+		t.Fatalf("ChainID: %v", err)
+	}
+	if n := down.callCount(); n != 1 {
+		t.Errorf("down endpoint called %d times, want 1", n)
+	}
+	// After its cooldown it is healthy again, behind the working endpoint.
+	now = now.Add(2 * time.Minute)
+	if st := p.Status(); !st[0].Healthy {
+		t.Errorf("endpoint still down after cooldown")
+	}
+
+	// A chain answer is not an endpoint failure.
+	if _, err := p.HeaderByNumber(ctx, big.NewInt(11)); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("HeaderByNumber = %v, want NotFound", err)
+	}
+	if st := p.Status(); st[1].Failures != 0 {
+		t.Errorf("NotFound counted as a failure: %+v", st[1])
+	}
+
+	up.set(nil, errDown)
+	if _, err := p.ChainID(ctx); err == nil || !strings.Contains(err.Error(), "a: ") || !strings.Contains(err.Error(), "b: ") {
+		t.Fatalf("ChainID with every endpoint down = %v", err)
+	}
+}
+
+func Test_Hedge(t *testing.T) {
+	slow := &fakeClient{delay: time.Second}
+	fast := &fakeClient{}
+	p := newTestPool(t, Config{HedgeAfter: 10 * time.Millisecond}, slow, fast)
+	start := time.Now()
+	if _, err := p.ChainID(context.Background()); err != nil {
+		t.Fatalf("ChainID: %v", err)
+	}
+	if took := time.Since(start); took > 500*time.Millisecond {
+		t.Fatalf("hedged read took %v", took)
+	}
+	if s, f := slow.callCount(), fast.callCount(); s != 1 || f != 1 {
+		t.Errorf("calls = %d, %d", s, f)
+	}
+}
+
+func Test_SendTransaction(t *testing.T) {
+	a, b := &fakeClient{err: errDown}, &fakeClient{}
+	p := newTestPool(t, Config{}, a, b)
+	tx := types.NewTx(&types.LegacyTx{})
+	if err := p.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("SendTransaction: %v", err)
+	}
+	if a.sent != 1 || b.sent != 1 {
+		t.Errorf("sent = %d, %d, want both", a.sent, b.sent)
+	}
+	b.set(nil, errors.New("nonce too low"))
+	if err := p.SendTransaction(context.Background(), tx); err == nil || !strings.Contains(err.Error(), "nonce too low") {
+		t.Errorf("SendTransaction = %v", err)
+	}
+}
+
+func Test_Quorum(t *testing.T) {
+	a := &fakeClient{head: 12, result: []byte("x")}
+	b := &fakeClient{head: 10, result: []byte("x")}
+	c := &fakeClient{head: 11, result: []byte("lie")}
+	p := newTestPool(t, Config{}, a, b, c)
+	q := p.Quorum(2)
+	ctx := context.Background()
+
+	// The head is read from the first two endpoints to answer, so a hung one is not waited for.
+	b.mu.Lock()
+	b.delay = time.Hour
+	b.mu.Unlock()
+	head, err := q.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatalf("HeaderByNumber: %v", err)
+	}
+	// Both a and c have block 11; only a has 12.
+	if head.Number.Uint64() != 11 {
+		t.Fatalf("quorum head = %d, want 11", head.Number)
+	}
+	b.mu.Lock()
+	b.delay = 0
+	b.mu.Unlock()
+
+	out, err := q.CallContract(ctx, ethereum.CallMsg{}, nil)
+	if err != nil || string(out) != "x" {
+		t.Fatalf("CallContract = %q, %v", out, err)
+	}
+	// Which two heads come first decides the pin, but every endpoint reads at it.
+	pinned := map[uint64]bool{}
+	for _, fc := range []*fakeClient{a, b, c} {
+		fc.mu.Lock()
+		if len(fc.callBlocks) > 0 {
+			pinned[fc.callBlocks[0]] = true
+		}
+		fc.mu.Unlock()
+	}
+	if len(pinned) != 1 || pinned[12] {
+		t.Errorf("calls pinned to blocks %v, want one block two endpoints have", pinned)
+	}
+
+	a.set([]byte("y"), nil)
+	if _, err := q.CallContract(ctx, ethereum.CallMsg{}, big.NewInt(10)); !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("CallContract with no two agreeing = %v, want %v", err, ErrNoQuorum)
+	}
+	a.set([]byte("x"), errDown)
+	if _, err := q.CallContract(ctx, ethereum.CallMsg{}, big.NewInt(10)); !errors.Is(err, ErrNoQuorum) || !strings.Contains(err.Error(), errDown.Error()) {
+		t.Fatalf("CallContract with one endpoint down = %v", err)
+	}
+
+	// A quorum the pool cannot reach fails rather than being lowered.
+	if _, err := p.Quorum(4).CallContract(ctx, ethereum.CallMsg{}, big.NewInt(10)); !errors.Is(err, ErrNoQuorum) {
+		t.Errorf("Quorum(4) over 3 endpoints = %v, want %v", err, ErrNoQuorum)
+	}
+	var _ interface {
+		CodeAt(context.Context, common.Address, *big.Int) ([]byte, error)
+	} = q
+}
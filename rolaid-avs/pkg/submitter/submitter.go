@@ -0,0 +1,721 @@
+// Package submitter sends contract calls from one account and follows them to finality.
+//
+// Every call is saved to a Store when it is enqueued, and every signed transaction is
+// saved before it is broadcast, so a crash loses nothing: on restart queued calls are
+// sent and pending transactions are broadcast again. Nonces are assigned locally in
+// enqueue order, after gas estimation, so a call that would revert is failed without
+// using one.
+//
+// Transactions are EIP-1559, priced at twice the base fee plus the suggested tip. One not
+// mined within BumpAfter is replaced at the same nonce with both fees raised by at least
+// BumpPercent. A mined transaction is final once it has Confirmations blocks on top, or,
+// when Confirmations is zero, once the chain's finalized block reaches it. A receipt that
+// disappears in a reorg puts the transaction back to pending, and one whose nonce another
+// transaction used is failed only once that nonce is final.
+package submitter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Defaults for zero Config fields.
+const (
+	DefaultGasMarginPercent = 20
+	DefaultBumpAfter        = time.Minute
+	DefaultBumpPercent      = 15
+	DefaultPollInterval     = 4 * time.Second
+)
+
+// minBumpPercent is the smallest fee increase nodes accept for a replacement transaction.
+const minBumpPercent = 10
+
+// ErrFeeCapReached is reported when a stuck transaction cannot be bumped without
+// exceeding Config.MaxFeePerGas.
+var ErrFeeCapReached = errors.New("fee bump exceeds max fee per gas")
+
+// Status is where a transaction is in its life.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"    // saved, not sent yet
+	StatusPending   Status = "pending"   // sent, not final yet
+	StatusFinalized Status = "finalized" // succeeded in a final block
+	StatusReverted  Status = "reverted"  // reverted in a final block
+	StatusFailed    Status = "failed"    // never mined: reverts in estimation, or its nonce was used elsewhere
+)
+
+// Backend is the subset of an RPC client the submitter uses. ethclient.Client implements it.
+type Backend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Request is a contract call to send. ID is the caller's key for it: enqueueing an ID
+// again returns the existing transaction, unless it failed or reverted, which the new
+// call replaces.
+type Request struct {
+	ID    string
+	To    common.Address
+	Data  []byte
+	Value *big.Int
+}
+
+// Attempt is one signed transaction sent at a Tx's nonce.
+type Attempt struct {
+	Hash      common.Hash   `json:"hash"`
+	Raw       hexutil.Bytes `json:"raw"`
+	GasTipCap *big.Int      `json:"gas_tip_cap"`
+	GasFeeCap *big.Int      `json:"gas_fee_cap"`
+	SentAt    time.Time     `json:"sent_at"`
+}
+
+// Receipt is the outcome of the attempt that was mined.
+type Receipt struct {
+	TxHash      common.Hash `json:"tx_hash"`
+	BlockNumber uint64      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+	Success     bool        `json:"success"`
+	GasUsed     uint64      `json:"gas_used"`
+}
+
+// Tx is a call and the transactions sent for it.
+type Tx struct {
+	ID     string         `json:"id"`
+	To     common.Address `json:"to"`
+	Data   hexutil.Bytes  `json:"data"`
+	Value  *big.Int       `json:"value"`
+	Status Status         `json:"status"`
+	Nonce  uint64         `json:"nonce"` // set once sent
+	Gas    uint64         `json:"gas"`
+	// Attempts are the transactions signed at Nonce, oldest first.
+	Attempts []Attempt `json:"attempts,omitempty"`
+	// Mined is set while one of the attempts is in the chain.
+	Mined   *Receipt  `json:"mined,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// Final reports whether the transaction has reached a status it does not leave.
+func (t *Tx) Final() bool {
+	switch t.Status {
+	case StatusFinalized, StatusReverted, StatusFailed:
+		return true
+	}
+	return false
+}
+
+func (t *Tx) clone() Tx {
+	c := *t
+	c.Data = append(hexutil.Bytes(nil), t.Data...)
+	c.Value = new(big.Int).Set(t.Value)
+	c.Attempts = append([]Attempt(nil), t.Attempts...)
+	if t.Mined != nil {
+		m := *t.Mined
+		c.Mined = &m
+	}
+	return c
+}
+
+// Store persists transactions by ID.
+type Store interface {
+	Put(tx *Tx) error
+	// All returns every saved transaction.
+	All() ([]*Tx, error)
+}
+
+// Config configures a Submitter.
+type Config struct {
+	Key     *ecdsa.PrivateKey // signs and pays for every transaction
+	ChainID *big.Int
+	// GasMarginPercent is added to the gas estimate.
+	GasMarginPercent uint64
+	// MaxFeePerGas caps the fee cap of every transaction; nil leaves it uncapped.
+	MaxFeePerGas *big.Int
+	BumpAfter    time.Duration
+	// BumpPercent raises both fees of a replacement; at least 10, which nodes require.
+	BumpPercent uint64
+	// Confirmations is how many blocks, counting its own, make a transaction final. Zero
+	// waits for the chain's finalized block instead.
+	Confirmations uint64
+	PollInterval  time.Duration
+	// OnUpdate is told of every change to a transaction, with a copy of it.
+	OnUpdate func(Tx)
+	// OnError is told about failed syncs; the submitter retries.
+	OnError func(error)
+}
+
+// Submitter sends and tracks transactions from the account of Config.Key.
+type Submitter struct {
+	backend Backend
+	store   Store
+	cfg     Config
+	from    common.Address
+	signer  types.Signer
+	now     func() time.Time
+
+	mu  sync.Mutex
+	txs map[string]*Tx
+	// nextNonce is the nonce of the next transaction, once read from the chain.
+	nextNonce  uint64
+	nonceKnown bool
+	// sent records the pending transactions broadcast since start.
+	sent map[string]bool
+}
+
+// New checks cfg, fills in defaults and loads the saved transactions.
+func New(backend Backend, store Store, cfg Config) (*Submitter, error) {
+	if backend == nil || store == nil {
+		return nil, fmt.Errorf("submitter: backend and store are required")
+	}
+	if cfg.Key == nil || cfg.ChainID == nil {
+		return nil, fmt.Errorf("submitter: key and chain id are required")
+	}
+	if cfg.GasMarginPercent == 0 {
+		cfg.GasMarginPercent = DefaultGasMarginPercent
+	}
+	if cfg.BumpAfter <= 0 {
+		cfg.BumpAfter = DefaultBumpAfter
+	}
+	if cfg.BumpPercent == 0 {
+		cfg.BumpPercent = DefaultBumpPercent
+	}
+	if cfg.BumpPercent < minBumpPercent {
+		return nil, fmt.Errorf("submitter: bump percent %d below the %d nodes require", cfg.BumpPercent, minBumpPercent)
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	saved, err := store.All()
+	if err != nil {
+		return nil, fmt.Errorf("submitter: load: %w", err)
+	}
+	s := &Submitter{
+		backend: backend,
+		store:   store,
+		cfg:     cfg,
+		from:    crypto.PubkeyToAddress(cfg.Key.PublicKey),
+		signer:  types.LatestSignerForChainID(cfg.ChainID),
+		now:     time.Now,
+		txs:     make(map[string]*Tx, len(saved)),
+		sent:    make(map[string]bool),
+	}
+	for _, t := range saved {
+		s.txs[t.ID] = t
+	}
+	return s, nil
+}
+
+// From returns the sending account.
+func (s *Submitter) From() common.Address { return s.from }
+
+// Enqueue saves a call to be sent on the next sync. A failed or reverted transaction
+// under the same ID is replaced, so one bad call does not hold the ID.
+func (s *Submitter) Enqueue(req Request) (Tx, error) {
+	if req.ID == "" {
+		return Tx{}, fmt.Errorf("submitter: request id missing")
+	}
+	value := new(big.Int)
+	if req.Value != nil {
+		if req.Value.Sign() < 0 {
+			return Tx{}, fmt.Errorf("submitter: negative value")
+		}
+		value.Set(req.Value)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.txs[req.ID]; ok && t.Status != StatusFailed && t.Status != StatusReverted {
+		return t.clone(), nil
+	}
+	now := s.now()
+	t := &Tx{
+		ID:      req.ID,
+		To:      req.To,
+		Data:    append(hexutil.Bytes(nil), req.Data...),
+		Value:   value,
+		Status:  StatusQueued,
+		Created: now,
+		Updated: now,
+	}
+	if err := s.store.Put(t); err != nil {
+		return Tx{}, err
+	}
+	s.txs[t.ID] = t
+	s.notify(t)
+	return t.clone(), nil
+}
+
+// Get returns a copy of the transaction saved under id.
+func (s *Submitter) Get(id string) (Tx, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.txs[id]
+	if !ok {
+		return Tx{}, false
+	}
+	return t.clone(), true
+}
+
+// List returns copies of every transaction, oldest first.
+func (s *Submitter) List() []Tx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Tx, 0, len(s.txs))
+	for _, t := range s.txs {
+		out = append(out, t.clone())
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Created.Equal(out[j].Created) {
+			return out[i].Created.Before(out[j].Created)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Run syncs every poll interval until ctx is done.
+func (s *Submitter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil && s.cfg.OnError != nil {
+			s.cfg.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync advances every transaction once: pending ones are checked for receipts, finality
+// and bumping, then queued ones are sent in enqueue order. Enqueue waits for a running
+// sync.
+func (s *Submitter) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending, queued []*Tx
+	for _, t := range s.txs {
+		switch t.Status {
+		case StatusPending:
+			pending = append(pending, t)
+		case StatusQueued:
+			queued = append(queued, t)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Nonce < pending[j].Nonce })
+	sort.Slice(queued, func(i, j int) bool {
+		if !queued[i].Created.Equal(queued[j].Created) {
+			return queued[i].Created.Before(queued[j].Created)
+		}
+		return queued[i].ID < queued[j].ID
+	})
+
+	var errs []error
+	for _, t := range pending {
+		if err := s.track(ctx, t); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.ID, err))
+		}
+	}
+	for _, t := range queued {
+		// Later calls wait, so nonces keep enqueue order.
+		if err := s.send(ctx, t); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.ID, err))
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send prices, signs and broadcasts a queued call.
+func (s *Submitter) send(ctx context.Context, t *Tx) error {
+	if !s.nonceKnown {
+		n, err := s.backend.PendingNonceAt(ctx, s.from)
+		if err != nil {
+			return fmt.Errorf("pending nonce: %w", err)
+		}
+		for _, o := range s.txs {
+			if len(o.Attempts) > 0 && o.Nonce >= n {
+				n = o.Nonce + 1
+			}
+		}
+		s.nextNonce, s.nonceKnown = n, true
+	}
+	gas, err := s.backend.EstimateGas(ctx, ethereum.CallMsg{From: s.from, To: &t.To, Value: t.Value, Data: t.Data})
+	if err != nil {
+		if isRevert(err) {
+			return s.update(t, func(t *Tx) {
+				t.Status = StatusFailed
+				t.Error = fmt.Sprintf("estimate gas: %v", err)
+			})
+		}
+		return fmt.Errorf("estimate gas: %w", err)
+	}
+	tip, feeCap, err := s.fees(ctx, nil)
+	if err != nil {
+		return err
+	}
+	nonce := s.nextNonce
+	gas += gas * s.cfg.GasMarginPercent / 100
+	attempt, err := s.sign(t, nonce, gas, tip, feeCap)
+	if err != nil {
+		return err
+	}
+	// Saved before it is broadcast, so a crash cannot leave a transaction we forgot.
+	err = s.update(t, func(t *Tx) {
+		t.Status = StatusPending
+		t.Nonce = nonce
+		t.Gas = gas
+		t.Attempts = append(t.Attempts, attempt)
+	})
+	if err != nil {
+		return err
+	}
+	s.nextNonce++
+	return s.broadcast(ctx, t)
+}
+
+// track checks a pending transaction for a receipt, finality, a lost nonce and bumping.
+func (s *Submitter) track(ctx context.Context, t *Tx) error {
+	receipt, err := s.receipt(ctx, t)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		if t.Mined != nil {
+			// Reorged out: send it again.
+			s.sent[t.ID] = false
+			if err := s.update(t, func(t *Tx) { t.Mined = nil }); err != nil {
+				return err
+			}
+		}
+		return s.unmined(ctx, t)
+	}
+	if t.Mined == nil || *t.Mined != *receipt {
+		if err := s.update(t, func(t *Tx) { t.Mined = receipt }); err != nil {
+			return err
+		}
+	}
+	final, err := s.final(ctx, receipt.BlockNumber)
+	if err != nil || !final {
+		return err
+	}
+	return s.update(t, func(t *Tx) {
+		t.Status = StatusFinalized
+		if !receipt.Success {
+			t.Status = StatusReverted
+		}
+	})
+}
+
+// unmined handles a pending transaction none of whose attempts is in the chain.
+func (s *Submitter) unmined(ctx context.Context, t *Tx) error {
+	latest, err := s.backend.NonceAt(ctx, s.from, nil)
+	if err != nil {
+		return fmt.Errorf("nonce: %w", err)
+	}
+	if latest > t.Nonce {
+		// The nonce is used, but the receipts may have been read from a node behind the
+		// one that answered: fail only once a final block has the nonce used and none of
+		// the attempts turns up on a second look.
+		final, err := s.finalNonce(ctx)
+		if err != nil || final <= t.Nonce {
+			return err
+		}
+		receipt, err := s.receipt(ctx, t)
+		if err != nil || receipt != nil {
+			return err // mined after all; the next sync tracks it
+		}
+		return s.update(t, func(t *Tx) {
+			t.Status = StatusFailed
+			t.Error = fmt.Sprintf("nonce %d used by another transaction", t.Nonce)
+		})
+	}
+	last := t.Attempts[len(t.Attempts)-1]
+	if s.now().Sub(last.SentAt) < s.cfg.BumpAfter {
+		if !s.sent[t.ID] {
+			return s.broadcast(ctx, t)
+		}
+		return nil
+	}
+	tip, feeCap, err := s.fees(ctx, &last)
+	if err != nil {
+		return err
+	}
+	attempt, err := s.sign(t, t.Nonce, t.Gas, tip, feeCap)
+	if err != nil {
+		return err
+	}
+	if err := s.update(t, func(t *Tx) { t.Attempts = append(t.Attempts, attempt) }); err != nil {
+		return err
+	}
+	return s.broadcast(ctx, t)
+}
+
+// receipt returns the receipt of whichever attempt is in the chain, or nil.
+func (s *Submitter) receipt(ctx context.Context, t *Tx) (*Receipt, error) {
+	for i := len(t.Attempts) - 1; i >= 0; i-- {
+		r, err := s.backend.TransactionReceipt(ctx, t.Attempts[i].Hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receipt: %w", err)
+		}
+		return &Receipt{
+			TxHash:      r.TxHash,
+			BlockNumber: r.BlockNumber.Uint64(),
+			BlockHash:   r.BlockHash,
+			Success:     r.Status == types.ReceiptStatusSuccessful,
+			GasUsed:     r.GasUsed,
+		}, nil
+	}
+	return nil, nil
+}
+
+// final reports whether a transaction mined in block is final.
+func (s *Submitter) final(ctx context.Context, block uint64) (bool, error) {
+	if s.cfg.Confirmations > 0 {
+		head, err := s.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return false, fmt.Errorf("head: %w", err)
+		}
+		return head.Number.Uint64()+1 >= block+s.cfg.Confirmations, nil
+	}
+	finalized, err := s.backend.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return false, fmt.Errorf("finalized block: %w", err)
+	}
+	return finalized.Number.Uint64() >= block, nil
+}
+
+// finalNonce returns the sender's nonce at the latest final block.
+func (s *Submitter) finalNonce(ctx context.Context) (uint64, error) {
+	var block *big.Int
+	if s.cfg.Confirmations > 0 {
+		head, err := s.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return 0, fmt.Errorf("head: %w", err)
+		}
+		if head.Number.Uint64()+1 < s.cfg.Confirmations {
+			return 0, nil
+		}
+		block = new(big.Int).SetUint64(head.Number.Uint64() + 1 - s.cfg.Confirmations)
+	} else {
+		finalized, err := s.backend.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		if err != nil {
+			return 0, fmt.Errorf("finalized block: %w", err)
+		}
+		block = finalized.Number
+	}
+	n, err := s.backend.NonceAt(ctx, s.from, block)
+	if err != nil {
+		return 0, fmt.Errorf("final nonce: %w", err)
+	}
+	return n, nil
+}
+
+// fees prices a transaction, or the replacement of prev when it is set.
+func (s *Submitter) fees(ctx context.Context, prev *Attempt) (tip, feeCap *big.Int, err error) {
+	tip, err = s.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gas tip: %w", err)
+	}
+	head, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("head: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, fmt.Errorf("chain has no base fee")
+	}
+	feeCap = new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+	if prev != nil {
+		tip = maxBig(tip, bump(prev.GasTipCap, s.cfg.BumpPercent))
+		feeCap = maxBig(feeCap, bump(prev.GasFeeCap, s.cfg.BumpPercent))
+	}
+	feeCap = maxBig(feeCap, tip)
+	if max := s.cfg.MaxFeePerGas; max != nil && feeCap.Cmp(max) > 0 {
+		if prev != nil {
+			return nil, nil, ErrFeeCapReached
+		}
+		feeCap = new(big.Int).Set(max)
+		if tip.Cmp(feeCap) > 0 {
+			tip = new(big.Int).Set(feeCap)
+		}
+	}
+	return tip, feeCap, nil
+}
+
+// bump raises v by percent, rounding up.
+func bump(v *big.Int, percent uint64) *big.Int {
+	n := new(big.Int).Mul(v, new(big.Int).SetUint64(100+percent))
+	n.Add(n, big.NewInt(99))
+	return n.Div(n, big.NewInt(100))
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func (s *Submitter) sign(t *Tx, nonce, gas uint64, tip, feeCap *big.Int) (Attempt, error) {
+	to := t.To
+	tx, err := types.SignNewTx(s.cfg.Key, s.signer, &types.DynamicFeeTx{
+		ChainID:   s.cfg.ChainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &to,
+		Value:     t.Value,
+		Data:      t.Data,
+	})
+	if err != nil {
+		return Attempt{}, fmt.Errorf("sign: %w", err)
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return Attempt{}, err
+	}
+	return Attempt{Hash: tx.Hash(), Raw: raw, GasTipCap: tip, GasFeeCap: feeCap, SentAt: s.now()}, nil
+}
+
+// broadcast sends the latest attempt. Nodes that already have it, or have already mined
+// the nonce, are not an error: the next sync reads the outcome.
+func (s *Submitter) broadcast(ctx context.Context, t *Tx) error {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(t.Attempts[len(t.Attempts)-1].Raw); err != nil {
+		return err
+	}
+	if err := s.backend.SendTransaction(ctx, &tx); err != nil && !alreadySent(err) {
+		return fmt.Errorf("send %s: %w", tx.Hash().Hex(), err)
+	}
+	s.sent[t.ID] = true
+	return nil
+}
+
+// update applies fn to t and saves it, leaving t unchanged if the save fails.
+func (s *Submitter) update(t *Tx, fn func(*Tx)) error {
+	old := t.clone()
+	fn(t)
+	t.Updated = s.now()
+	if err := s.store.Put(t); err != nil {
+		*t = old
+		return fmt.Errorf("save: %w", err)
+	}
+	s.notify(t)
+	return nil
+}
+
+func (s *Submitter) notify(t *Tx) {
+	if s.cfg.OnUpdate != nil {
+		s.cfg.OnUpdate(t.clone())
+	}
+}
+
+func isRevert(err error) bool {
+	var de rpc.DataError
+	return errors.As(err, &de) || strings.Contains(err.Error(), "execution reverted")
+}
+
+func alreadySent(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "nonce too low")
+}
+
+var txBucket = []byte("submitter_txs")
+
+// BoltStore persists transactions in a bbolt database, as JSON keyed by ID.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore creates the transaction bucket in db if needed.
+func NewBoltStore(db *bolt.DB) (*BoltStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(txBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create submitter bucket: %w", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Put(t *Tx) error {
+	v, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(txBucket).Put([]byte(t.ID), v)
+	})
+}
+
+func (s *BoltStore) All() ([]*Tx, error) {
+	var out []*Tx
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(txBucket).ForEach(func(k, v []byte) error {
+			var t Tx
+			if err := json.Unmarshal(v, &t); err != nil {
+				return fmt.Errorf("transaction %s: %w", k, err)
+			}
+			out = append(out, &t)
+			return nil
+		})
+	})
+	return out, err
+}
+
+// MemoryStore keeps transactions in memory, for tests and for running without a data dir.
+type MemoryStore struct {
+	mu  sync.Mutex
+	txs map[string]Tx
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{txs: make(map[string]Tx)}
+}
+
+func (s *MemoryStore) Put(t *Tx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs[t.ID] = t.clone()
+	return nil
+}
+
+func (s *MemoryStore) All() ([]*Tx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*Tx, 0, len(s.txs))
+	for _, t := range s.txs {
+		c := t.clone()
+		out = append(out, &c)
+	}
+	return out, nil
+}
@@ -0,0 +1,385 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	bolt "go.etcd.io/bbolt"
+)
+
+var target = common.HexToAddress("0xc0")
+
+// node is an in-memory chain for one sender.
+type node struct {
+	head, finalized uint64
+	baseFee, tip    *big.Int
+	// nonce is the sender's latest mined nonce count, and finalNonce its count at any
+	// block asked for by number.
+	nonce, finalNonce uint64
+	// lag makes the next lag receipt lookups miss, as a node behind the chain would.
+	lag      int
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	// estimateErr makes EstimateGas fail, when set.
+	estimateErr error
+}
+
+func newNode() *node {
+	return &node{head: 100, baseFee: big.NewInt(10), tip: big.NewInt(2), receipts: make(map[common.Hash]*types.Receipt)}
+}
+
+func (n *node) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return n.nonce, nil
+}
+
+func (n *node) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if blockNumber != nil {
+		return n.finalNonce, nil
+	}
+	return n.nonce, nil
+}
+
+func (n *node) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if n.estimateErr != nil {
+		return 0, n.estimateErr
+	}
+	return 50_000, nil
+}
+
+func (n *node) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(n.tip), nil
+}
+
+func (n *node) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number != nil && number.Int64() == int64(rpc.FinalizedBlockNumber) {
+		return &types.Header{Number: new(big.Int).SetUint64(n.finalized)}, nil
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(n.head), BaseFee: new(big.Int).Set(n.baseFee)}, nil
+}
+
+func (n *node) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	n.sent = append(n.sent, tx)
+	return nil
+}
+
+func (n *node) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if n.lag > 0 {
+		n.lag--
+		return nil, ethereum.NotFound
+	}
+	if r, ok := n.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// mine includes tx in the next block.
+func (n *node) mine(tx *types.Transaction, success bool) {
+	n.head++
+	status := types.ReceiptStatusSuccessful
+	if !success {
+		status = types.ReceiptStatusFailed
+	}
+	n.receipts[tx.Hash()] = &types.Receipt{
+		TxHash: tx.Hash(), BlockNumber: new(big.Int).SetUint64(n.head), BlockHash: common.BigToHash(new(big.Int).SetUint64(n.head)),
+		Status: status, GasUsed: 40_000,
+	}
+	n.nonce = tx.Nonce() + 1
+}
+
+// reorg drops every receipt and mined nonce.
+func (n *node) reorg() {
+	n.receipts = make(map[common.Hash]*types.Receipt)
+	n.nonce = 0
+}
+
+func (n *node) last(t *testing.T) *types.Transaction {
+	t.Helper()
+	if len(n.sent) == 0 {
+		t.Fatal("nothing sent")
+	}
+	return n.sent[len(n.sent)-1]
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestSubmitter(t *testing.T, n *node, store Store, cfg Config) (*Submitter, *clock) {
+	t.Helper()
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Key, cfg.ChainID = key, big.NewInt(31337)
+	s, err := New(n, store, cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c := &clock{t: time.Unix(1_700_000_000, 0)}
+	s.now = c.now
+	return s, c
+}
+
+func syncOnce(t *testing.T, s *Submitter) {
+	t.Helper()
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+}
+
+func status(t *testing.T, s *Submitter, id string) Tx {
+	t.Helper()
+	tx, ok := s.Get(id)
+	if !ok {
+		t.Fatalf("%s missing", id)
+	}
+	return tx
+}
+
+func Test_SendAndFinalize(t *testing.T) {
+	n := newNode()
+	var updates []Status
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{Confirmations: 3, OnUpdate: func(tx Tx) { updates = append(updates, tx.Status) }})
+
+	for _, id := range []string{"a", "b"} {
+		if _, err := s.Enqueue(Request{ID: id, To: target, Data: []byte(id), Value: big.NewInt(5)}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	if _, err := s.Enqueue(Request{ID: "a", To: target}); err != nil {
+		t.Fatalf("Enqueue again: %v", err)
+	}
+	syncOnce(t, s)
+	if len(n.sent) != 2 {
+		t.Fatalf("sent %d transactions, want 2", len(n.sent))
+	}
+	first := n.sent[0]
+	if first.Nonce() != 0 || n.sent[1].Nonce() != 1 || string(first.Data()) != "a" || first.Value().Int64() != 5 {
+		t.Fatalf("first transaction nonce %d data %q value %v", first.Nonce(), first.Data(), first.Value())
+	}
+	if first.Gas() != 60_000 || first.GasTipCap().Int64() != 2 || first.GasFeeCap().Int64() != 22 {
+		t.Errorf("gas %d tip %v fee cap %v, want 60000, 2, 22", first.Gas(), first.GasTipCap(), first.GasFeeCap())
+	}
+	if from, err := types.Sender(s.signer, first); err != nil || from != s.From() {
+		t.Errorf("sender = %s, %v", from.Hex(), err)
+	}
+
+	n.mine(first, true)
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusPending || tx.Mined == nil || tx.Mined.BlockNumber != 101 {
+		t.Fatalf("a after mining = %+v", tx)
+	}
+	n.head += 2
+	n.mine(n.sent[1], false)
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusFinalized || !tx.Final() {
+		t.Errorf("a = %s, want finalized", tx.Status)
+	}
+	if tx := status(t, s, "b"); tx.Status != StatusPending {
+		t.Errorf("b = %s, want pending", tx.Status)
+	}
+	n.head += 2
+	syncOnce(t, s)
+	if tx := status(t, s, "b"); tx.Status != StatusReverted {
+		t.Errorf("b = %s, want reverted", tx.Status)
+	}
+	if len(updates) == 0 || updates[0] != StatusQueued {
+		t.Errorf("updates = %v", updates)
+	}
+	if l := s.List(); len(l) != 2 || l[0].ID != "a" {
+		t.Errorf("List = %+v", l)
+	}
+}
+
+func Test_FinalizedTag(t *testing.T) {
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{})
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	n.mine(n.last(t), true)
+	n.head += 100
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusPending {
+		t.Fatalf("a = %s before the finalized block reached it", tx.Status)
+	}
+	n.finalized = 101
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusFinalized {
+		t.Fatalf("a = %s, want finalized", tx.Status)
+	}
+}
+
+func Test_Bump(t *testing.T) {
+	n := newNode()
+	s, c := newTestSubmitter(t, n, NewMemoryStore(), Config{BumpAfter: time.Minute, MaxFeePerGas: big.NewInt(29)})
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	c.advance(30 * time.Second)
+	syncOnce(t, s)
+	if len(n.sent) != 1 {
+		t.Fatalf("bumped after 30s: %d sends", len(n.sent))
+	}
+
+	c.advance(time.Minute)
+	syncOnce(t, s)
+	if len(n.sent) != 2 {
+		t.Fatalf("sent %d transactions, want a replacement", len(n.sent))
+	}
+	orig, repl := n.sent[0], n.sent[1]
+	if repl.Nonce() != orig.Nonce() || repl.GasTipCap().Int64() != 3 || repl.GasFeeCap().Int64() != 26 {
+		t.Fatalf("replacement nonce %d tip %v fee cap %v, want 0, 3, 26", repl.Nonce(), repl.GasTipCap(), repl.GasFeeCap())
+	}
+
+	c.advance(time.Minute)
+	if err := s.Sync(context.Background()); !errors.Is(err, ErrFeeCapReached) {
+		t.Fatalf("Sync = %v, want %v", err, ErrFeeCapReached)
+	}
+
+	// The original is mined after all.
+	n.mine(orig, true)
+	n.finalized = n.head
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusFinalized || tx.Mined.TxHash != orig.Hash() || len(tx.Attempts) != 2 {
+		t.Fatalf("a = %+v", tx)
+	}
+}
+
+func Test_Reorg(t *testing.T) {
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{Confirmations: 5})
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	n.mine(n.last(t), true)
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Mined == nil {
+		t.Fatal("receipt not recorded")
+	}
+
+	n.reorg()
+	syncOnce(t, s)
+	tx := status(t, s, "a")
+	if tx.Status != StatusPending || tx.Mined != nil {
+		t.Fatalf("a after reorg = %+v", tx)
+	}
+	if len(n.sent) != 2 || n.sent[1].Hash() != n.sent[0].Hash() {
+		t.Fatalf("sent %d transactions, want the original rebroadcast", len(n.sent))
+	}
+}
+
+func Test_EstimateRevert(t *testing.T) {
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{})
+	n.estimateErr = errors.New("execution reverted: AuctionNotFound")
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusFailed || tx.Error == "" {
+		t.Fatalf("a = %+v, want failed", tx)
+	}
+
+	// A failed call leaves no nonce gap.
+	n.estimateErr = nil
+	s.Enqueue(Request{ID: "b", To: target})
+	syncOnce(t, s)
+	if len(n.sent) != 1 || n.sent[0].Nonce() != 0 {
+		t.Fatalf("sent %d transactions", len(n.sent))
+	}
+
+	// An RPC failure is retried rather than failing the call.
+	n.estimateErr = errors.New("connection refused")
+	s.Enqueue(Request{ID: "c", To: target})
+	if err := s.Sync(context.Background()); err == nil {
+		t.Fatal("Sync hid the RPC error")
+	}
+	if tx := status(t, s, "c"); tx.Status != StatusQueued {
+		t.Fatalf("c = %s, want queued", tx.Status)
+	}
+
+	// The failed call does not hold its id.
+	if tx, err := s.Enqueue(Request{ID: "a", To: target, Data: []byte("again")}); err != nil || tx.Status != StatusQueued || string(tx.Data) != "again" {
+		t.Fatalf("Enqueue over failed a = %+v, %v", tx, err)
+	}
+}
+
+func Test_NonceTaken(t *testing.T) {
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{})
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	// Another transaction from the account is mined at the nonce.
+	n.nonce = 1
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusPending {
+		t.Fatalf("a = %s before the nonce was final, want pending", tx.Status)
+	}
+	n.finalNonce = 1
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusFailed {
+		t.Fatalf("a = %s, want failed", tx.Status)
+	}
+}
+
+func Test_LaggingReceipt(t *testing.T) {
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, NewMemoryStore(), Config{Confirmations: 5})
+	s.Enqueue(Request{ID: "a", To: target})
+	syncOnce(t, s)
+	n.mine(n.last(t), true)
+	n.finalNonce = 1
+
+	// The first receipt lookup misses although the nonce is used and final.
+	n.lag = 1
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Status != StatusPending {
+		t.Fatalf("a = %s after a lagging receipt, want pending", tx.Status)
+	}
+	syncOnce(t, s)
+	if tx := status(t, s, "a"); tx.Mined == nil || tx.Mined.TxHash != n.sent[0].Hash() {
+		t.Fatalf("a = %+v, want its receipt", tx)
+	}
+}
+
+func Test_Restart(t *testing.T) {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "state.db"), 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	n := newNode()
+	s, _ := newTestSubmitter(t, n, store, Config{})
+	s.Enqueue(Request{ID: "a", To: target, Value: big.NewInt(7)})
+	syncOnce(t, s)
+	s.Enqueue(Request{ID: "b", To: target})
+
+	// The node lost its mempool and never saw b.
+	n.sent = nil
+	s, _ = newTestSubmitter(t, n, store, Config{})
+	if tx := status(t, s, "a"); tx.Status != StatusPending || tx.Value.Int64() != 7 || len(tx.Attempts) != 1 {
+		t.Fatalf("a after restart = %+v", tx)
+	}
+	syncOnce(t, s)
+	if len(n.sent) != 2 {
+		t.Fatalf("sent %d transactions after restart, want 2", len(n.sent))
+	}
+	if n.sent[0].Nonce() != 0 || n.sent[1].Nonce() != 1 {
+		t.Errorf("nonces %d, %d, want 0, 1", n.sent[0].Nonce(), n.sent[1].Nonce())
+	}
+	syncOnce(t, s)
+	if len(n.sent) != 2 {
+		t.Errorf("rebroadcast again: %d sends", len(n.sent))
+	}
+}
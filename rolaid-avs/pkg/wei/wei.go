@@ -0,0 +1,159 @@
+// Package wei parses and bounds the wei amounts carried in task envelopes.
+//
+// Amounts are written as a decimal integer ("1000"), a 0x-prefixed hex integer ("0x3e8")
+// or a decimal number with a unit suffix ("0.001ether", "25 gwei", "7wei"). Every amount
+// must fit the uint96 AuctionService uses for bids, so a parsed Amount can be passed to
+// submitSettlement and recorded in results unchanged.
+package wei
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrSyntax   = errors.New("invalid wei amount")
+	ErrNegative = errors.New("wei amount is negative")
+	ErrOverflow = errors.New("wei amount exceeds uint96")
+)
+
+// MaxUint96 is the largest amount, 2^96 - 1 wei (about 79 billion ether).
+var MaxUint96 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(1))
+
+// units maps unit suffixes to their power of ten. Longer suffixes come first so
+// "gwei" is not read as "wei".
+var units = []struct {
+	suffix   string
+	decimals int
+}{
+	{"ether", 18},
+	{"gwei", 9},
+	{"eth", 18},
+	{"wei", 0},
+}
+
+// Amount is a wei amount in [0, MaxUint96]. The zero value is 0 wei.
+type Amount struct {
+	v *big.Int
+}
+
+// Parse reads an amount in any of the package's forms.
+func Parse(s string) (Amount, error) {
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return Amount{}, fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	if strings.HasPrefix(raw, "-") {
+		return Amount{}, fmt.Errorf("%w: %q", ErrNegative, s)
+	}
+
+	lower := strings.ToLower(raw)
+	var v *big.Int
+	switch {
+	case strings.HasPrefix(lower, "0x"):
+		if lower == "0x" || !onlyDigits(lower[2:], 16) {
+			return Amount{}, fmt.Errorf("%w: %q is not a hex integer", ErrSyntax, s)
+		}
+		v, _ = new(big.Int).SetString(lower[2:], 16)
+	default:
+		number, decimals := lower, 0
+		for _, u := range units {
+			if strings.HasSuffix(lower, u.suffix) {
+				number, decimals = strings.TrimSpace(strings.TrimSuffix(lower, u.suffix)), u.decimals
+				break
+			}
+		}
+		var err error
+		if v, err = scaleDecimal(number, decimals); err != nil {
+			return Amount{}, fmt.Errorf("%w: %q %v", ErrSyntax, s, err)
+		}
+	}
+	return FromBig(v)
+}
+
+// FromBig checks v is in range and wraps a copy of it.
+func FromBig(v *big.Int) (Amount, error) {
+	if v == nil {
+		return Amount{}, nil
+	}
+	if v.Sign() < 0 {
+		return Amount{}, fmt.Errorf("%w: %s", ErrNegative, v)
+	}
+	if v.Cmp(MaxUint96) > 0 {
+		return Amount{}, fmt.Errorf("%w: %s", ErrOverflow, v)
+	}
+	return Amount{v: new(big.Int).Set(v)}, nil
+}
+
+// scaleDecimal parses a non-negative decimal number and multiplies it by 10^decimals. The
+// result must be a whole number of wei.
+func scaleDecimal(number string, decimals int) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(number, ".")
+	if whole+frac == "" || !onlyDigits(whole, 10) || !onlyDigits(frac, 10) {
+		return nil, fmt.Errorf("is not a decimal number")
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("has a fraction of a wei")
+	}
+	// The leading zero keeps forms like ".5ether" valid.
+	v, _ := new(big.Int).SetString("0"+whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	return v, nil
+}
+
+// onlyDigits reports whether s holds only digits of the given base (10 or 16).
+func onlyDigits(s string, base int) bool {
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+		case base == 16 && c >= 'a' && c <= 'f':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Big returns the amount as a new big.Int.
+func (a Amount) Big() *big.Int {
+	if a.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.v)
+}
+
+// IsZero reports whether the amount is 0 wei.
+func (a Amount) IsZero() bool { return a.v == nil || a.v.Sign() == 0 }
+
+// Cmp compares two amounts like big.Int.Cmp.
+func (a Amount) Cmp(b Amount) int { return a.Big().Cmp(b.Big()) }
+
+// String returns the amount in decimal wei.
+func (a Amount) String() string { return a.Big().String() }
+
+// MarshalJSON encodes the amount as a decimal wei string, which survives JSON number
+// precision limits.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts any form Parse does, as a JSON string, or a JSON integer.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
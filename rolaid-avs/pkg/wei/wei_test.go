@@ -0,0 +1,99 @@
+package wei
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "1000", want: "1000"},
+		{in: "0", want: "0"},
+		{in: "0x3e8", want: "1000"},
+		{in: "0X3E8", want: "1000"},
+		{in: "0.001ether", want: "1000000000000000"},
+		{in: "1 ETH", want: "1000000000000000000"},
+		{in: ".5ether", want: "500000000000000000"},
+		{in: "25gwei", want: "25000000000"},
+		{in: "1.5 gwei", want: "1500000000"},
+		{in: "7wei", want: "7"},
+		{in: "1.000wei", want: "1"},
+		{in: "79228162514264337593543950335", want: "79228162514264337593543950335"},
+		{in: "0xffffffffffffffffffffffff", want: "79228162514264337593543950335"},
+
+		{in: "", wantErr: ErrSyntax},
+		{in: "0x", wantErr: ErrSyntax},
+		{in: "0x-1", wantErr: ErrSyntax},
+		{in: "1.5", wantErr: ErrSyntax},
+		{in: "0.1wei", wantErr: ErrSyntax},
+		{in: "1e18", wantErr: ErrSyntax},
+		{in: "ether", wantErr: ErrSyntax},
+		{in: "+5", wantErr: ErrSyntax},
+		{in: "1_000", wantErr: ErrSyntax},
+		{in: "-1", wantErr: ErrNegative},
+		{in: "-0.1ether", wantErr: ErrNegative},
+		{in: "79228162514264337593543950336", wantErr: ErrOverflow},
+		{in: "0x1000000000000000000000000", wantErr: ErrOverflow},
+		{in: "80000000000ether", wantErr: ErrOverflow},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("Parse(%q) = %v, %v; want %s", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFromBig(t *testing.T) {
+	if _, err := FromBig(big.NewInt(-1)); !errors.Is(err, ErrNegative) {
+		t.Fatalf("FromBig(-1) = %v, want ErrNegative", err)
+	}
+	if _, err := FromBig(new(big.Int).Add(MaxUint96, big.NewInt(1))); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("FromBig(2^96) = %v, want ErrOverflow", err)
+	}
+	src := big.NewInt(5)
+	a, err := FromBig(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.SetInt64(6)
+	if a.String() != "5" {
+		t.Fatalf("Amount aliases its source: %s", a)
+	}
+	var zero Amount
+	if !zero.IsZero() || zero.Big().Sign() != 0 || zero.Cmp(a) >= 0 {
+		t.Fatalf("zero value is not 0 wei")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A Amount `json:"a"`
+		B Amount `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": "0.001ether", "b": 42}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"a":"1000000000000000","b":"42"}` {
+		t.Fatalf("marshal = %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"a": "-1"}`), &v); !errors.Is(err, ErrNegative) {
+		t.Fatalf("unmarshal negative = %v, want ErrNegative", err)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.26;
+
+import {OwnableLite} from "./OwnableLite.sol";
+
+/// @notice Pays LP proceeds and insurance payouts (native ETH) from a Merkle distribution.
+/// Set it as the SettlementVault's lpSink or insuranceSink and publish roots built by
+/// rolaid-avs/pkg/merkle. Leaves use OpenZeppelin's StandardMerkleTree encoding,
+/// keccak256(bytes.concat(keccak256(abi.encode(account, cumulativeAmount)))). Amounts are
+/// cumulative per account, so each new root pays only what an account has not claimed yet.
+contract MerkleDistributor is OwnableLite {
+    bytes32 public merkleRoot;
+
+    mapping(address caller => bool allowed) public isAuthorized;
+    mapping(address account => uint256 amount) public claimed;
+
+    event Authorized(address indexed caller, bool allowed);
+    event RootUpdated(bytes32 indexed root);
+    event Funded(address indexed from, uint256 amount);
+    event Claimed(address indexed account, uint256 amount, uint256 cumulativeAmount);
+
+    modifier onlyAuthorized() {
+        require(isAuthorized[msg.sender] || msg.sender == owner, "not auth");
+        _;
+    }
+
+    receive() external payable {
+        emit Funded(msg.sender, msg.value);
+    }
+
+    function setAuthorized(address caller, bool allowed) external onlyOwner {
+        isAuthorized[caller] = allowed;
+        emit Authorized(caller, allowed);
+    }
+
+    function setRoot(bytes32 root) external onlyAuthorized {
+        merkleRoot = root;
+        emit RootUpdated(root);
+    }
+
+    /// @notice Pay account the part of cumulativeAmount it has not claimed yet. Anyone may
+    /// submit a claim; funds always go to the account in the leaf.
+    function claim(address payable account, uint256 cumulativeAmount, bytes32[] calldata proof) external {
+        require(verify(account, cumulativeAmount, proof), "bad proof");
+        uint256 already = claimed[account];
+        require(cumulativeAmount > already, "nothing to claim");
+        uint256 amount = cumulativeAmount - already;
+        claimed[account] = cumulativeAmount;
+        (bool ok, ) = account.call{value: amount}("");
+        require(ok, "send fail");
+        emit Claimed(account, amount, cumulativeAmount);
+    }
+
+    function leaf(address account, uint256 cumulativeAmount) public pure returns (bytes32) {
+        return keccak256(bytes.concat(keccak256(abi.encode(account, cumulativeAmount))));
+    }
+
+    /// @notice Check a proof against the current root, hashing pairs in sorted order like
+    /// OpenZeppelin's MerkleProof.
+    function verify(address account, uint256 cumulativeAmount, bytes32[] calldata proof) public view returns (bool) {
+        bytes32 node = leaf(account, cumulativeAmount);
+        for (uint256 i = 0; i < proof.length; i++) {
+            bytes32 sibling = proof[i];
+            node = node < sibling
+                ? keccak256(abi.encodePacked(node, sibling))
+                : keccak256(abi.encodePacked(sibling, node));
+        }
+        return node == merkleRoot;
+    }
+}
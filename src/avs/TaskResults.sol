@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.26;
+
+/// @notice ABI layout of ROLAID task results signed by AVS operators.
+/// Results are encoded as abi.encode(uint8 kind, uint8 version, bytes body), where body is
+/// abi.encode of the kind's result struct. Mirrors rolaid-avs/pkg/results; keep both in sync.
+library TaskResults {
+    uint8 internal constant KIND_AUCTION_SETTLEMENT = 1;
+    uint8 internal constant KIND_INSURANCE_PAYOUT = 2;
+    uint8 internal constant VERSION = 1;
+
+    /// @dev Claim models recorded in InsurancePayoutResult.modelSource.
+    uint8 internal constant MODEL_RULES = 1; // rule-based actuarial model, modelVersion is its version
+    uint8 internal constant MODEL_EIGENAI = 2; // EigenAI, modelVersion is the model name
+
+    /// @dev Commitment domains, mirroring rolaid-avs/pkg/commitment.
+    bytes32 internal constant AUCTION_SETTLEMENT_DOMAIN = keccak256("ROLAID_AUCTION_SETTLEMENT_V1");
+    bytes32 internal constant INSURANCE_PAYOUT_DOMAIN = keccak256("ROLAID_INSURANCE_PAYOUT_V1");
+    bytes32 internal constant PAYOUT_VECTOR_DOMAIN = keccak256("ROLAID_PAYOUT_VECTOR_V1");
+
+    struct AuctionSettlementResult {
+        uint256 auctionId;
+        bytes32 poolId;
+        bytes32 oracleUpdateId;
+        bytes32 commitment;
+        address auctionService;
+        uint96 bidAmount;
+        address winner; // bidder the settlement pays for, zero if the task named none
+        bytes32 distributionRoot; // Merkle root of the LP split, zero if none
+        uint256 lvrEstimate; // pool arbitrage profit at the oracle price in token1, zero if not estimated
+    }
+
+    struct InsurancePayoutResult {
+        string policyBatchId;
+        bytes32 payoutCommitment;
+        uint64 seed;
+        address settlementVault;
+        bytes32 vectorCommitment;
+        address[] beneficiaries;
+        uint256[] amounts;
+        bytes32 distributionRoot; // Merkle root over the payout vector, zero if empty
+        uint8 modelSource; // MODEL_RULES or MODEL_EIGENAI
+        string modelVersion;
+    }
+
+    error UnexpectedResult(uint8 kind, uint8 version);
+
+    function decodeAuctionSettlement(bytes memory result) internal pure returns (AuctionSettlementResult memory) {
+        return abi.decode(_body(result, KIND_AUCTION_SETTLEMENT), (AuctionSettlementResult));
+    }
+
+    function decodeInsurancePayout(bytes memory result) internal pure returns (InsurancePayoutResult memory) {
+        return abi.decode(_body(result, KIND_INSURANCE_PAYOUT), (InsurancePayoutResult));
+    }
+
+    /// @notice Recompute the auction commitment from the settlementHash stored by AuctionService.
+    /// Each part is prefixed with its 4-byte length after the domain separator.
+    function auctionCommitment(uint256 auctionId, bytes32 poolId, bytes32 oracleUpdateId, bytes32 settlementHash)
+        internal
+        pure
+        returns (bytes32)
+    {
+        return keccak256(
+            abi.encodePacked(
+                AUCTION_SETTLEMENT_DOMAIN,
+                uint32(32),
+                auctionId,
+                uint32(32),
+                poolId,
+                uint32(32),
+                oracleUpdateId,
+                uint32(32),
+                settlementHash
+            )
+        );
+    }
+
+    /// @notice Check a signed auction result against the settlement recorded onchain.
+    function matchesSettlement(AuctionSettlementResult memory r, bytes32 settlementHash)
+        internal
+        pure
+        returns (bool)
+    {
+        return r.commitment == auctionCommitment(r.auctionId, r.poolId, r.oracleUpdateId, settlementHash);
+    }
+
+    /// @notice Check a signed auction result against the bidAmount AuctionService recorded,
+    /// which submitSettlement requires to equal the value paid.
+    function matchesBid(AuctionSettlementResult memory r, uint96 bidAmount) internal pure returns (bool) {
+        return r.bidAmount == bidAmount;
+    }
+
+    /// @notice Check a signed auction result against the winner AuctionService recorded.
+    /// A result that names no winner matches none.
+    function matchesWinner(AuctionSettlementResult memory r, address winner) internal pure returns (bool) {
+        return r.winner != address(0) && r.winner == winner;
+    }
+
+    /// @notice Recompute the payout vector commitment: the vector length, then each
+    /// beneficiary and amount, every part prefixed with its 4-byte length.
+    function payoutVectorCommitment(address[] memory beneficiaries, uint256[] memory amounts)
+        internal
+        pure
+        returns (bytes32)
+    {
+        require(beneficiaries.length == amounts.length, "length mismatch");
+        bytes memory packed = abi.encodePacked(PAYOUT_VECTOR_DOMAIN, uint32(4), uint32(amounts.length));
+        for (uint256 i = 0; i < amounts.length; i++) {
+            packed = abi.encodePacked(packed, uint32(20), beneficiaries[i], uint32(32), amounts[i]);
+        }
+        return keccak256(packed);
+    }
+
+    /// @notice Check a signed insurance result's vector against its vector commitment.
+    function matchesPayoutVector(InsurancePayoutResult memory r) internal pure returns (bool) {
+        return r.beneficiaries.length == r.amounts.length
+            && r.vectorCommitment == payoutVectorCommitment(r.beneficiaries, r.amounts);
+    }
+
+    function _body(bytes memory result, uint8 wantKind) private pure returns (bytes memory body) {
+        uint8 kind;
+        uint8 version;
+        (kind, version, body) = abi.decode(result, (uint8, uint8, bytes));
+        if (kind != wantKind || version != VERSION) revert UnexpectedResult(kind, version);
+    }
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.26;
+
+import "forge-std/Test.sol";
+
+import {MerkleDistributor} from "../../src/avs/MerkleDistributor.sol";
+import {SettlementVault} from "../../src/avs/SettlementVault.sol";
+
+/// @dev Tree over (0xa1, 100), (0xb2, 200), (0xc3, 300), the same vector as TestTreeGolden
+/// in rolaid-avs/pkg/merkle.
+contract MerkleDistributorTest is Test {
+    MerkleDistributor distributor;
+
+    bytes32 constant ROOT = 0x265e8ffdb13c337235907025a451c66d8fbc5eb898d8ce2aab834a67b5c939e0;
+    address payable constant ALICE = payable(address(0xa1));
+    address payable constant BOB = payable(address(0xb2));
+    address payable constant CAROL = payable(address(0xc3));
+
+    function setUp() public {
+        distributor = new MerkleDistributor();
+        distributor.setRoot(ROOT);
+        vm.deal(address(distributor), 600);
+    }
+
+    function aliceProof() internal pure returns (bytes32[] memory proof) {
+        proof = new bytes32[](2);
+        proof[0] = 0x17232523fedc013e241e56612aaf7a684d13e02541747e5611d6a2e164bb6a0b;
+        proof[1] = 0xe2619ca8e7ef37c1d67399c9fb266214b55408292552d921384e816eeded8115;
+    }
+
+    function carolProof() internal pure returns (bytes32[] memory proof) {
+        proof = new bytes32[](1);
+        proof[0] = 0x7af74014198677f464d44df7c5cd9e245ba4e4f5e275d7170765d5f8d655e3d2;
+    }
+
+    function testClaimGoProofs() public {
+        distributor.claim(ALICE, 100, aliceProof());
+        distributor.claim(CAROL, 300, carolProof());
+
+        assertEq(ALICE.balance, 100);
+        assertEq(CAROL.balance, 300);
+        assertEq(distributor.claimed(ALICE), 100);
+        assertEq(address(distributor).balance, 200);
+    }
+
+    function testRejectsWrongAmount() public {
+        vm.expectRevert(bytes("bad proof"));
+        distributor.claim(ALICE, 101, aliceProof());
+    }
+
+    function testRejectsDoubleClaim() public {
+        distributor.claim(ALICE, 100, aliceProof());
+        vm.expectRevert(bytes("nothing to claim"));
+        distributor.claim(ALICE, 100, aliceProof());
+    }
+
+    function testOnlyAuthorizedSetsRoot() public {
+        vm.prank(address(0xdead));
+        vm.expectRevert(bytes("not auth"));
+        distributor.setRoot(bytes32(0));
+
+        distributor.setAuthorized(address(0xdead), true);
+        vm.prank(address(0xdead));
+        distributor.setRoot(bytes32(uint256(1)));
+        assertEq(distributor.merkleRoot(), bytes32(uint256(1)));
+    }
+
+    function testReceivesVaultProceeds() public {
+        SettlementVault vault = new SettlementVault(payable(address(distributor)), payable(address(0x2000)), 7000);
+        vault.setAuthorized(address(this), true);
+        vault.recordProceeds{value: 1000}(1000);
+        assertEq(address(distributor).balance, 600 + 700);
+    }
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.26;
+
+import "forge-std/Test.sol";
+
+import {TaskResults} from "../../src/avs/TaskResults.sol";
+
+contract TaskResultsHarness {
+    function decodeAuctionSettlement(bytes memory result)
+        external
+        pure
+        returns (TaskResults.AuctionSettlementResult memory)
+    {
+        return TaskResults.decodeAuctionSettlement(result);
+    }
+
+    function decodeInsurancePayout(bytes memory result)
+        external
+        pure
+        returns (TaskResults.InsurancePayoutResult memory)
+    {
+        return TaskResults.decodeInsurancePayout(result);
+    }
+}
+
+contract TaskResultsTest is Test {
+    TaskResultsHarness harness;
+
+    function setUp() public {
+        harness = new TaskResultsHarness();
+    }
+
+    function testDecodeAuctionSettlement() public view {
+        TaskResults.AuctionSettlementResult memory want = TaskResults.AuctionSettlementResult({
+            auctionId: 7,
+            poolId: bytes32(uint256(0x11)),
+            oracleUpdateId: bytes32(uint256(0x22)),
+            commitment: bytes32(uint256(0x33)),
+            auctionService: address(0xa1),
+            bidAmount: 1000,
+            winner: address(0xb2),
+            distributionRoot: bytes32(uint256(0x44)),
+            lvrEstimate: 5000
+        });
+        bytes memory result = abi.encode(TaskResults.KIND_AUCTION_SETTLEMENT, TaskResults.VERSION, abi.encode(want));
+
+        TaskResults.AuctionSettlementResult memory got = harness.decodeAuctionSettlement(result);
+        assertEq(got.auctionId, want.auctionId);
+        assertEq(got.poolId, want.poolId);
+        assertEq(got.oracleUpdateId, want.oracleUpdateId);
+        assertEq(got.commitment, want.commitment);
+        assertEq(got.auctionService, want.auctionService);
+        assertEq(got.bidAmount, want.bidAmount);
+        assertEq(got.winner, want.winner);
+        assertEq(got.distributionRoot, want.distributionRoot);
+        assertEq(got.lvrEstimate, want.lvrEstimate);
+    }
+
+    function testDecodeInsurancePayout() public view {
+        address[] memory beneficiaries = new address[](2);
+        beneficiaries[0] = address(0xa1);
+        beneficiaries[1] = address(0xb2);
+        uint256[] memory amounts = new uint256[](2);
+        amounts[0] = 500;
+        amounts[1] = 500;
+        TaskResults.InsurancePayoutResult memory want = TaskResults.InsurancePayoutResult({
+            policyBatchId: "batch-1",
+            payoutCommitment: bytes32(uint256(0x44)),
+            seed: 42,
+            settlementVault: address(0xb2),
+            vectorCommitment: TaskResults.payoutVectorCommitment(beneficiaries, amounts),
+            beneficiaries: beneficiaries,
+            amounts: amounts,
+            distributionRoot: bytes32(uint256(0x66)),
+            modelSource: TaskResults.MODEL_EIGENAI,
+            modelVersion: "gpt-oss-120b-f16"
+        });
+        bytes memory result = abi.encode(TaskResults.KIND_INSURANCE_PAYOUT, TaskResults.VERSION, abi.encode(want));
+
+        TaskResults.InsurancePayoutResult memory got = harness.decodeInsurancePayout(result);
+        assertEq(got.policyBatchId, want.policyBatchId);
+        assertEq(got.payoutCommitment, want.payoutCommitment);
+        assertEq(got.seed, want.seed);
+        assertEq(got.settlementVault, want.settlementVault);
+        assertEq(got.vectorCommitment, want.vectorCommitment);
+        assertEq(got.beneficiaries.length, 2);
+        assertEq(got.amounts[1], 500);
+        assertTrue(TaskResults.matchesPayoutVector(got));
+        assertEq(got.distributionRoot, want.distributionRoot);
+        assertEq(got.modelSource, TaskResults.MODEL_EIGENAI);
+        assertEq(got.modelVersion, want.modelVersion);
+    }
+
+    /// @dev Same vector as TestPayoutVectorGolden in rolaid-avs/pkg/commitment.
+    function testPayoutVectorCommitmentMatchesGo() public pure {
+        address[] memory beneficiaries = new address[](2);
+        beneficiaries[0] = address(0xa1);
+        beneficiaries[1] = address(0xb2);
+        uint256[] memory amounts = new uint256[](2);
+        amounts[0] = 500;
+        amounts[1] = 500;
+        assertEq(
+            TaskResults.payoutVectorCommitment(beneficiaries, amounts),
+            0x398e26ea5224690246d59e84f7e0c050cfc0032278c1335478c3f92cbc06becf
+        );
+    }
+
+    /// @dev Same vector as TestAuctionSettlementGolden in rolaid-avs/pkg/results.
+    function testDecodeGoEncodedAuctionSettlement() public view {
+        bytes memory result =
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"0000000000000000000000000000000000000000000000000000000000000060"
+            hex"0000000000000000000000000000000000000000000000000000000000000120"
+            hex"0000000000000000000000000000000000000000000000000000000000000007"
+            hex"0000000000000000000000000000000000000000000000000000000000000011"
+            hex"0000000000000000000000000000000000000000000000000000000000000022"
+            hex"0000000000000000000000000000000000000000000000000000000000000033"
+            hex"00000000000000000000000000000000000000000000000000000000000000a1"
+            hex"00000000000000000000000000000000000000000000000000000000000003e8"
+            hex"00000000000000000000000000000000000000000000000000000000000000b2"
+            hex"0000000000000000000000000000000000000000000000000000000000000044"
+            hex"0000000000000000000000000000000000000000000000000000000000001388";
+
+        TaskResults.AuctionSettlementResult memory got = harness.decodeAuctionSettlement(result);
+        assertEq(got.auctionId, 7);
+        assertEq(got.poolId, bytes32(uint256(0x11)));
+        assertEq(got.oracleUpdateId, bytes32(uint256(0x22)));
+        assertEq(got.commitment, bytes32(uint256(0x33)));
+        assertEq(got.auctionService, address(0xa1));
+        assertEq(got.bidAmount, 1000);
+        assertEq(got.winner, address(0xb2));
+        assertEq(got.distributionRoot, bytes32(uint256(0x44)));
+        assertEq(got.lvrEstimate, 5000);
+    }
+
+    /// @dev Same vector as TestAuctionSettlementGolden in rolaid-avs/pkg/commitment.
+    function testAuctionCommitmentMatchesGo() public pure {
+        bytes32 settlementHash = keccak256(hex"deadbeef");
+        bytes32 commitment =
+            TaskResults.auctionCommitment(7, bytes32(uint256(0x11)), bytes32(uint256(0x22)), settlementHash);
+        assertEq(commitment, 0xdddfaf49d19a0ae0807b10c78d6b83166e1308c0e7d178ca6647820df22bf050);
+
+        TaskResults.AuctionSettlementResult memory r = TaskResults.AuctionSettlementResult({
+            auctionId: 7,
+            poolId: bytes32(uint256(0x11)),
+            oracleUpdateId: bytes32(uint256(0x22)),
+            commitment: commitment,
+            auctionService: address(0xa1),
+            bidAmount: 1000,
+            winner: address(0xb2),
+            distributionRoot: bytes32(0),
+            lvrEstimate: 0
+        });
+        assertTrue(TaskResults.matchesSettlement(r, settlementHash));
+        assertFalse(TaskResults.matchesSettlement(r, keccak256(hex"deadbeee")));
+        assertTrue(TaskResults.matchesBid(r, 1000));
+        assertFalse(TaskResults.matchesBid(r, 999));
+        assertTrue(TaskResults.matchesWinner(r, address(0xb2)));
+        assertFalse(TaskResults.matchesWinner(r, address(0xb3)));
+        r.winner = address(0);
+        assertFalse(TaskResults.matchesWinner(r, address(0)));
+    }
+
+    function testRejectsWrongKind() public {
+        TaskResults.AuctionSettlementResult memory r;
+        bytes memory result = abi.encode(TaskResults.KIND_AUCTION_SETTLEMENT, TaskResults.VERSION, abi.encode(r));
+
+        vm.expectRevert(
+            abi.encodeWithSelector(
+                TaskResults.UnexpectedResult.selector, TaskResults.KIND_AUCTION_SETTLEMENT, TaskResults.VERSION
+            )
+        );
+        harness.decodeInsurancePayout(result);
+    }
+}